@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -181,6 +182,28 @@ func WithCustomTimeout(duration time.Duration) (context.Context, context.CancelF
 	return context.WithTimeout(context.Background(), duration)
 }
 
+// EstimateCount is the planner's estimate of the rows a query returns (built with GORM,
+// e.g. db.Model(&models.Product{}).Where(...) or db.Raw(...)), from the table statistics:
+// cheap next to COUNT(*) on large tables, close enough for "about N" totals
+func EstimateCount(query *gorm.DB) (int, error) {
+	var rows []map[string]interface{}
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(&rows).Statement
+
+	var plan string
+	if err := stmt.ConnPool.QueryRowContext(stmt.Context, "EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Scan(&plan); err != nil {
+		return 0, err
+	}
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explained); err != nil || len(explained) == 0 {
+		return 0, fmt.Errorf("failed to read query plan: %v", err)
+	}
+	return int(explained[0].Plan.Rows), nil
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
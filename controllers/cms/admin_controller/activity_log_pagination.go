@@ -0,0 +1,78 @@
+package admin_controller
+
+import (
+	"math"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"gorm.io/gorm"
+)
+
+// activityLogKeyset is the order of the activity log lists in cursor mode: newest first
+var activityLogKeyset = models.Keyset{Sort: "newest", KeySQL: "created_at", KeyType: "timestamptz", IDSQL: "id", Desc: true}
+
+// fetchActivityLogPage fetches a page of the activity logs query (on models.ActivityLog)
+// finds, newest first: by page, or after the cursor of a cursor request (nil for none), with
+// its pagination meta. Returns models.ErrInvalidCursor for a cursor it can't use.
+func fetchActivityLogPage(query *gorm.DB, page, limit int, request *models.CursorRequest) ([]models.ActivityLog, *models.Pagination, error) {
+	var activityLogs []models.ActivityLog
+	var total int64
+
+	if request == nil {
+		if err := query.Session(&gorm.Session{}).
+			Order("created_at DESC").
+			Limit(limit).
+			Offset((page - 1) * limit).
+			Find(&activityLogs).Error; err != nil {
+			return nil, nil, err
+		}
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, nil, err
+		}
+		return activityLogs, &models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		}, nil
+	}
+
+	// Cursor pagination: one more than the page tells whether there's a next one
+	pageQuery := query.Session(&gorm.Session{})
+	if request.Cursor != "" {
+		cursor, err := models.DecodeCursor(request.Cursor, activityLogKeyset.Sort)
+		if err != nil {
+			return nil, nil, err
+		}
+		pageQuery = pageQuery.Where(activityLogKeyset.AfterSQL(), activityLogKeyset.AfterArgs(cursor)...)
+	}
+	if err := pageQuery.
+		Order(activityLogKeyset.OrderSQL()).
+		Limit(limit + 1).
+		Find(&activityLogs).Error; err != nil {
+		return nil, nil, err
+	}
+
+	nextCursor := ""
+	if len(activityLogs) > limit {
+		activityLogs = activityLogs[:limit]
+		last := activityLogs[limit-1]
+		nextCursor = activityLogKeyset.NextCursor(last.CreatedAt.Format(time.RFC3339Nano), last.ID)
+	}
+
+	// Activity logs only grow; the estimate spares counting them on every page
+	if request.ExactTotal {
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, nil, err
+		}
+	} else {
+		estimate, err := config.EstimateCount(query.Session(&gorm.Session{}))
+		if err != nil {
+			return nil, nil, err
+		}
+		total = int64(estimate)
+	}
+
+	return activityLogs, models.CursorPagination(limit, int(total), !request.ExactTotal, nextCursor), nil
+}
@@ -1,8 +1,8 @@
 package admin_controller
 
 import (
+	"errors"
 	"log"
-	"math"
 	"net/http"
 	"strconv"
 
@@ -21,7 +21,10 @@ import (
 // @Param limit query int false "Items per page (default: 20, max: 100)"
 // @Param admin_id query string false "Filter by admin ID"
 // @Param action query string false "Filter by action (e.g., created_product, updated_order)"
+// @Param cursor query string false "Cursor pagination instead of page: empty for the first page, then meta.next_cursor"
+// @Param total query string false "With cursor: exact for a counted total instead of the planner's estimate" Enums(exact)
 // @Success 200 {object} models.ApiResponse{data=map[string]interface{}}
+// @Failure 400 {object} models.ApiResponse "Invalid cursor"
 // @Failure 401 {object} models.ApiResponse "Unauthorized"
 // @Router /admin/activity-logs [get]
 func GetAllAdminActivityLogs(c *gin.Context) {
@@ -45,13 +48,11 @@ func GetAllAdminActivityLogs(c *gin.Context) {
 		}
 	}
 
-	offset := (page - 1) * limit
-
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
 	// Build base query
-	baseQuery := config.CmsGorm.WithContext(ctx)
+	baseQuery := config.CmsGorm.WithContext(ctx).Model(&models.ActivityLog{})
 
 	// Optional filters
 	if adminID := c.Query("admin_id"); adminID != "" {
@@ -62,23 +63,14 @@ func GetAllAdminActivityLogs(c *gin.Context) {
 		baseQuery = baseQuery.Where("action = ?", action)
 	}
 
-	// Get activity logs
-	var activityLogs []models.ActivityLog
-	var total int64
-
-	if err := baseQuery.
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&activityLogs).Error; err != nil {
-		log.Printf("[admin.all-activity] failed to fetch logs: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Server error"))
+	// Get activity logs, by page or cursor (see models.CursorRequest)
+	activityLogs, meta, err := fetchActivityLogPage(baseQuery, page, limit, models.CursorRequestFromQuery(c))
+	if errors.Is(err, models.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid cursor"))
 		return
 	}
-
-	// Get total count
-	if err := baseQuery.Model(&models.ActivityLog{}).Count(&total).Error; err != nil {
-		log.Printf("[admin.all-activity] failed to count logs: %v", err)
+	if err != nil {
+		log.Printf("[admin.all-activity] failed to fetch logs: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Server error"))
 		return
 	}
@@ -89,19 +81,10 @@ func GetAllAdminActivityLogs(c *gin.Context) {
 		responses[i] = log.ToResponse()
 	}
 
-	// Prepare pagination meta
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-	meta := &models.Pagination{
-		Page:       page,
-		Limit:      limit,
-		Total:      int(total),
-		TotalPages: totalPages,
-	}
-
 	response := gin.H{
 		"logs": responses,
 	}
 
-	log.Printf("[admin.all-activity] retrieved %d logs (page %d/%d, total: %d)", len(responses), page, totalPages, total)
+	log.Printf("[admin.all-activity] retrieved %d logs (page %d/%d, total: %d)", len(responses), meta.Page, meta.TotalPages, meta.Total)
 	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Activity logs retrieved", response, meta))
 }
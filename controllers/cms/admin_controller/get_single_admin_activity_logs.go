@@ -1,8 +1,8 @@
 package admin_controller
 
 import (
+	"errors"
 	"log"
-	"math"
 	"net/http"
 	"strconv"
 
@@ -21,7 +21,10 @@ import (
 // @Param id path string true "Admin ID"
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Param cursor query string false "Cursor pagination instead of page: empty for the first page, then meta.next_cursor"
+// @Param total query string false "With cursor: exact for a counted total instead of the planner's estimate" Enums(exact)
 // @Success 200 {object} models.ApiResponse{data=map[string]interface{}}
+// @Failure 400 {object} models.ApiResponse "Invalid cursor"
 // @Failure 404 {object} models.ApiResponse "Admin not found"
 // @Failure 401 {object} models.ApiResponse "Unauthorized"
 // @Router /admin/admins/:id/activity [get]
@@ -47,8 +50,6 @@ func GetSingleAdminActivityLogs(c *gin.Context) {
 		}
 	}
 
-	offset := (page - 1) * limit
-
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
@@ -66,27 +67,19 @@ func GetSingleAdminActivityLogs(c *gin.Context) {
 		return
 	}
 
-	// Get activity logs
-	var activityLogs []models.ActivityLog
-	var total int64
-
-	if err := config.CmsGorm.WithContext(ctx).
-		Where("admin_id = ?", adminID).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&activityLogs).Error; err != nil {
-		log.Printf("[admin.activity] failed to fetch logs: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Server error"))
+	// Get activity logs, by page or cursor (see models.CursorRequest)
+	activityLogs, meta, err := fetchActivityLogPage(
+		config.CmsGorm.WithContext(ctx).Model(&models.ActivityLog{}).Where("admin_id = ?", adminID),
+		page,
+		limit,
+		models.CursorRequestFromQuery(c),
+	)
+	if errors.Is(err, models.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid cursor"))
 		return
 	}
-
-	// Get total count
-	if err := config.CmsGorm.WithContext(ctx).
-		Model(&models.ActivityLog{}).
-		Where("admin_id = ?", adminID).
-		Count(&total).Error; err != nil {
-		log.Printf("[admin.activity] failed to count logs: %v", err)
+	if err != nil {
+		log.Printf("[admin.activity] failed to fetch logs: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Server error"))
 		return
 	}
@@ -97,15 +90,6 @@ func GetSingleAdminActivityLogs(c *gin.Context) {
 		responses[i] = log.ToResponse()
 	}
 
-	// Step 6: Prepare pagination meta
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-	meta := &models.Pagination{
-		Page:       page,
-		Limit:      limit,
-		Total:      int(total),
-		TotalPages: totalPages,
-	}
-
 	// ✅ Include admin details in response
 	response := gin.H{
 		"admin": gin.H{
@@ -118,6 +102,6 @@ func GetSingleAdminActivityLogs(c *gin.Context) {
 		"logs": responses,
 	}
 
-	log.Printf("[admin.activity] retrieved %d logs for admin %s (page %d/%d)", len(responses), adminID, page, totalPages)
+	log.Printf("[admin.activity] retrieved %d logs for admin %s (page %d/%d)", len(responses), adminID, meta.Page, meta.TotalPages)
 	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Activity logs retrieved", response, meta))
 }
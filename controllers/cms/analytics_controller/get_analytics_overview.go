@@ -3,11 +3,13 @@ package analytics_controller
 import (
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // GetAnalyticsOverview godoc
@@ -16,12 +18,24 @@ import (
 // @Tags Admin - Analytics
 // @Produce json
 // @Security BearerAuth
+// @Param location_id query string false "Only count inventory held at this stock location"
 // @Success 200 {object} models.ApiResponse{data=models.AnalyticsOverview}
 // @Failure 500 {object} models.ApiResponse
 // @Router /admin/analytics/overview [get]
 func GetAnalyticsOverview(c *gin.Context) {
 	log.Printf("[admin.analytics-overview] start")
 
+	var locationID *uuid.UUID
+	if locationIDStr := strings.TrimSpace(c.Query("location_id")); locationIDStr != "" {
+		parsed, err := uuid.Parse(locationIDStr)
+		if err != nil {
+			log.Printf("[admin.analytics-overview] bad request: invalid location_id=%q", locationIDStr)
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid location_id"))
+			return
+		}
+		locationID = &parsed
+	}
+
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
@@ -102,14 +116,24 @@ func GetAnalyticsOverview(c *gin.Context) {
 	// ================================
 	// Inventory Count (sum of all quantities in inventory JSONB)
 	// ================================
-	var currentInventory int64
-	if err := config.CmsGorm.WithContext(ctx).
+	currentInventoryQuery := config.CmsGorm.WithContext(ctx).
 		Raw(`
 			SELECT COALESCE(SUM(CAST(elem->>'quantity' AS INTEGER)), 0)
 			FROM products, LATERAL jsonb_array_elements(inventory) AS elem
-			WHERE status = ?
-		`, "Active").
-		Scan(&currentInventory).Error; err != nil {
+			WHERE status = ? AND deleted_at IS NULL
+		`, "Active")
+	if locationID != nil {
+		currentInventoryQuery = config.CmsGorm.WithContext(ctx).
+			Raw(`
+				SELECT COALESCE(SUM(ls.quantity), 0)
+				FROM location_stock ls
+				JOIN products p ON p.id = ls.product_id
+				WHERE p.status = ? AND p.deleted_at IS NULL AND ls.location_id = ?
+			`, "Active", *locationID)
+	}
+
+	var currentInventory int64
+	if err := currentInventoryQuery.Scan(&currentInventory).Error; err != nil {
 		log.Printf("[admin.analytics-overview] ERROR current inventory err=%v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch analytics"))
 		return
@@ -122,13 +146,23 @@ func GetAnalyticsOverview(c *gin.Context) {
 	var lastMonthInventory int64
 	// Since we don't have historical inventory, we'll estimate using products updated > 30 days ago
 	// In production, you'd want to track inventory_snapshots or use logs
-	if err := config.CmsGorm.WithContext(ctx).
+	lastMonthInventoryQuery := config.CmsGorm.WithContext(ctx).
 		Raw(`
 			SELECT COALESCE(SUM(CAST(elem->>'quantity' AS INTEGER)), 0)
 			FROM products, LATERAL jsonb_array_elements(inventory) AS elem
-			WHERE status = ? AND updated_at < ?
-		`, "Active", thirtyDaysAgo).
-		Scan(&lastMonthInventory).Error; err != nil {
+			WHERE status = ? AND deleted_at IS NULL AND updated_at < ?
+		`, "Active", thirtyDaysAgo)
+	if locationID != nil {
+		lastMonthInventoryQuery = config.CmsGorm.WithContext(ctx).
+			Raw(`
+				SELECT COALESCE(SUM(ls.quantity), 0)
+				FROM location_stock ls
+				JOIN products p ON p.id = ls.product_id
+				WHERE p.status = ? AND p.deleted_at IS NULL AND p.updated_at < ? AND ls.location_id = ?
+			`, "Active", thirtyDaysAgo, *locationID)
+	}
+
+	if err := lastMonthInventoryQuery.Scan(&lastMonthInventory).Error; err != nil {
 		log.Printf("[admin.analytics-overview] ERROR last month inventory err=%v", err)
 		// Don't fail, just use current as fallback
 		lastMonthInventory = currentInventory
@@ -0,0 +1,155 @@
+package analytics_controller
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	searchReportDefaultDays = 30   // Range when from is not given
+	searchReportMaxDays     = 366  // Longest range
+	searchLowCTRMinSearches = 5    // Default min_searches of the low click-through report
+	searchLowCTRMaxRate     = 0.10 // Default max_ctr of the low click-through report
+)
+
+// GetTopSearchQueries godoc
+// @Summary Get top search queries
+// @Description The storefront searches made most often over a date range, with their average result count, zero-result searches and click-through rate, plus the range's totals
+// @Tags Admin - Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD, inclusive; default 30 days before to)"
+// @Param to query string false "End date (YYYY-MM-DD, inclusive; default today)"
+// @Param source query string false "search (product listing), suggest (search box) or all" default(search)
+// @Param limit query int false "Queries to return (max 100)" default(20)
+// @Success 200 {object} models.ApiResponse{data=models.SearchAnalyticsReport}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 500 {object} models.ApiResponse
+// @Router /admin/analytics/search/top-queries [get]
+func GetTopSearchQueries(c *gin.Context) {
+	searchReport(c, services.SearchReportTop, "Top search queries retrieved successfully")
+}
+
+// GetZeroResultSearchQueries godoc
+// @Summary Get zero-result search queries
+// @Description The storefront searches that found nothing over a date range, most frequent first: candidates for synonyms, redirects or new products
+// @Tags Admin - Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD, inclusive; default 30 days before to)"
+// @Param to query string false "End date (YYYY-MM-DD, inclusive; default today)"
+// @Param source query string false "search (product listing), suggest (search box) or all" default(search)
+// @Param limit query int false "Queries to return (max 100)" default(20)
+// @Success 200 {object} models.ApiResponse{data=models.SearchAnalyticsReport}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 500 {object} models.ApiResponse
+// @Router /admin/analytics/search/zero-results [get]
+func GetZeroResultSearchQueries(c *gin.Context) {
+	searchReport(c, services.SearchReportZeroResults, "Zero-result search queries retrieved successfully")
+}
+
+// GetLowClickThroughSearchQueries godoc
+// @Summary Get low click-through search queries
+// @Description The storefront searches that find products but whose results are rarely clicked over a date range, lowest click-through first: candidates for pinned results or better product data
+// @Tags Admin - Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD, inclusive; default 30 days before to)"
+// @Param to query string false "End date (YYYY-MM-DD, inclusive; default today)"
+// @Param source query string false "search (product listing), suggest (search box) or all" default(search)
+// @Param limit query int false "Queries to return (max 100)" default(20)
+// @Param min_searches query int false "Leave out queries made fewer times" default(5)
+// @Param max_ctr query number false "Leave out queries with a higher click-through rate (0-1)" default(0.1)
+// @Success 200 {object} models.ApiResponse{data=models.SearchAnalyticsReport}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 500 {object} models.ApiResponse
+// @Router /admin/analytics/search/low-click-through [get]
+func GetLowClickThroughSearchQueries(c *gin.Context) {
+	searchReport(c, services.SearchReportLowClickThrough, "Low click-through search queries retrieved successfully")
+}
+
+// searchReport parses the report options shared by the search analytics endpoints, then
+// runs and writes the report
+func searchReport(c *gin.Context, report, message string) {
+	// Step 1: Date range (whole days)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid to date (use YYYY-MM-DD)"))
+			return
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -(searchReportDefaultDays - 1))
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid from date (use YYYY-MM-DD)"))
+			return
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "from must not be after to"))
+		return
+	}
+	if to.Sub(from) >= searchReportMaxDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Date range can't be longer than a year"))
+		return
+	}
+
+	// Step 2: Other options
+	opts := services.SearchReportOptions{
+		From:        from,
+		To:          to.AddDate(0, 0, 1), // Include the whole of the last day
+		Limit:       20,
+		MinSearches: searchLowCTRMinSearches,
+		MaxCTR:      searchLowCTRMaxRate,
+	}
+	source := c.DefaultQuery("source", models.SearchSourceSearch)
+	switch source {
+	case models.SearchSourceSearch, models.SearchSourceSuggest:
+		opts.Source = source
+	case "all":
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "source must be search, suggest or all"))
+		return
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit >= 1 && limit <= 100 {
+		opts.Limit = limit
+	}
+	if minSearches, err := strconv.Atoi(c.Query("min_searches")); err == nil && minSearches >= 1 {
+		opts.MinSearches = minSearches
+	}
+	if maxCTR, err := strconv.ParseFloat(c.Query("max_ctr"), 64); err == nil && maxCTR >= 0 && maxCTR <= 1 {
+		opts.MaxCTR = maxCTR
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 3: Run it
+	totals, queries, err := services.GetSearchAnalyticsService().Report(ctx, report, opts)
+	if err != nil {
+		log.Printf("[admin.analytics-search] ERROR %s report err=%v", report, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch search analytics"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, message, models.SearchAnalyticsReport{
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Source:  source,
+		Totals:  totals,
+		Queries: queries,
+	}))
+}
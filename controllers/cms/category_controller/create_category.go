@@ -57,11 +57,12 @@ func CreateCategory(c *gin.Context) {
 
 	// Step 4: Create the category object
 	category := models.Category{
-		Name:        input.Name,
-		Description: input.Description,
-		Status:      "Inactive", // Default status
-		ParentID:    input.ParentID,
-		ParentName:  parentName,
+		Name:              input.Name,
+		Description:       input.Description,
+		Status:            "Inactive", // Default status
+		ParentID:          input.ParentID,
+		ParentName:        parentName,
+		LowStockThreshold: input.LowStockThreshold,
 		// ID will be auto-generated by BeforeCreate hook
 	}
 
@@ -65,7 +65,7 @@ func GetCategories(c *gin.Context) {
 			var counts []CountResult
 			if err := config.CmsGorm.Table("products").
 				Select("sub_category_id, COUNT(*) as count").
-				Where("sub_category_id IN ?", categoryIDs).
+				Where("sub_category_id IN ? AND deleted_at IS NULL", categoryIDs).
 				Group("sub_category_id").
 				Scan(&counts).Error; err != nil {
 				c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count products"))
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -101,6 +102,9 @@ func UpdateCategory(c *gin.Context) {
 		updates["parent_name"] = existing.ParentName
 		existing.ParentID = input.ParentID
 	}
+	if input.LowStockThreshold != nil {
+		updates["low_stock_threshold"] = thresholdOrNil(*input.LowStockThreshold)
+	}
 
 	// Step 7: Update in database
 	if err := config.CmsGorm.Model(&existing).Updates(updates).Error; err != nil {
@@ -108,6 +112,11 @@ func UpdateCategory(c *gin.Context) {
 		return
 	}
 
+	// Thresholds cascade to every product under this category, so re-check them all
+	if _, ok := updates["low_stock_threshold"]; ok {
+		services.GetStockAlertService().CheckProductsAsync()
+	}
+
 	// Step 8: Reload to get fresh data (with updated_at)
 	if err := config.CmsGorm.First(&existing, "id = ?", categoryID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to reload category"))
@@ -133,5 +142,22 @@ func hasChanges(input models.UpdateCategoryRequest, existing models.Category) bo
 			return true
 		}
 	}
+	if input.LowStockThreshold != nil {
+		current := -1
+		if existing.LowStockThreshold != nil {
+			current = *existing.LowStockThreshold
+		}
+		if *input.LowStockThreshold != current {
+			return true
+		}
+	}
 	return false
 }
+
+// thresholdOrNil maps the "-1 clears it" convention of update requests to a NULL column
+func thresholdOrNil(threshold int) interface{} {
+	if threshold < 0 {
+		return nil
+	}
+	return threshold
+}
@@ -70,6 +70,7 @@ func GetCustomerDetailsByID(c *gin.Context) {
 			u.ban_reason,
 			u.suspended_until,
 			u.suspended_reason,
+			u.customer_group_id::text AS customer_group_id,
 			COALESCE(os.order_count, 0)::int AS orders,
 			COALESCE(os.total_amount, 0)::float8 AS total_spent,
 			COALESCE(os.avg_amount, 0)::float8 AS avg_order_value,
@@ -180,6 +181,18 @@ func GetCustomerDetailsByID(c *gin.Context) {
 		customer.RecentOrders = recentOrders
 	}
 
+	// =================================
+	// Step 4: Customer group name (groups live in the CMS database)
+	// =================================
+	if customer.CustomerGroupID != nil {
+		var group models.CustomerGroup
+		if err := config.CmsGorm.WithContext(ctx).
+			Select("name").
+			First(&group, "id = ?", *customer.CustomerGroupID).Error; err == nil {
+			customer.CustomerGroup = &group.Name
+		}
+	}
+
 	// Remaining steps unchanged …
 
 	c.JSON(http.StatusOK, models.SuccessResponse(
@@ -6,10 +6,12 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // GetCustomers godoc
@@ -23,6 +25,8 @@ import (
 // @Param limit query int false "Items per page (max 50)" default(10)
 // @Param q query string false "Search by name or email"
 // @Param status query string false "Filter by status" Enums(active,suspended,deleted,banned)
+// @Param cursor query string false "Cursor pagination instead of page: empty for the first page, then meta.next_cursor"
+// @Param total query string false "With cursor: exact for a counted total instead of the planner's estimate" Enums(exact)
 // @Success 200 {object} models.ApiResponse{data=[]models.CMSCustomerListRow,meta=models.Pagination}
 // @Failure 400 {object} models.ApiResponse
 // @Failure 401 {object} models.ApiResponse
@@ -46,6 +50,18 @@ func GetCustomers(c *gin.Context) {
 	}
 	offset := (page - 1) * limit
 
+	// Cursor pagination (see models.CursorRequest): newest first, keyset instead of page
+	keyset := models.Keyset{Sort: "newest", KeySQL: "u.created_at", KeyType: "timestamptz", IDSQL: "u.id", Desc: true}
+	request := models.CursorRequestFromQuery(c)
+	var cursor *models.Cursor
+	if request != nil && request.Cursor != "" {
+		var err error
+		if cursor, err = models.DecodeCursor(request.Cursor, keyset.Sort); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid cursor"))
+			return
+		}
+	}
+
 	// ================================
 	// Filters
 	// ================================
@@ -77,7 +93,16 @@ func GetCustomers(c *gin.Context) {
 	// Count
 	// ================================
 	var total int64
-	if err := db.Count(&total).Error; err != nil {
+	if request != nil && !request.ExactTotal {
+		// Estimated in cursor mode unless asked for
+		estimate, err := config.EstimateCount(db.Select("u.id"))
+		if err != nil {
+			log.Printf("[admin.customers] ERROR count estimate failed err=%v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch customers"))
+			return
+		}
+		total = int64(estimate)
+	} else if err := db.Count(&total).Error; err != nil {
 		log.Printf("[admin.customers] ERROR count failed err=%v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch customers"))
 		return
@@ -151,12 +176,23 @@ func GetCustomers(c *gin.Context) {
 		whereArgs = append(whereArgs, status)
 	}
 
+	if cursor != nil {
+		whereConditions = append(whereConditions, keyset.AfterSQL())
+		whereArgs = append(whereArgs, keyset.AfterArgs(cursor)...)
+	}
+
 	if len(whereConditions) > 0 {
 		dataSQL += " WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	dataSQL += " ORDER BY u.created_at DESC LIMIT ? OFFSET ?"
-	whereArgs = append(whereArgs, limit, offset)
+	if request != nil {
+		// One more than the page tells whether there's a next one
+		dataSQL += " ORDER BY " + keyset.OrderSQL() + " LIMIT ?"
+		whereArgs = append(whereArgs, limit+1)
+	} else {
+		dataSQL += " ORDER BY u.created_at DESC LIMIT ? OFFSET ?"
+		whereArgs = append(whereArgs, limit, offset)
+	}
 
 	if err := config.EcommerceGorm.WithContext(ctx).Raw(dataSQL, whereArgs...).Scan(&out).Error; err != nil {
 		log.Printf("[admin.customers] ERROR data query failed err=%v", err)
@@ -174,6 +210,15 @@ func GetCustomers(c *gin.Context) {
 		Total:      int(total),
 		TotalPages: totalPages,
 	}
+	if request != nil {
+		nextCursor := ""
+		if len(out) > limit {
+			out = out[:limit]
+			last := out[limit-1]
+			nextCursor = keyset.NextCursor(last.JoinDate.Format(time.RFC3339Nano), uuid.MustParse(last.ID))
+		}
+		meta = models.CursorPagination(limit, int(total), !request.ExactTotal, nextCursor)
+	}
 
 	log.Printf("[admin.customers] respond 200 total=%d page=%d", total, page)
 
@@ -13,7 +13,7 @@ import (
 
 // UpdateCustomerDetails godoc
 // @Summary Update customer information
-// @Description Update customer name, phone, and account status (active, suspended, banned, deleted)
+// @Description Update customer name, phone, account status (active, suspended, banned, deleted) and customer group (empty string removes it)
 // @Tags Admin - Customers
 // @Accept json
 // @Produce json
@@ -134,6 +134,32 @@ func UpdateCustomerDetails(c *gin.Context) {
 		}
 	}
 
+	if input.CustomerGroupID != nil {
+		raw := strings.TrimSpace(*input.CustomerGroupID)
+		if raw == "" {
+			updates["customer_group_id"] = nil
+		} else {
+			groupID, err := uuid.Parse(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid customer_group_id"))
+				return
+			}
+			var count int64
+			if err := config.CmsGorm.WithContext(ctx).
+				Model(&models.CustomerGroup{}).
+				Where("id = ?", groupID).
+				Count(&count).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+				return
+			}
+			if count == 0 {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Customer group not found"))
+				return
+			}
+			updates["customer_group_id"] = groupID
+		}
+	}
+
 	if len(updates) == 0 {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "No fields to update"))
 		return
@@ -158,6 +184,7 @@ func UpdateCustomerDetails(c *gin.Context) {
 		BanReason       *string `gorm:"column:ban_reason"`
 		SuspendedUntil  *string `gorm:"column:suspended_until"`
 		SuspendedReason *string `gorm:"column:suspended_reason"`
+		CustomerGroupID *string `gorm:"column:customer_group_id"`
 	}
 
 	_ = config.EcommerceGorm.WithContext(ctx).
@@ -170,7 +197,8 @@ func UpdateCustomerDetails(c *gin.Context) {
 			status,
 			ban_reason,
 			suspended_until::text AS suspended_until,
-			suspended_reason
+			suspended_reason,
+			customer_group_id::text AS customer_group_id
 		`).
 		Where("id = ?", customerID).
 		First(&updatedCustomer)
@@ -0,0 +1,85 @@
+package inventory_controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateStockLocation godoc
+// @Summary Create a stock location
+// @Description Add a warehouse, store or pop-up that stock can be held at. Lower priority values are allocated first at checkout.
+// @Tags CMS - Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param location body models.StockLocationRequest true "Location details"
+// @Success 201 {object} models.ApiResponse{data=models.StockLocation}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Code already in use"
+// @Router /api/v1/admin/inventory/locations [post]
+func CreateStockLocation(c *gin.Context) {
+	// Step 1: Validate JSON input
+	var req models.StockLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Build location with defaults
+	location := models.StockLocation{
+		Name:      strings.TrimSpace(req.Name),
+		Code:      strings.ToUpper(strings.TrimSpace(req.Code)),
+		Type:      req.Type,
+		Address:   strings.TrimSpace(req.Address),
+		Priority:  100,
+		IsDefault: req.IsDefault,
+		IsActive:  true,
+	}
+	if location.Type == "" {
+		location.Type = "warehouse"
+	}
+	if req.Priority != nil {
+		location.Priority = *req.Priority
+	}
+
+	// Step 3: Ensure code is unique
+	var existing int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.StockLocation{}).
+		Where("code = ?", location.Code).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "A location with this code already exists"))
+		return
+	}
+
+	// Step 4: Save (and take over as default if requested)
+	if err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if location.IsDefault {
+			if err := tx.Model(&models.StockLocation{}).
+				Where("is_default = ?", true).
+				Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&location).Error
+	}); err != nil {
+		log.Printf("[admin.inventory] failed to create location: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create stock location"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Stock location created successfully", location))
+}
@@ -0,0 +1,80 @@
+package inventory_controller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeleteStockLocation godoc
+// @Summary Delete a stock location
+// @Description Delete an empty, non-default stock location. Its movement history is kept.
+// @Tags CMS - Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Location ID (UUID)"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Location still holds stock"
+// @Router /api/v1/admin/inventory/locations/{id} [delete]
+func DeleteStockLocation(c *gin.Context) {
+	locationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid location ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find location
+	var location models.StockLocation
+	if err := config.CmsGorm.WithContext(ctx).First(&location, "id = ?", locationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Stock location not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	if location.IsDefault {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "The default location can't be deleted"))
+		return
+	}
+
+	// Step 2: Make sure it's empty
+	var units int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.LocationStock{}).
+		Where("location_id = ?", locationID).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&units).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if units > 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "Transfer all stock out of this location before deleting it"))
+		return
+	}
+
+	// Step 3: Delete empty stock rows, then the location (movements keep a NULL location)
+	if err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("location_id = ?", locationID).Delete(&models.LocationStock{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&location).Error
+	}); err != nil {
+		log.Printf("[admin.inventory] failed to delete location %s: %v", locationID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to delete stock location"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Stock location deleted successfully", nil))
+}
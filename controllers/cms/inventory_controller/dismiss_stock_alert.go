@@ -0,0 +1,80 @@
+package inventory_controller
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DismissStockAlert godoc
+// @Summary Dismiss a low-stock alert
+// @Description Acknowledge an open alert. The combo won't be alerted again until it has been restocked above its threshold.
+// @Tags CMS - Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alert ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=models.StockAlert}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Alert is not open"
+// @Router /api/v1/admin/inventory/alerts/{id}/dismiss [patch]
+func DismissStockAlert(c *gin.Context) {
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid alert ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find alert
+	var alert models.StockAlert
+	if err := config.CmsGorm.WithContext(ctx).First(&alert, "id = ?", alertID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Stock alert not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	if alert.Status != models.StockAlertOpen {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "Only open alerts can be dismissed"))
+		return
+	}
+
+	// Step 2: Mark as dismissed by the current admin
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":       models.StockAlertDismissed,
+		"dismissed_at": now,
+	}
+	if raw, exists := c.Get("adminID"); exists {
+		if idStr, ok := raw.(string); ok {
+			if adminID, err := uuid.Parse(idStr); err == nil {
+				updates["dismissed_by"] = adminID
+			}
+		}
+	}
+
+	if err := config.CmsGorm.WithContext(ctx).Model(&alert).Updates(updates).Error; err != nil {
+		log.Printf("[admin.inventory] failed to dismiss stock alert %s: %v", alertID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to dismiss stock alert"))
+		return
+	}
+
+	// Step 3: Reload to get fresh data
+	if err := config.CmsGorm.WithContext(ctx).First(&alert, "id = ?", alertID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to reload stock alert"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Stock alert dismissed successfully", alert))
+}
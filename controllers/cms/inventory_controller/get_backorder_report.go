@@ -0,0 +1,46 @@
+package inventory_controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetBackorderReport godoc
+// @Summary Get the backorder report
+// @Description Every combo with units on backorder or pre-order on open orders, with stock on hand and how many units to reorder (backordered units on hand doesn't cover). Combos with the most to reorder come first.
+// @Tags CMS - Inventory
+// @Produce json
+// @Param product_id query string false "Filter by product ID"
+// @Success 200 {object} models.ApiResponse{data=[]models.BackorderReportRow}
+// @Failure 400 {object} models.ApiResponse
+// @Router /api/v1/admin/inventory/backorders [get]
+func GetBackorderReport(c *gin.Context) {
+	var productID *uuid.UUID
+	if productIDStr := strings.TrimSpace(c.Query("product_id")); productIDStr != "" {
+		parsed, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product_id"))
+			return
+		}
+		productID = &parsed
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	report, err := services.GetBackorderService().Report(ctx, productID)
+	if err != nil {
+		log.Printf("[admin.backorders] failed to build backorder report: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch backorder report"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Backorder report fetched successfully", report))
+}
@@ -0,0 +1,114 @@
+package inventory_controller
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetLocationStock godoc
+// @Summary Get per-location stock
+// @Description Paginated list of quantities per location and SKU, filterable by location, product or SKU
+// @Tags CMS - Inventory
+// @Produce json
+// @Param location_id query string false "Filter by location ID"
+// @Param product_id query string false "Filter by product ID"
+// @Param sku query string false "Filter by SKU"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.LocationStockRow}
+// @Failure 400 {object} models.ApiResponse
+// @Router /api/v1/admin/inventory/stock [get]
+func GetLocationStock(c *gin.Context) {
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	query := config.CmsGorm.WithContext(ctx).
+		Table("location_stock ls").
+		Joins("JOIN stock_locations l ON l.id = ls.location_id").
+		Joins("JOIN products p ON p.id = ls.product_id AND p.deleted_at IS NULL")
+
+	if locationIDStr := strings.TrimSpace(c.Query("location_id")); locationIDStr != "" {
+		locationID, err := uuid.Parse(locationIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid location_id"))
+			return
+		}
+		query = query.Where("ls.location_id = ?", locationID)
+	}
+	if productIDStr := strings.TrimSpace(c.Query("product_id")); productIDStr != "" {
+		productID, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product_id"))
+			return
+		}
+		query = query.Where("ls.product_id = ?", productID)
+	}
+	if sku := strings.TrimSpace(c.Query("sku")); sku != "" {
+		query = query.Where("LOWER(ls.sku) = LOWER(?)", sku)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("[admin.inventory] failed to count location stock: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	rows := make([]models.LocationStockRow, 0)
+	if err := query.
+		Select(`
+			ls.location_id,
+			l.name AS location_name,
+			l.code AS location_code,
+			ls.product_id,
+			p.name AS product_name,
+			ls.sku,
+			ls.quantity,
+			ls.updated_at
+		`).
+		Order("p.name ASC, ls.sku ASC, l.priority ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error; err != nil {
+		log.Printf("[admin.inventory] failed to fetch location stock: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	meta := &models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Location stock fetched successfully", rows, meta))
+}
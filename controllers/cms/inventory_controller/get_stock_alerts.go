@@ -0,0 +1,102 @@
+package inventory_controller
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetStockAlerts godoc
+// @Summary Get low-stock alerts
+// @Description Paginated list of low-stock alerts, lowest quantity first. Defaults to open alerts.
+// @Tags CMS - Inventory
+// @Produce json
+// @Param status query string false "Filter by status (open, dismissed, resolved, all). Default: open"
+// @Param product_id query string false "Filter by product ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.StockAlertRow}
+// @Failure 400 {object} models.ApiResponse
+// @Router /api/v1/admin/inventory/alerts [get]
+func GetStockAlerts(c *gin.Context) {
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	query := config.CmsGorm.WithContext(ctx).
+		Table("stock_alerts a").
+		Joins("JOIN products p ON p.id = a.product_id AND p.deleted_at IS NULL")
+
+	status := strings.ToLower(strings.TrimSpace(c.DefaultQuery("status", models.StockAlertOpen)))
+	switch status {
+	case "all":
+	case models.StockAlertOpen, models.StockAlertDismissed, models.StockAlertResolved:
+		query = query.Where("a.status = ?", status)
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid status. Must be one of: open, dismissed, resolved, all"))
+		return
+	}
+
+	if productIDStr := strings.TrimSpace(c.Query("product_id")); productIDStr != "" {
+		productID, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product_id"))
+			return
+		}
+		query = query.Where("a.product_id = ?", productID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("[admin.inventory] failed to count stock alerts: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	alerts := make([]models.StockAlertRow, 0)
+	if err := query.
+		Select("a.*, p.name AS product_name").
+		Order("a.quantity ASC, a.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&alerts).Error; err != nil {
+		log.Printf("[admin.inventory] failed to fetch stock alerts: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	meta := &models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Stock alerts fetched successfully", alerts, meta))
+}
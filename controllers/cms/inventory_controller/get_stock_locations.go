@@ -0,0 +1,43 @@
+package inventory_controller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetStockLocations godoc
+// @Summary Get stock locations
+// @Description List all stock locations in allocation order with the units and SKUs held at each
+// @Tags CMS - Inventory
+// @Produce json
+// @Success 200 {object} models.ApiResponse{data=[]models.StockLocationResponse}
+// @Failure 500 {object} models.ApiResponse
+// @Router /api/v1/admin/inventory/locations [get]
+func GetStockLocations(c *gin.Context) {
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	locations := make([]models.StockLocationResponse, 0)
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`
+			SELECT
+				l.*,
+				COALESCE(SUM(ls.quantity), 0) AS total_units,
+				COUNT(ls.sku) FILTER (WHERE ls.quantity > 0) AS sku_count
+			FROM stock_locations l
+			LEFT JOIN location_stock ls ON ls.location_id = l.id
+			GROUP BY l.id
+			ORDER BY l.priority ASC, l.is_default DESC, l.name ASC
+		`).
+		Scan(&locations).Error; err != nil {
+		log.Printf("[admin.inventory] failed to fetch locations: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch stock locations"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Stock locations fetched successfully", locations))
+}
@@ -0,0 +1,89 @@
+package inventory_controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransferStock godoc
+// @Summary Transfer stock between locations
+// @Description Move units of one inventory combo from one location to another. The product's total stock is unchanged; both sides are recorded in the stock movement ledger.
+// @Tags CMS - Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param transfer body models.StockTransferRequest true "Transfer details"
+// @Success 201 {object} models.ApiResponse{data=[]models.StockMovement}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Not enough stock at the source location"
+// @Router /api/v1/admin/inventory/transfers [post]
+func TransferStock(c *gin.Context) {
+	// Step 1: Validate JSON input
+	var req models.StockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	req.SKU = strings.TrimSpace(req.SKU)
+
+	if req.FromLocationID == req.ToLocationID {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Source and destination locations must be different"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Move the stock
+	meta := services.StockMovementMeta{
+		Reason: models.StockReasonTransfer,
+		Note:   req.Note,
+	}
+	if v, ok := c.Get("adminID"); ok {
+		if adminID, err := uuid.Parse(v.(string)); err == nil {
+			meta.AdminID = &adminID
+		}
+	}
+	if v, ok := c.Get("adminEmail"); ok {
+		email := v.(string)
+		meta.AdminEmail = &email
+	}
+
+	var movements []models.StockMovement
+	err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var transferErr error
+		movements, transferErr = services.GetStockService().Transfer(tx, req, meta)
+		return transferErr
+	})
+
+	if err != nil {
+		var stockErr *services.InsufficientStockError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		case errors.Is(err, services.ErrStockComboNotFound):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "SKU not found for this product"))
+		case errors.Is(err, services.ErrStockLocationNotFound):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Location not found or inactive"))
+		case errors.As(err, &stockErr):
+			c.JSON(http.StatusConflict, models.ErrorResponse(c, stockErr.Error()))
+		default:
+			log.Printf("[admin.inventory] transfer failed: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to transfer stock"))
+		}
+		return
+	}
+
+	log.Printf("[admin.inventory] transferred %d x %s from %s to %s", req.Quantity, req.SKU, req.FromLocationID, req.ToLocationID)
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Stock transferred successfully", movements))
+}
@@ -0,0 +1,180 @@
+package inventory_controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UpdateStockLocation godoc
+// @Summary Update a stock location
+// @Description Update location details, allocation priority, default flag or active state. A location must be empty before it can be deactivated, the default location can't be deactivated, and only an active location can be made the default.
+// @Tags CMS - Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Location ID (UUID)"
+// @Param location body models.UpdateStockLocationRequest true "Fields to update"
+// @Success 200 {object} models.ApiResponse{data=models.StockLocation}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse
+// @Router /api/v1/admin/inventory/locations/{id} [patch]
+func UpdateStockLocation(c *gin.Context) {
+	locationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid location ID"))
+		return
+	}
+
+	var req models.UpdateStockLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find existing location
+	var location models.StockLocation
+	if err := config.CmsGorm.WithContext(ctx).First(&location, "id = ?", locationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Stock location not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	// Step 2: Build update map (only non-nil fields)
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		updates["name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.Code != nil {
+		code := strings.ToUpper(strings.TrimSpace(*req.Code))
+		var existing int64
+		if err := config.CmsGorm.WithContext(ctx).
+			Model(&models.StockLocation{}).
+			Where("code = ? AND id <> ?", code, locationID).
+			Count(&existing).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+			return
+		}
+		if existing > 0 {
+			c.JSON(http.StatusConflict, models.ErrorResponse(c, "A location with this code already exists"))
+			return
+		}
+		updates["code"] = code
+	}
+	if req.Type != nil {
+		updates["type"] = *req.Type
+	}
+	if req.Address != nil {
+		updates["address"] = strings.TrimSpace(*req.Address)
+	}
+	if req.Priority != nil {
+		updates["priority"] = *req.Priority
+	}
+	if req.IsDefault != nil {
+		if !*req.IsDefault && location.IsDefault {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Set another location as default instead"))
+			return
+		}
+		// Stock in goes to the default location, so it has to be one that can sell it
+		if *req.IsDefault && !location.IsActive && (req.IsActive == nil || !*req.IsActive) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Only an active location can be the default"))
+			return
+		}
+		updates["is_default"] = *req.IsDefault
+	}
+	deactivating := req.IsActive != nil && !*req.IsActive && location.IsActive
+	if req.IsActive != nil {
+		if !*req.IsActive && (location.IsDefault || (req.IsDefault != nil && *req.IsDefault)) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "The default location can't be deactivated"))
+			return
+		}
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "No fields to update"))
+		return
+	}
+
+	// Step 3: Save (and take over as default if requested)
+	errLocationHasStock := errors.New("location has stock")
+	errDefaultLocation := errors.New("location is the default")
+	errInactiveLocation := errors.New("location is inactive")
+	becomingDefault := req.IsDefault != nil && *req.IsDefault && !location.IsDefault
+	if err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Re-check the flags under the row lock, against concurrent updates
+		if deactivating || becomingDefault {
+			var locked models.StockLocation
+			if err := tx.Raw(`SELECT * FROM stock_locations WHERE id = ? FOR UPDATE`, locationID).Scan(&locked).Error; err != nil {
+				return err
+			}
+			if deactivating && locked.IsDefault {
+				return errDefaultLocation
+			}
+			if becomingDefault && !locked.IsActive && req.IsActive == nil {
+				return errInactiveLocation
+			}
+		}
+		if deactivating {
+			// Stock held at an inactive location can't be sold, so it has to be moved out
+			// first; the lock holds off stock coming in until the location is inactive
+			var units int64
+			if err := tx.Model(&models.LocationStock{}).
+				Where("location_id = ?", locationID).
+				Select("COALESCE(SUM(quantity), 0)").
+				Scan(&units).Error; err != nil {
+				return err
+			}
+			if units > 0 {
+				return errLocationHasStock
+			}
+		}
+		if becomingDefault {
+			if err := tx.Model(&models.StockLocation{}).
+				Where("is_default = ?", true).
+				Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&location).Updates(updates).Error
+	}); err != nil {
+		if errors.Is(err, errDefaultLocation) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "The default location can't be deactivated"))
+			return
+		}
+		if errors.Is(err, errInactiveLocation) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Only an active location can be the default"))
+			return
+		}
+		if errors.Is(err, errLocationHasStock) {
+			c.JSON(http.StatusConflict, models.ErrorResponse(c, "Transfer all stock out of this location before deactivating it"))
+			return
+		}
+		log.Printf("[admin.inventory] failed to update location %s: %v", locationID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update stock location"))
+		return
+	}
+
+	// Step 4: Reload
+	if err := config.CmsGorm.WithContext(ctx).First(&location, "id = ?", locationID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to reload stock location"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Stock location updated successfully", location))
+}
@@ -0,0 +1,348 @@
+package order_controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/johnfercher/maroto/pkg/color"
+	"github.com/johnfercher/maroto/pkg/consts"
+	"github.com/johnfercher/maroto/pkg/pdf"
+	"github.com/johnfercher/maroto/pkg/props"
+	"gorm.io/gorm"
+)
+
+// DownloadOrderPackingSlipPDF godoc
+// @Summary Download order packing slip PDF
+// @Description Generate a packing slip (no prices) for picking and packing the order. Bundles list every component with the quantity to pick; lines still on backorder say how many units are waiting on stock.
+// @Tags Orders
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param orderId path string true "Order ID"
+// @Success 200 "PDF file"
+// @Failure 400 {object} models.ApiResponse "Invalid order ID"
+// @Failure 404 {object} models.ApiResponse "Order not found"
+// @Failure 500 {object} models.ApiResponse "Server error"
+// @Router /orders/:id/packing-slip [get]
+func DownloadOrderPackingSlipPDF(c *gin.Context) {
+	orderId := c.Param("id")
+	log.Printf("[order.packing-slip] request for order: %s", orderId)
+
+	// Validate order ID
+	if _, err := uuid.Parse(orderId); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid order ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Get the order (from ecommerce database)
+	var order models.Order
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Where("id = ?", orderId).
+		First(&order).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Printf("[order.packing-slip] order not found: %s", orderId)
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Order not found"))
+			return
+		}
+		log.Printf("[order.packing-slip] database error: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Server error"))
+		return
+	}
+
+	// Get order items (from ecommerce database)
+	var orderItems []models.OrderItem
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Where("order_id = ?", orderId).
+		Order("created_at ASC").
+		Find(&orderItems).Error; err != nil {
+		log.Printf("[order.packing-slip] failed to fetch order items: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Server error"))
+		return
+	}
+
+	// Ship to the address as it was at checkout
+	var address models.CMSOrderAddress
+	if order.AddressSnapshot != nil {
+		if err := json.Unmarshal([]byte(*order.AddressSnapshot), &address); err != nil {
+			log.Printf("[order.packing-slip] failed to parse address snapshot: %v", err)
+		}
+	}
+
+	// Generate PDF in memory
+	pdfBuffer := generateOrderPackingSlipPDF(&order, orderItems, address)
+
+	// Set response headers for file download
+	filename := fmt.Sprintf("packing-slip-%s.pdf", order.OrderNumber)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, filename, filename))
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Length", fmt.Sprintf("%d", pdfBuffer.Len()))
+	c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
+	c.Header("Pragma", "no-cache")
+	c.Header("Expires", "0")
+
+	// Write PDF to response
+	c.Data(http.StatusOK, "application/pdf", pdfBuffer.Bytes())
+
+	log.Printf("[order.packing-slip] packing slip PDF downloaded for order %s", orderId)
+}
+
+// generateOrderPackingSlipPDF creates a packing slip: what to pick (with SKUs) and where to ship it
+func generateOrderPackingSlipPDF(order *models.Order, items []models.OrderItem, address models.CMSOrderAddress) *bytes.Buffer {
+	m := pdf.NewMaroto(consts.Portrait, consts.A4)
+	m.SetPageMargins(20, 20, 20)
+
+	// Colors
+	darkGray := color.Color{Red: 38, Green: 38, Blue: 34}
+	mediumGray := color.Color{Red: 121, Green: 119, Blue: 109}
+
+	// Title
+	m.Row(15, func() {
+		m.Col(12, func() {
+			m.Text("PACKING SLIP", props.Text{
+				Size:  24,
+				Style: consts.Bold,
+				Color: darkGray,
+			})
+		})
+	})
+
+	m.Row(10, func() {
+		m.Col(12, func() {
+			m.Text("MODEVA STORE", props.Text{
+				Size:  16,
+				Style: consts.Bold,
+				Color: darkGray,
+			})
+		})
+	})
+
+	m.Row(8, func() {})
+
+	// Ship to / order details
+	m.Row(5, func() {
+		m.Col(6, func() {
+			m.Text("SHIP TO", props.Text{
+				Size:  8,
+				Style: consts.Bold,
+				Color: darkGray,
+			})
+		})
+		m.Col(6, func() {
+			m.Text("ORDER DETAILS", props.Text{
+				Size:  8,
+				Style: consts.Bold,
+				Color: darkGray,
+				Align: consts.Right,
+			})
+		})
+	})
+
+	shipTo := addressLines(address)
+	details := []string{
+		fmt.Sprintf("Order #%s", order.OrderNumber),
+		fmt.Sprintf("Date: %s", order.CreatedAt.Format("Jan 02, 2006")),
+	}
+	for i := 0; i < len(shipTo) || i < len(details); i++ {
+		left, right := "", ""
+		if i < len(shipTo) {
+			left = shipTo[i]
+		}
+		if i < len(details) {
+			right = details[i]
+		}
+		m.Row(5, func() {
+			m.Col(6, func() {
+				m.Text(left, props.Text{
+					Size:  9,
+					Color: darkGray,
+				})
+			})
+			m.Col(6, func() {
+				m.Text(right, props.Text{
+					Size:  9,
+					Color: darkGray,
+					Align: consts.Right,
+				})
+			})
+		})
+	}
+
+	m.Row(8, func() {})
+
+	// Items Table Header
+	m.Row(6, func() {
+		m.Col(7, func() {
+			m.Text("Item", props.Text{
+				Size:  8,
+				Style: consts.Bold,
+				Color: darkGray,
+			})
+		})
+		m.Col(3, func() {
+			m.Text("SKU", props.Text{
+				Size:  8,
+				Style: consts.Bold,
+				Color: darkGray,
+			})
+		})
+		m.Col(2, func() {
+			m.Text("Qty", props.Text{
+				Size:  8,
+				Style: consts.Bold,
+				Color: darkGray,
+				Align: consts.Right,
+			})
+		})
+	})
+
+	// Items; bundles are picked as their components
+	for _, item := range items {
+		sku := ""
+		if item.SKU != nil {
+			sku = *item.SKU
+		}
+		m.Row(6, func() {
+			m.Col(7, func() {
+				m.Text(item.ProductName, props.Text{
+					Size:  9,
+					Color: darkGray,
+				})
+			})
+			m.Col(3, func() {
+				m.Text(sku, props.Text{
+					Size:  9,
+					Color: darkGray,
+				})
+			})
+			m.Col(2, func() {
+				m.Text(fmt.Sprintf("%d", item.Quantity), props.Text{
+					Size:  9,
+					Color: darkGray,
+					Align: consts.Right,
+				})
+			})
+		})
+
+		for _, component := range item.BundleComponents {
+			name := component.ProductName
+			if component.VariantName != "" {
+				name = fmt.Sprintf("%s (%s)", component.ProductName, component.VariantName)
+			}
+			pick := item.Quantity * component.Quantity
+			m.Row(5, func() {
+				m.Col(7, func() {
+					m.Text(name, props.Text{
+						Size:  8,
+						Color: mediumGray,
+						Left:  4,
+					})
+				})
+				m.Col(3, func() {
+					m.Text(component.SKU, props.Text{
+						Size:  8,
+						Color: mediumGray,
+					})
+				})
+				m.Col(2, func() {
+					m.Text(fmt.Sprintf("%d", pick), props.Text{
+						Size:  8,
+						Color: mediumGray,
+						Align: consts.Right,
+					})
+				})
+			})
+		}
+
+		if item.BackorderedQuantity > 0 {
+			note := fmt.Sprintf("%d on backorder", item.BackorderedQuantity)
+			if item.ExpectedShipDate != nil {
+				note += fmt.Sprintf(", expected %s", item.ExpectedShipDate.Format("Jan 02, 2006"))
+			}
+			m.Row(5, func() {
+				m.Col(12, func() {
+					m.Text(note, props.Text{
+						Size:  8,
+						Style: consts.Italic,
+						Color: mediumGray,
+						Left:  4,
+					})
+				})
+			})
+		}
+	}
+
+	// Customer notes
+	if order.CustomerNotes != nil && strings.TrimSpace(*order.CustomerNotes) != "" {
+		m.Row(8, func() {})
+		m.Row(5, func() {
+			m.Col(12, func() {
+				m.Text("NOTES", props.Text{
+					Size:  8,
+					Style: consts.Bold,
+					Color: darkGray,
+				})
+			})
+		})
+		m.Row(10, func() {
+			m.Col(12, func() {
+				m.Text(*order.CustomerNotes, props.Text{
+					Size:  9,
+					Color: darkGray,
+				})
+			})
+		})
+	}
+
+	// Output to buffer
+	buf, err := m.Output()
+	if err != nil {
+		log.Printf("[order.packing-slip] failed to generate PDF: %v", err)
+		return bytes.NewBuffer(nil)
+	}
+
+	return &buf
+}
+
+// addressLines formats an address snapshot for printing, skipping empty parts
+func addressLines(address models.CMSOrderAddress) []string {
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return strings.TrimSpace(*s)
+	}
+
+	lines := make([]string, 0, 5)
+	add := func(parts ...string) {
+		nonEmpty := make([]string, 0, len(parts))
+		for _, part := range parts {
+			if part != "" {
+				nonEmpty = append(nonEmpty, part)
+			}
+		}
+		if len(nonEmpty) > 0 {
+			lines = append(lines, strings.Join(nonEmpty, " "))
+		}
+	}
+
+	add(value(address.FirstName), value(address.LastName))
+	add(value(address.Street))
+	city := value(address.City)
+	if city != "" && (value(address.State) != "" || value(address.Zip) != "") {
+		city += ","
+	}
+	add(city, value(address.State), value(address.Zip))
+	add(value(address.Country))
+	add(value(address.Phone))
+	return lines
+}
@@ -122,12 +122,17 @@ func GetOrderDetailsByID(c *gin.Context) {
 			product_name,
 			variant_size,
 			variant_color,
+			sku,
 			price,
 			quantity,
 			subtotal,
 			status,
 			created_at,
-			updated_at
+			updated_at,
+			bundle_components,
+			backordered_quantity,
+			backorder_type,
+			expected_ship_date
 		`).
 		Where("order_id = ?", orderID).
 		Order("created_at ASC").
@@ -154,6 +159,8 @@ func GetOrderDetailsByID(c *gin.Context) {
 
 	// =====================================
 	// 5. Fetch product images (CMS DB)
+	// Trashed products are included on purpose: past orders keep their images until
+	// the product is purged
 	// =====================================
 	imageByProductID := make(map[string]*string)
 
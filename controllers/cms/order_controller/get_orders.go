@@ -6,10 +6,12 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // GetOrders godoc
@@ -23,7 +25,10 @@ import (
 // @Param limit query int false "Items per page (max 50)" default(10)
 // @Param status query string false "Filter by order status (pending, confirmed, processing, shipped, delivered, cancelled, refunded)"
 // @Param q query string false "Search by order number, customer email, or customer name"
+// @Param cursor query string false "Cursor pagination instead of page: empty for the first page, then meta.next_cursor"
+// @Param total query string false "With cursor: exact for a counted total instead of the planner's estimate" Enums(exact)
 // @Success 200 {object} models.ApiResponse{data=[]models.CMSOrderListRow,meta=models.Pagination}
+// @Failure 400 {object} models.ApiResponse "Invalid cursor"
 // @Failure 401 {object} models.ApiResponse "Unauthorized"
 // @Failure 403 {object} models.ApiResponse "Forbidden"
 // @Failure 500 {object} models.ApiResponse "Internal server error"
@@ -55,6 +60,17 @@ func GetOrders(c *gin.Context) {
 
 	log.Printf("[admin.orders] params page=%d limit=%d offset=%d status=%q q=%q", page, limit, offset, status, q)
 
+	// Cursor pagination (see models.CursorRequest): newest first, keyset instead of page
+	keyset := models.Keyset{Sort: "newest", KeySQL: "o.created_at", KeyType: "timestamptz", IDSQL: "o.id", Desc: true}
+	request := models.CursorRequestFromQuery(c)
+	var cursor *models.Cursor
+	if request != nil && request.Cursor != "" {
+		if cursor, err = models.DecodeCursor(request.Cursor, keyset.Sort); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid cursor"))
+			return
+		}
+	}
+
 	db := config.EcommerceGorm.Table("orders o").
 		Joins("LEFT JOIN users u ON u.id = o.user_id")
 
@@ -70,9 +86,17 @@ func GetOrders(c *gin.Context) {
 		log.Printf("[admin.orders] filter q=%q", like)
 	}
 
-	// Count total orders
+	// Count total orders (estimated in cursor mode unless asked for)
 	var total int64
-	if err := db.Count(&total).Error; err != nil {
+	if request != nil && !request.ExactTotal {
+		estimate, err := config.EstimateCount(db.Select("o.id"))
+		if err != nil {
+			log.Printf("[admin.orders] ERROR count estimate failed err=%v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count orders"))
+			return
+		}
+		total = int64(estimate)
+	} else if err := db.Count(&total).Error; err != nil {
 		log.Printf("[admin.orders] ERROR count failed err=%v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count orders"))
 		return
@@ -113,17 +137,27 @@ func GetOrders(c *gin.Context) {
 		whereArgs = append(whereArgs, like, like, like)
 	}
 
+	if cursor != nil {
+		whereConditions = append(whereConditions, keyset.AfterSQL())
+		whereArgs = append(whereArgs, keyset.AfterArgs(cursor)...)
+	}
+
 	if len(whereConditions) > 0 {
 		dataSQL += " WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
 	dataSQL += `
 		GROUP BY o.id, o.order_number, u.id, u.name, u.email, o.created_at, o.total_amount, o.status
-		ORDER BY o.created_at DESC
-		LIMIT ? OFFSET ?
 	`
 
-	whereArgs = append(whereArgs, limit, offset)
+	if request != nil {
+		// One more than the page tells whether there's a next one
+		dataSQL += " ORDER BY " + keyset.OrderSQL() + " LIMIT ?"
+		whereArgs = append(whereArgs, limit+1)
+	} else {
+		dataSQL += " ORDER BY o.created_at DESC LIMIT ? OFFSET ?"
+		whereArgs = append(whereArgs, limit, offset)
+	}
 
 	log.Printf("[admin.orders] dataSQL=%s", strings.ReplaceAll(dataSQL, "\n", " "))
 	log.Printf("[admin.orders] dataArgs=%v", whereArgs)
@@ -143,6 +177,15 @@ func GetOrders(c *gin.Context) {
 		Total:      int(total),
 		TotalPages: totalPages,
 	}
+	if request != nil {
+		nextCursor := ""
+		if len(result) > limit {
+			result = result[:limit]
+			last := result[limit-1]
+			nextCursor = keyset.NextCursor(last.CreatedAt.Format(time.RFC3339Nano), uuid.MustParse(last.ID))
+		}
+		meta = models.CursorPagination(limit, int(total), !request.ExactTotal, nextCursor)
+	}
 
 	log.Printf("[admin.orders] respond 200 meta=%+v", *meta)
 
@@ -124,11 +124,21 @@ func SendOrderInvoicePDF(c *gin.Context) {
 	// Convert order items to service format
 	serviceItems := make([]services.OrderInvoiceItem, len(orderItems))
 	for i, item := range orderItems {
+		sku := ""
+		if item.SKU != nil {
+			sku = *item.SKU
+		}
+		components := make([]string, 0, len(item.BundleComponents))
+		for _, component := range item.BundleComponents {
+			components = append(components, bundleComponentLine(component))
+		}
 		serviceItems[i] = services.OrderInvoiceItem{
 			ProductName: item.ProductName,
+			SKU:         sku,
 			Quantity:    item.Quantity,
 			Price:       item.Price,
 			Subtotal:    item.Price * float64(item.Quantity),
+			Components:  components,
 		}
 	}
 
@@ -196,6 +206,18 @@ func SendOrderInvoicePDF(c *gin.Context) {
 	}))
 }
 
+// bundleComponentLine describes a bundle component per bundle, e.g. "1 x Linen Shirt (Small-Black), SKU: linen-shirt-small-black"
+func bundleComponentLine(component models.OrderBundleComponent) string {
+	line := fmt.Sprintf("%d x %s", component.Quantity, component.ProductName)
+	if component.VariantName != "" {
+		line += fmt.Sprintf(" (%s)", component.VariantName)
+	}
+	if component.SKU != "" {
+		line += ", SKU: " + component.SKU
+	}
+	return line
+}
+
 // generateOrderInvoicePDF creates a professional invoice PDF matching your HTML design
 func generateOrderInvoicePDF(order *models.Order, items []models.OrderItem, customerName, customerEmail string) *bytes.Buffer {
 	m := pdf.NewMaroto(consts.Portrait, consts.A4)
@@ -330,9 +352,13 @@ func generateOrderInvoicePDF(order *models.Order, items []models.OrderItem, cust
 	// Items
 	for _, item := range items {
 		itemTotal := item.Price * float64(item.Quantity)
+		description := item.ProductName
+		if item.SKU != nil && *item.SKU != "" {
+			description = fmt.Sprintf("%s (SKU: %s)", item.ProductName, *item.SKU)
+		}
 		m.Row(6, func() {
 			m.Col(6, func() {
-				m.Text(item.ProductName, props.Text{
+				m.Text(description, props.Text{
 					Size:  9,
 					Color: darkGray,
 				})
@@ -359,6 +385,20 @@ func generateOrderInvoicePDF(order *models.Order, items []models.OrderItem, cust
 				})
 			})
 		})
+
+		// Bundle contents, under the bundle
+		for _, component := range item.BundleComponents {
+			line := bundleComponentLine(component)
+			m.Row(5, func() {
+				m.Col(6, func() {
+					m.Text(line, props.Text{
+						Size:  8,
+						Color: mediumGray,
+						Left:  4,
+					})
+				})
+			})
+		}
 	}
 
 	m.Row(8, func() {})
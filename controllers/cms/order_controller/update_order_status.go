@@ -1,6 +1,7 @@
 package order_controller
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strings"
@@ -8,13 +9,14 @@ import (
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 // UpdateOrderStatus godoc
 // @Summary Update order status (CMS)
-// @Description Update an order status. admin_notes is optional for all statuses, but required when status is cancelled (cancellation reason).
+// @Description Update an order status. admin_notes is optional for all statuses, but required when status is cancelled (cancellation reason). Cancelling returns the order's stock; if that fails the order stays cancelled and restock_error is set, and cancelling it again retries. Shipping (or completing) an order takes its backordered units out of stock, so it fails with 409 until their stock has arrived.
 // @Tags Admin - Orders
 // @Accept json
 // @Produce json
@@ -26,6 +28,7 @@ import (
 // @Failure 401 {object} models.ApiResponse "Unauthorized"
 // @Failure 403 {object} models.ApiResponse "Forbidden"
 // @Failure 404 {object} models.ApiResponse "Order not found"
+// @Failure 409 {object} models.ApiResponse "Backordered items not in stock yet, or order cancelled"
 // @Failure 500 {object} models.ApiResponse "Internal server error"
 // @Router /admin/orders/{id}/status [patch]
 func UpdateOrderStatus(c *gin.Context) {
@@ -78,6 +81,30 @@ func UpdateOrderStatus(c *gin.Context) {
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
+	// Backordered units leave stock when the order ships (or is completed without shipping first)
+	if req.Status == "shipped" || req.Status == "completed" {
+		units, err := services.GetBackorderService().FulfilOrder(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, services.ErrOrderCancelled) {
+				log.Printf("[admin.order.update] cannot fulfil cancelled order id=%s", orderID)
+				c.JSON(http.StatusConflict, models.ErrorResponse(c, "Cancelled orders can't be shipped or completed"))
+				return
+			}
+			var stockErr *services.InsufficientStockError
+			if errors.As(err, &stockErr) {
+				log.Printf("[admin.order.update] backorder not in stock id=%s err=%v", orderID, err)
+				c.JSON(http.StatusConflict, models.ErrorResponse(c, "Backordered items are not in stock yet: "+stockErr.Error()))
+				return
+			}
+			log.Printf("[admin.order.update] ERROR backorder fulfilment failed id=%s err=%v", orderID, err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update order"))
+			return
+		}
+		if units > 0 {
+			log.Printf("[admin.order.update] took %d backordered units from stock id=%s", units, orderID)
+		}
+	}
+
 	q := `
 		UPDATE orders
 		SET
@@ -133,6 +160,31 @@ func UpdateOrderStatus(c *gin.Context) {
 
 	log.Printf("[admin.order.update] success order_number=%s status=%s", out.OrderNumber, out.Status)
 
+	// Return stock for cancelled orders (no-op if this order was already restocked)
+	if out.Status == "cancelled" {
+		var adminID *uuid.UUID
+		var adminEmail *string
+		if v, ok := c.Get("adminID"); ok {
+			if parsed, err := uuid.Parse(v.(string)); err == nil {
+				adminID = &parsed
+			}
+		}
+		if v, ok := c.Get("adminEmail"); ok {
+			email := v.(string)
+			adminEmail = &email
+		}
+
+		restocked, err := services.GetStockService().RestockCancelledOrder(ctx, orderID, adminID, adminEmail)
+		if err != nil {
+			// Status change already committed; the restock is idempotent, so cancelling again retries it
+			log.Printf("[admin.order.update] ERROR restock failed order_number=%s err=%v", out.OrderNumber, err)
+			restockErr := "Order cancelled, but its stock could not be returned; cancel it again to retry"
+			out.RestockError = &restockErr
+		} else if restocked {
+			log.Printf("[admin.order.update] restocked items for order_number=%s", out.OrderNumber)
+		}
+	}
+
 	c.JSON(http.StatusOK, models.SuccessResponse(
 		c,
 		"Order updated successfully",
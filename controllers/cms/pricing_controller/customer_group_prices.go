@@ -0,0 +1,195 @@
+package pricing_controller
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetCustomerGroupPrices godoc
+// @Summary Get a customer group's price list
+// @Description Paginated group prices with each product's regular price, by product name
+// @Tags CMS - Pricing
+// @Produce json
+// @Param id path string true "Customer group ID (UUID)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.CustomerGroupPriceResponse}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/customer-groups/{id}/prices [get]
+func GetCustomerGroupPrices(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid customer group ID"))
+		return
+	}
+
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	if _, ok := loadCustomerGroup(c, groupID); !ok {
+		return
+	}
+
+	query := config.CmsGorm.WithContext(ctx).
+		Table("customer_group_prices gp").
+		Joins("JOIN products p ON p.id = gp.product_id AND p.deleted_at IS NULL").
+		Where("gp.customer_group_id = ?", groupID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("[admin.pricing] failed to count group prices: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	prices := make([]models.CustomerGroupPriceResponse, 0)
+	if err := query.
+		Select("gp.product_id, p.name AS product_name, p.price AS regular_price, gp.price, gp.updated_at").
+		Order("p.name ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&prices).Error; err != nil {
+		log.Printf("[admin.pricing] failed to fetch group prices: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	meta := &models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Customer group prices fetched successfully", prices, meta))
+}
+
+// SetCustomerGroupPrices godoc
+// @Summary Set a customer group's prices
+// @Description Sets the group's price for each product listed (a price of -1 removes it, so members pay the regular price again). Products not listed keep their group price.
+// @Tags CMS - Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer group ID (UUID)"
+// @Param prices body models.SetCustomerGroupPricesRequest true "Prices to set"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/customer-groups/{id}/prices [put]
+func SetCustomerGroupPrices(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid customer group ID"))
+		return
+	}
+
+	var req models.SetCustomerGroupPricesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find group
+	if _, ok := loadCustomerGroup(c, groupID); !ok {
+		return
+	}
+
+	// Step 2: Split into prices to set and prices to remove (the last entry for a product
+	// wins), checking the products exist
+	latest := make(map[uuid.UUID]float64, len(req.Prices))
+	productIDs := make([]uuid.UUID, 0, len(req.Prices))
+	for _, input := range req.Prices {
+		if _, ok := latest[input.ProductID]; !ok {
+			productIDs = append(productIDs, input.ProductID)
+		}
+		latest[input.ProductID] = input.Price
+	}
+
+	set := make([]models.CustomerGroupPrice, 0, len(productIDs))
+	remove := make([]uuid.UUID, 0)
+	for _, productID := range productIDs {
+		if price := latest[productID]; price >= 0 {
+			set = append(set, models.CustomerGroupPrice{
+				CustomerGroupID: groupID,
+				ProductID:       productID,
+				Price:           price,
+			})
+		} else {
+			remove = append(remove, productID)
+		}
+	}
+
+	var found int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id IN ?", productIDs).
+		Count(&found).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if int(found) != len(productIDs) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "One or more products were not found"))
+		return
+	}
+
+	// Step 3: Save
+	if err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(remove) > 0 {
+			if err := tx.
+				Where("customer_group_id = ? AND product_id IN ?", groupID, remove).
+				Delete(&models.CustomerGroupPrice{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(set) > 0 {
+			return tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "customer_group_id"}, {Name: "product_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"price", "updated_at"}),
+			}).Create(&set).Error
+		}
+		return nil
+	}); err != nil {
+		log.Printf("[admin.pricing] failed to set prices of group %s: %v", groupID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to save customer group prices"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Customer group prices saved successfully", map[string]int{
+		"set":     len(set),
+		"removed": len(remove),
+	}))
+}
@@ -0,0 +1,257 @@
+package pricing_controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetCustomerGroups godoc
+// @Summary Get customer groups
+// @Description List customer groups with how many customers are in each and how many products have a group price
+// @Tags CMS - Pricing
+// @Produce json
+// @Success 200 {object} models.ApiResponse{data=[]models.CustomerGroupResponse}
+// @Failure 500 {object} models.ApiResponse
+// @Router /api/v1/admin/customer-groups [get]
+func GetCustomerGroups(c *gin.Context) {
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Groups with their price list size
+	groups := make([]models.CustomerGroupResponse, 0)
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`
+			SELECT g.*, COUNT(gp.product_id) AS price_count
+			FROM customer_groups g
+			LEFT JOIN customer_group_prices gp ON gp.customer_group_id = g.id
+			GROUP BY g.id
+			ORDER BY g.name ASC
+		`).
+		Scan(&groups).Error; err != nil {
+		log.Printf("[admin.pricing] failed to fetch customer groups: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch customer groups"))
+		return
+	}
+
+	// Step 2: Members live in the ecommerce database
+	var counts []struct {
+		CustomerGroupID uuid.UUID
+		Members         int
+	}
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Table("users").
+		Select("customer_group_id, COUNT(*) AS members").
+		Where("customer_group_id IS NOT NULL").
+		Group("customer_group_id").
+		Scan(&counts).Error; err != nil {
+		log.Printf("[admin.pricing] failed to count group members: %v", err)
+	}
+	members := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		members[count.CustomerGroupID] = count.Members
+	}
+	for i := range groups {
+		groups[i].MemberCount = members[groups[i].ID]
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Customer groups fetched successfully", groups))
+}
+
+// CreateCustomerGroup godoc
+// @Summary Create a customer group
+// @Description Add a customer group (e.g. Wholesale). Give it prices with PUT /customer-groups/{id}/prices and assign customers from their detail page.
+// @Tags CMS - Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group body models.CustomerGroupRequest true "Group details"
+// @Success 201 {object} models.ApiResponse{data=models.CustomerGroup}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Name already in use"
+// @Router /api/v1/admin/customer-groups [post]
+func CreateCustomerGroup(c *gin.Context) {
+	// Step 1: Validate JSON input
+	var req models.CustomerGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	group := models.CustomerGroup{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+	}
+
+	// Step 2: Ensure name is unique
+	if taken, err := groupNameTaken(c, group.Name, uuid.Nil); err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	} else if taken {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "A customer group with this name already exists"))
+		return
+	}
+
+	// Step 3: Save
+	if err := config.CmsGorm.WithContext(ctx).Create(&group).Error; err != nil {
+		log.Printf("[admin.pricing] failed to create customer group: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create customer group"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Customer group created successfully", group))
+}
+
+// UpdateCustomerGroup godoc
+// @Summary Update a customer group
+// @Description Rename a customer group or change its description
+// @Tags CMS - Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer group ID (UUID)"
+// @Param group body models.UpdateCustomerGroupRequest true "Fields to update"
+// @Success 200 {object} models.ApiResponse{data=models.CustomerGroup}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Name already in use"
+// @Router /api/v1/admin/customer-groups/{id} [patch]
+func UpdateCustomerGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid customer group ID"))
+		return
+	}
+
+	var req models.UpdateCustomerGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find existing group
+	group, ok := loadCustomerGroup(c, groupID)
+	if !ok {
+		return
+	}
+
+	// Step 2: Build update map (only non-nil fields)
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if taken, err := groupNameTaken(c, name, groupID); err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+			return
+		} else if taken {
+			c.JSON(http.StatusConflict, models.ErrorResponse(c, "A customer group with this name already exists"))
+			return
+		}
+		updates["name"] = name
+	}
+	if req.Description != nil {
+		updates["description"] = strings.TrimSpace(*req.Description)
+	}
+
+	if len(updates) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "No fields to update"))
+		return
+	}
+
+	// Step 3: Save
+	if err := config.CmsGorm.WithContext(ctx).Model(group).Updates(updates).Error; err != nil {
+		log.Printf("[admin.pricing] failed to update customer group %s: %v", groupID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update customer group"))
+		return
+	}
+
+	// Step 4: Reload
+	if err := config.CmsGorm.WithContext(ctx).First(group, "id = ?", groupID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to reload customer group"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Customer group updated successfully", group))
+}
+
+// DeleteCustomerGroup godoc
+// @Summary Delete a customer group
+// @Description Delete a customer group with its price list and tiers. Its customers go back to regular prices.
+// @Tags CMS - Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer group ID (UUID)"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/customer-groups/{id} [delete]
+func DeleteCustomerGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid customer group ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find group
+	group, ok := loadCustomerGroup(c, groupID)
+	if !ok {
+		return
+	}
+
+	// Step 2: Unassign its customers first, so nobody is left pointing at a missing group
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Table("users").
+		Where("customer_group_id = ?", groupID).
+		Update("customer_group_id", nil).Error; err != nil {
+		log.Printf("[admin.pricing] failed to unassign customers of group %s: %v", groupID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to delete customer group"))
+		return
+	}
+
+	// Step 3: Delete (prices and tiers cascade)
+	if err := config.CmsGorm.WithContext(ctx).Delete(group).Error; err != nil {
+		log.Printf("[admin.pricing] failed to delete customer group %s: %v", groupID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to delete customer group"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Customer group deleted successfully", nil))
+}
+
+// loadCustomerGroup fetches a group, writing the error response when it can't
+func loadCustomerGroup(c *gin.Context, groupID uuid.UUID) (*models.CustomerGroup, bool) {
+	var group models.CustomerGroup
+	if err := config.CmsGorm.WithContext(c.Request.Context()).First(&group, "id = ?", groupID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Customer group not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return nil, false
+	}
+	return &group, true
+}
+
+// groupNameTaken reports whether another group (case-insensitively) has the name
+func groupNameTaken(c *gin.Context, name string, exceptID uuid.UUID) (bool, error) {
+	var existing int64
+	err := config.CmsGorm.WithContext(c.Request.Context()).
+		Model(&models.CustomerGroup{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", name, exceptID).
+		Count(&existing).Error
+	return existing > 0, err
+}
@@ -0,0 +1,173 @@
+package pricing_controller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetQuantityTiers godoc
+// @Summary Get quantity tiers
+// @Description List quantity discount tiers, optionally only those of one customer group or product (tiers for everyone / every product are included in both)
+// @Tags CMS - Pricing
+// @Produce json
+// @Param customer_group_id query string false "Customer group ID (UUID)"
+// @Param product_id query string false "Product ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=[]models.QuantityTier}
+// @Failure 400 {object} models.ApiResponse
+// @Router /api/v1/admin/quantity-tiers [get]
+func GetQuantityTiers(c *gin.Context) {
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	query := config.CmsGorm.WithContext(ctx).Model(&models.QuantityTier{})
+	if raw := c.Query("customer_group_id"); raw != "" {
+		groupID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid customer_group_id"))
+			return
+		}
+		query = query.Where("(customer_group_id IS NULL OR customer_group_id = ?)", groupID)
+	}
+	if raw := c.Query("product_id"); raw != "" {
+		productID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product_id"))
+			return
+		}
+		query = query.Where("(product_id IS NULL OR product_id = ?)", productID)
+	}
+
+	tiers := make([]models.QuantityTier, 0)
+	if err := query.
+		Order("customer_group_id NULLS FIRST, product_id NULLS FIRST, min_quantity ASC").
+		Find(&tiers).Error; err != nil {
+		log.Printf("[admin.pricing] failed to fetch quantity tiers: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch quantity tiers"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Quantity tiers fetched successfully", tiers))
+}
+
+// CreateQuantityTier godoc
+// @Summary Create a quantity tier
+// @Description Take a percentage off once a line reaches min_quantity units of a product (units of different combos of the same product add up). Scope it to a customer group and/or a product, or leave either out to apply to everyone / every product. When several tiers apply, the biggest discount wins; it is applied on top of group and sale prices.
+// @Tags CMS - Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tier body models.QuantityTierRequest true "Tier details"
+// @Success 201 {object} models.ApiResponse{data=models.QuantityTier}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "A tier with this threshold already exists"
+// @Router /api/v1/admin/quantity-tiers [post]
+func CreateQuantityTier(c *gin.Context) {
+	// Step 1: Validate JSON input
+	var req models.QuantityTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Check the group and product exist
+	if req.CustomerGroupID != nil {
+		if _, ok := loadCustomerGroup(c, *req.CustomerGroupID); !ok {
+			return
+		}
+	}
+	if req.ProductID != nil {
+		var count int64
+		if err := config.CmsGorm.WithContext(ctx).
+			Model(&models.Product{}).
+			Where("id = ?", *req.ProductID).
+			Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+			return
+		}
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product_id"))
+			return
+		}
+	}
+
+	// Step 3: One tier per threshold per scope
+	scope := config.CmsGorm.WithContext(ctx).
+		Model(&models.QuantityTier{}).
+		Where("min_quantity = ?", req.MinQuantity)
+	if req.CustomerGroupID != nil {
+		scope = scope.Where("customer_group_id = ?", *req.CustomerGroupID)
+	} else {
+		scope = scope.Where("customer_group_id IS NULL")
+	}
+	if req.ProductID != nil {
+		scope = scope.Where("product_id = ?", *req.ProductID)
+	} else {
+		scope = scope.Where("product_id IS NULL")
+	}
+	var existing int64
+	if err := scope.Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "A tier with this threshold already exists; delete it first"))
+		return
+	}
+
+	// Step 4: Save
+	tier := models.QuantityTier{
+		CustomerGroupID: req.CustomerGroupID,
+		ProductID:       req.ProductID,
+		MinQuantity:     req.MinQuantity,
+		DiscountPercent: req.DiscountPercent,
+	}
+	if err := config.CmsGorm.WithContext(ctx).Create(&tier).Error; err != nil {
+		log.Printf("[admin.pricing] failed to create quantity tier: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create quantity tier"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Quantity tier created successfully", tier))
+}
+
+// DeleteQuantityTier godoc
+// @Summary Delete a quantity tier
+// @Tags CMS - Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tier ID (UUID)"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/quantity-tiers/{id} [delete]
+func DeleteQuantityTier(c *gin.Context) {
+	tierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid tier ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	result := config.CmsGorm.WithContext(ctx).Delete(&models.QuantityTier{}, "id = ?", tierID)
+	if result.Error != nil {
+		log.Printf("[admin.pricing] failed to delete quantity tier %s: %v", tierID, result.Error)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to delete quantity tier"))
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Quantity tier not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Quantity tier deleted successfully", nil))
+}
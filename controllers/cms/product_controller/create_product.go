@@ -11,6 +11,7 @@ import (
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -19,12 +20,16 @@ var cloudinaryService *services.CloudinaryService
 func InitCloudinary(cloudName, apiKey, apiSecret string) error {
 	var err error
 	cloudinaryService, err = services.NewCloudinaryService(cloudName, apiKey, apiSecret)
-	return err
+	if err != nil {
+		return err
+	}
+	services.GetProductTrashService().UseCloudinary(cloudinaryService) // Purged products' images
+	return nil
 }
 
 // CreateProduct godoc
 // @Summary Create a new product
-// @Description Create a new product with Cloudinary URLs (optimized flow)
+// @Description Create a new product with Cloudinary URLs (optimized flow). To schedule a drop, create it as Draft with publish_at (and optionally unpublish_at). For a bundle, set product_type to bundle with bundle_components (combos of other products, by SKU) and an empty inventory. quantity_rules limit how many units can be bought (per order, per customer, in multiples of a step). backorder lets the product be ordered beyond its stock (backorder or preorder with an expected ship date, optionally capped); combos can override it in their inventory entry.
 // @Tags CMS - Products
 // @Accept json
 // @Produce json
@@ -53,6 +58,40 @@ func CreateProduct(c *gin.Context) {
 	if req.Status == "" {
 		req.Status = "Draft"
 	}
+	if err := validateSchedule(req.Status, req.PublishAt, req.UnpublishAt); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	if err := validateSale(req.Price, req.SalePrice, req.SaleStartsAt, req.SaleEndsAt, req.Inventory); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	var quantityRules models.QuantityRules
+	if req.QuantityRules != nil {
+		quantityRules = *req.QuantityRules
+	}
+	if err := quantityRules.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	if req.ProductType == "" {
+		req.ProductType = models.ProductTypeStandard
+	}
+	if err := validateBundle(ctx, uuid.Nil, req.ProductType, req.BundleComponents, req.Inventory); err != nil {
+		log.Printf("[ERROR] Bundle validation failed: %v", err)
+		respondBundleError(c, err)
+		return
+	}
+	var backorder models.BackorderPolicy
+	if req.Backorder != nil {
+		backorder = *req.Backorder
+	}
+	if err := validateBackorders(req.ProductType, &backorder, req.Inventory); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
 
 	// Step 3: Validate subcategory exists
 	validationStart := time.Now()
@@ -83,25 +122,62 @@ func CreateProduct(c *gin.Context) {
 		log.Printf("[PERF] 📸 Other images: %d URLs", len(req.Media.Other))
 	}
 
-	// Step 5: Create product model (UUID v7 auto-generated in BeforeCreate hook)
+	// Step 5: Generate missing SKUs and validate SKU/barcode uniqueness
+	if err := prepareInventorySKUs(ctx, uuid.Nil, req.Name, req.SKUPattern, req.Variants, req.Inventory); err != nil {
+		log.Printf("[ERROR] SKU validation failed: %v", err)
+		respondSKUError(c, err)
+		return
+	}
+
+	// Step 6: Create product model (UUID v7 auto-generated in BeforeCreate hook)
 	product := models.Product{
-		Name:          req.Name,
-		Description:   req.Description,
-		Composition:   models.CompositionList(req.Composition),
-		Price:         req.Price,
-		SubCategoryID: req.SubCategoryID,
-		Status:        req.Status,
-		Tags:          models.TagsList(req.Tags),
-		Media:         req.Media,
-		Variants:      models.VariantsList(req.Variants),
-		Inventory:     models.InventoryList(req.Inventory),
-		SEO:           req.SEO,
-		Views:         0,
-	}
-
-	// Step 6: Save to database
+		Name:              req.Name,
+		Description:       req.Description,
+		Composition:       models.CompositionList(req.Composition),
+		Price:             req.Price,
+		SubCategoryID:     req.SubCategoryID,
+		Status:            req.Status,
+		Tags:              models.TagsList(req.Tags),
+		Media:             req.Media,
+		Variants:          models.VariantsList(req.Variants),
+		Inventory:         models.InventoryList(req.Inventory),
+		ProductType:       req.ProductType,
+		BundleComponents:  models.BundleComponentList(req.BundleComponents),
+		QuantityRules:     quantityRules,
+		Backorder:         backorder,
+		SKUPattern:        req.SKUPattern,
+		LowStockThreshold: req.LowStockThreshold,
+		PublishAt:         req.PublishAt,
+		UnpublishAt:       req.UnpublishAt,
+		CompareAtPrice:    req.CompareAtPrice,
+		SalePrice:         req.SalePrice,
+		SaleStartsAt:      req.SaleStartsAt,
+		SaleEndsAt:        req.SaleEndsAt,
+		SEO:               req.SEO,
+		Views:             0,
+	}
+
+	// Step 7: Save to database along with opening stock movements and the first revision
 	dbStart := time.Now()
-	if err := config.CmsGorm.WithContext(ctx).Create(&product).Error; err != nil {
+	if err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&product).Error; err != nil {
+			return err
+		}
+		if err := services.GetStockService().RecordInventoryDiff(
+			tx,
+			product.ID,
+			nil,
+			product.Inventory,
+			stockMetaFromContext(c, models.StockReasonInitial),
+		); err != nil {
+			return err
+		}
+		meta := revisionMetaFromContext(c, models.RevisionSourceCreate)
+		if _, err := services.GetProductRevisionService().Record(tx, product.ID, meta); err != nil {
+			return err
+		}
+		return services.GetPriceHistoryService().Record(tx, product.ID, meta)
+	}); err != nil {
 		log.Printf("[ERROR] Failed to create product: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create product: "+err.Error()))
 		return
@@ -110,7 +186,9 @@ func CreateProduct(c *gin.Context) {
 	log.Printf("[PERF] ⏱️  Database insert: %v", dbDuration)
 	log.Printf("[PERF] 🆔 Product ID (UUID v7): %s", product.ID)
 
-	// Step 7: Load subcategory relationship for response
+	services.GetStockAlertService().CheckProductsAsync(product.ID)
+
+	// Step 8: Load subcategory relationship for response
 	if err := config.CmsGorm.WithContext(ctx).
 		Preload("SubCategory", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, name, parent_id, parent_name")
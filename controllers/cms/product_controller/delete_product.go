@@ -1,11 +1,10 @@
 package product_controller
 
 import (
-	"context"
-	"fmt"
 	"net/http"
 	"time"
 
+	category_cache "github.com/Modeva-Ecommerce/modeva-cms-backend/cache"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
 	"github.com/gin-gonic/gin"
@@ -14,8 +13,8 @@ import (
 )
 
 // DeleteProduct godoc
-// @Summary Delete a product
-// @Description Delete a product by ID and its associated Cloudinary folder
+// @Summary Move a product to the trash
+// @Description Soft-deletes a product: it disappears from the CMS and storefront but keeps its SKUs, revisions, stock history and images, and can be restored from the trash. Trashed products are purged for good (images included) once PRODUCT_TRASH_RETENTION has passed.
 // @Tags CMS - Products
 // @Produce json
 // @Param id path string true "Product ID (UUID)"
@@ -35,10 +34,10 @@ func DeleteProduct(c *gin.Context) {
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
-	// Step 2: Find product and check if it has Cloudinary images
+	// Step 2: Find product (already-trashed products are not found)
 	var product models.Product
 	if err := config.CmsGorm.WithContext(ctx).
-		Select("id, media").
+		Select("id").
 		First(&product, "id = ?", productID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
@@ -48,39 +47,22 @@ func DeleteProduct(c *gin.Context) {
 		return
 	}
 
-	// Step 3: Check if product has Cloudinary images
-	hasCloudinaryImages := false
-	if product.Media.Primary.URL != "" || len(product.Media.Other) > 0 {
-		hasCloudinaryImages = true
+	// Step 3: Move to the trash
+	updates := map[string]interface{}{"deleted_at": time.Now()}
+	if meta := revisionMetaFromContext(c, ""); meta.AdminID != nil {
+		updates["deleted_by"] = *meta.AdminID
 	}
-
-	// Step 4: Delete from database
-	if err := config.CmsGorm.WithContext(ctx).Delete(&product).Error; err != nil {
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&product).
+		Updates(updates).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to delete product: "+err.Error()))
 		return
 	}
 
-	// Step 5: Delete Cloudinary folder in background (don't block response)
-	if hasCloudinaryImages && cloudinaryService != nil {
-		go func(prodID uuid.UUID) {
-			// Create folder path: modeva/products/{productId}
-			folderPath := fmt.Sprintf("modeva/products/%s", prodID.String())
-
-			// Create context with timeout for deletion
-			deleteCtx, deleteCancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer deleteCancel()
-
-			// Delete the entire folder
-			if err := cloudinaryService.DeleteFolder(deleteCtx, folderPath); err != nil {
-				// Log error but don't fail the delete operation
-				fmt.Printf("⚠️  Warning: Failed to delete Cloudinary folder %s: %v\n", folderPath, err)
-			} else {
-				fmt.Printf("✓ Successfully deleted Cloudinary folder: %s\n", folderPath)
-			}
-		}(productID)
-	}
+	// Step 4: Trashed products no longer count towards their category
+	category_cache.Invalidate()
 
-	c.JSON(http.StatusOK, models.SuccessResponse(c, "Product deleted successfully", map[string]string{
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Product moved to trash", map[string]string{
 		"id": productID.String(),
 	}))
 }
@@ -0,0 +1,119 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetBackInStockStats godoc
+// @Summary Get back-in-stock subscription stats for a product
+// @Description Per-variant counts of pending, notified and unsubscribed back-in-stock subscriptions, alongside current stock
+// @Tags CMS - Products
+// @Produce json
+// @Param id path string true "Product ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=[]models.BackInStockVariantStats}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/products/{id}/back-in-stock [get]
+func GetBackInStockStats(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Fetch product inventory (CMS DB)
+	var product models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Select("id, inventory").
+		First(&product, "id = ?", productID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	// Step 2: Count subscriptions per SKU (ecommerce DB)
+	var rows []struct {
+		SKU            string
+		VariantName    string
+		Unconfirmed    int
+		Pending        int
+		Notified       int
+		Unsubscribed   int
+		LastNotifiedAt *time.Time
+	}
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Raw(`
+			SELECT
+				sku,
+				MAX(variant_name) AS variant_name,
+				COUNT(*) FILTER (WHERE status = ?) AS unconfirmed,
+				COUNT(*) FILTER (WHERE status = ?) AS pending,
+				COUNT(*) FILTER (WHERE status = ?) AS notified,
+				COUNT(*) FILTER (WHERE status = ?) AS unsubscribed,
+				MAX(notified_at) AS last_notified_at
+			FROM back_in_stock_subscriptions
+			WHERE product_id = ?
+			GROUP BY sku
+		`, models.BackInStockUnconfirmed, models.BackInStockPending, models.BackInStockNotified, models.BackInStockUnsubscribed, productID).
+		Scan(&rows).Error; err != nil {
+		log.Printf("[admin.back-in-stock] failed to count subscriptions for %s: %v", productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	// Step 3: One entry per current combo, plus any SKUs that have since been removed
+	bySKU := make(map[string]int, len(rows))
+	for i, row := range rows {
+		bySKU[strings.ToLower(row.SKU)] = i
+	}
+
+	stats := make([]models.BackInStockVariantStats, 0, len(product.Inventory))
+	seen := make(map[int]bool)
+	for _, item := range product.Inventory {
+		entry := models.BackInStockVariantStats{
+			SKU:         item.SKU,
+			VariantName: item.VariantName,
+			Quantity:    item.Quantity,
+		}
+		if i, ok := bySKU[strings.ToLower(item.SKU)]; ok && item.SKU != "" {
+			entry.Unconfirmed = rows[i].Unconfirmed
+			entry.Pending = rows[i].Pending
+			entry.Notified = rows[i].Notified
+			entry.Unsubscribed = rows[i].Unsubscribed
+			entry.LastNotifiedAt = rows[i].LastNotifiedAt
+			seen[i] = true
+		}
+		stats = append(stats, entry)
+	}
+	for i, row := range rows {
+		if seen[i] {
+			continue
+		}
+		stats = append(stats, models.BackInStockVariantStats{
+			SKU:            row.SKU,
+			VariantName:    row.VariantName,
+			Unconfirmed:    row.Unconfirmed,
+			Pending:        row.Pending,
+			Notified:       row.Notified,
+			Unsubscribed:   row.Unsubscribed,
+			LastNotifiedAt: row.LastNotifiedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Back-in-stock stats fetched successfully", stats))
+}
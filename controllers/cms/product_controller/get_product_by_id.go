@@ -5,6 +5,7 @@ import (
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,7 +13,7 @@ import (
 
 // GetProductByID godoc
 // @Summary Get a product by ID
-// @Description Retrieve a single product and its related details
+// @Description Retrieve a single product and its related details. Bundles also get bundle: their components with stock on hand and how many bundles that makes up.
 // @Tags CMS - Products
 // @Produce json
 // @Param id path string true "Product ID (UUID)"
@@ -50,22 +51,44 @@ func GetProductByID(c *gin.Context) {
 	// Step 3: Build response with structured data
 	response := gin.H{
 		"basic_info": models.ProductBase{
-			ID:              product.ID,
-			Name:            product.Name,
-			Description:     product.Description,
-			Composition:     []models.Composition(product.Composition),
-			Price:           product.Price,
-			SubCategoryID:   product.SubCategoryID,
-			SubCategoryName: product.SubCategoryName,
-			Status:          product.Status,
-			Tags:            []string(product.Tags),
-			CreatedAt:       product.CreatedAt,
-			UpdatedAt:       product.UpdatedAt,
+			ID:                product.ID,
+			Name:              product.Name,
+			Description:       product.Description,
+			Composition:       []models.Composition(product.Composition),
+			Price:             product.Price,
+			SubCategoryID:     product.SubCategoryID,
+			SubCategoryName:   product.SubCategoryName,
+			Status:            product.Status,
+			PublishAt:         product.PublishAt,
+			UnpublishAt:       product.UnpublishAt,
+			CompareAtPrice:    product.CompareAtPrice,
+			SalePrice:         product.SalePrice,
+			SaleStartsAt:      product.SaleStartsAt,
+			SaleEndsAt:        product.SaleEndsAt,
+			DisplayStatus:     product.DisplayStatus,
+			ProductType:       product.ProductType,
+			Tags:              []string(product.Tags),
+			SKUPattern:        product.SKUPattern,
+			LowStockThreshold: product.LowStockThreshold,
+			CreatedAt:         product.CreatedAt,
+			UpdatedAt:         product.UpdatedAt,
 		},
-		"seo":       product.SEO,
-		"media":     product.Media,
-		"variants":  []models.ProductVariant(product.Variants),
-		"inventory": []models.InventoryField(product.Inventory),
+		"seo":            product.SEO,
+		"media":          product.Media,
+		"variants":       []models.ProductVariant(product.Variants),
+		"inventory":      []models.InventoryField(product.Inventory),
+		"quantity_rules": product.QuantityRules,
+		"backorder":      product.Backorder,
+	}
+
+	// Step 4: Bundles show their components and how many bundles their stock makes up
+	if product.IsBundle() {
+		bundle, err := services.GetBundleService().Details(ctx, product.BundleComponents)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+			return
+		}
+		response["bundle"] = bundle
 	}
 
 	c.JSON(http.StatusOK, models.SuccessResponse(c, "Product fetched successfully", response))
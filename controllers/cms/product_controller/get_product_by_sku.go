@@ -0,0 +1,111 @@
+package product_controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetProductBySKU godoc
+// @Summary Look up a product by SKU or barcode
+// @Description Retrieve the product and the inventory combination matching a SKU (case-insensitive) or GTIN/EAN barcode
+// @Tags CMS - Products
+// @Produce json
+// @Param sku path string true "SKU or barcode"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/products/sku/{sku} [get]
+func GetProductBySKU(c *gin.Context) {
+	// Step 1: Validate SKU param
+	sku := strings.TrimSpace(c.Param("sku"))
+	if sku == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "SKU is required"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Resolve SKU (or barcode) to a product
+	var match struct {
+		ProductID uuid.UUID
+		SKU       string
+	}
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`
+			SELECT product_id, sku
+			FROM product_skus
+			WHERE LOWER(sku) = LOWER(?) OR barcode = ?
+			LIMIT 1
+		`, sku, sku).
+		Scan(&match).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if match.ProductID == uuid.Nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "No product found for this SKU"))
+		return
+	}
+
+	// Step 3: Fetch product with subcategory relationship
+	var product models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Preload("SubCategory", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name, parent_id, parent_name")
+		}).
+		First(&product, "id = ?", match.ProductID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	// Step 4: Pick out the matching inventory combination
+	var matched *models.InventoryField
+	if idx := product.Inventory.IndexOfSKU(match.SKU); idx >= 0 {
+		matched = &product.Inventory[idx]
+	}
+
+	response := gin.H{
+		"basic_info": models.ProductBase{
+			ID:                product.ID,
+			Name:              product.Name,
+			Description:       product.Description,
+			Composition:       []models.Composition(product.Composition),
+			Price:             product.Price,
+			SubCategoryID:     product.SubCategoryID,
+			SubCategoryName:   product.SubCategoryName,
+			Status:            product.Status,
+			PublishAt:         product.PublishAt,
+			UnpublishAt:       product.UnpublishAt,
+			CompareAtPrice:    product.CompareAtPrice,
+			SalePrice:         product.SalePrice,
+			SaleStartsAt:      product.SaleStartsAt,
+			SaleEndsAt:        product.SaleEndsAt,
+			DisplayStatus:     product.DisplayStatus,
+			ProductType:       product.ProductType,
+			Tags:              []string(product.Tags),
+			SKUPattern:        product.SKUPattern,
+			LowStockThreshold: product.LowStockThreshold,
+			CreatedAt:         product.CreatedAt,
+			UpdatedAt:         product.UpdatedAt,
+		},
+		"seo":               product.SEO,
+		"media":             product.Media,
+		"variants":          []models.ProductVariant(product.Variants),
+		"inventory":         []models.InventoryField(product.Inventory),
+		"quantity_rules":    product.QuantityRules,
+		"backorder":         product.Backorder,
+		"matched_inventory": matched,
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Product fetched successfully", response))
+}
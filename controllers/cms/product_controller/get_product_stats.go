@@ -2,21 +2,34 @@ package product_controller
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // GetProductStats godoc
 // @Summary Get product statistics
-// @Description Returns overall product stats including low-stock counts
+// @Description Returns overall product stats including low-stock counts (combos below their product or category low-stock threshold, default 5). With location_id, inventory totals and low-stock counts only cover stock held at that location.
 // @Tags CMS - Products
 // @Produce json
+// @Param location_id query string false "Stock location ID"
 // @Success 200 {object} models.ApiResponse
 // @Failure 500 {object} models.ApiResponse
 // @Router /api/v1/admin/products/stats [get]
 func GetProductStats(c *gin.Context) {
+	var locationID *uuid.UUID
+	if locationIDStr := strings.TrimSpace(c.Query("location_id")); locationIDStr != "" {
+		parsed, err := uuid.Parse(locationIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid location_id"))
+			return
+		}
+		locationID = &parsed
+	}
+
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
@@ -49,14 +62,35 @@ func GetProductStats(c *gin.Context) {
 		return
 	}
 
-	// Step 4: Total inventory (sum of all quantities in inventory JSONB array)
-	var totalInventory int
+	// Drafts waiting on a publish_at (a subset of the drafts)
+	var scheduledProducts int64
 	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("status = ? AND publish_at IS NOT NULL", models.ProductStatusDraft).
+		Count(&scheduledProducts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count scheduled products"))
+		return
+	}
+
+	// Step 4: Total inventory (sum of all quantities in inventory JSONB array, or at one location)
+	totalInventoryQuery := config.CmsGorm.WithContext(ctx).
 		Raw(`
 			SELECT COALESCE(SUM((inv->>'quantity')::int), 0)
 			FROM products, jsonb_array_elements(inventory) AS inv
-		`).
-		Scan(&totalInventory).Error; err != nil {
+			WHERE products.deleted_at IS NULL
+		`)
+	if locationID != nil {
+		totalInventoryQuery = config.CmsGorm.WithContext(ctx).
+			Raw(`
+				SELECT COALESCE(SUM(ls.quantity), 0)
+				FROM location_stock ls
+				JOIN products p ON p.id = ls.product_id AND p.deleted_at IS NULL
+				WHERE ls.location_id = ?
+			`, *locationID)
+	}
+
+	var totalInventory int
+	if err := totalInventoryQuery.Scan(&totalInventory).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count total inventory"))
 		return
 	}
@@ -81,16 +115,35 @@ func GetProductStats(c *gin.Context) {
 		return
 	}
 
-	// Step 7: Count low stock products (any variant quantity < 5)
-	var lowStockProducts int64
-	if err := config.CmsGorm.WithContext(ctx).
+	// Step 7: Count low stock products (any variant below its effective threshold, or below it at the location)
+	// Threshold: product → subcategory → parent category → default
+	effectiveThreshold := `COALESCE(
+		products.low_stock_threshold,
+		(SELECT COALESCE(sc.low_stock_threshold, pc.low_stock_threshold)
+		 FROM categories sc
+		 LEFT JOIN categories pc ON pc.id = sc.parent_id
+		 WHERE sc.id = products.sub_category_id),
+		?
+	)`
+	lowStockQuery := config.CmsGorm.WithContext(ctx).
 		Model(&models.Product{}).
 		Where(`EXISTS (
 			SELECT 1 
 			FROM jsonb_array_elements(inventory) AS inv
-			WHERE (inv->>'quantity')::int < 5
-		)`).
-		Count(&lowStockProducts).Error; err != nil {
+			WHERE (inv->>'quantity')::int < `+effectiveThreshold+`
+		)`, models.DefaultLowStockThreshold)
+	if locationID != nil {
+		lowStockQuery = config.CmsGorm.WithContext(ctx).
+			Model(&models.Product{}).
+			Where(`EXISTS (
+				SELECT 1
+				FROM location_stock ls
+				WHERE ls.product_id = products.id AND ls.location_id = ? AND ls.quantity < `+effectiveThreshold+`
+			)`, *locationID, models.DefaultLowStockThreshold)
+	}
+
+	var lowStockProducts int64
+	if err := lowStockQuery.Count(&lowStockProducts).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count low stock products"))
 		return
 	}
@@ -109,6 +162,7 @@ func GetProductStats(c *gin.Context) {
 			TotalProducts:      int(totalProducts),
 			ActiveProducts:     int(activeProducts),
 			DraftProducts:      int(draftProducts),
+			ScheduledProducts:  int(scheduledProducts),
 			AveragePrice:       averagePrice,
 			TotalInventory:     totalInventory,
 			TaggedProducts:     int(taggedProducts),
@@ -4,6 +4,8 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
@@ -18,8 +20,11 @@ import (
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param status query string false "Filter by status" Enums(Active, Draft)
+// @Param cursor query string false "Cursor pagination instead of page: empty for the first page, then meta.next_cursor"
+// @Param total query string false "With cursor: exact for a counted total instead of the planner's estimate" Enums(exact)
+// @Param status query string false "Filter by status. Scheduled: Drafts waiting on a publish_at, soonest first (they're also included in Draft)" Enums(Active, Draft, Scheduled)
 // @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse "Invalid cursor"
 // @Failure 500 {object} models.ApiResponse
 // @Router /api/v1/admin/products [get]
 func GetProducts(c *gin.Context) {
@@ -40,24 +45,61 @@ func GetProducts(c *gin.Context) {
 	query := config.CmsGorm.Model(&models.Product{})
 
 	// Optional status filter
+	order := "created_at DESC"
+	keyset := models.Keyset{Sort: "newest", KeySQL: "created_at", KeyType: "timestamptz", IDSQL: "id", Desc: true}
+	scheduled := false
 	if status := c.Query("status"); status != "" {
-		if status == "Active" || status == "Draft" {
+		if status == models.ProductStatusActive || status == models.ProductStatusDraft {
 			query = query.Where("status = ?", status)
+		} else if strings.EqualFold(status, models.ProductStatusScheduled) {
+			query = query.Where("status = ? AND publish_at IS NOT NULL", models.ProductStatusDraft)
+			order = "publish_at ASC, created_at DESC"
+			keyset = models.Keyset{Sort: "publish_at", KeySQL: "publish_at", KeyType: "timestamptz", IDSQL: "id"}
+			scheduled = true
+		}
+	}
+
+	// Cursor pagination (see models.CursorRequest): keyset instead of page, total
+	// estimated unless asked for
+	request := models.CursorRequestFromQuery(c)
+	pageQuery := query.Session(&gorm.Session{})
+	if request != nil {
+		order = keyset.OrderSQL()
+		offset = 0
+		if request.Cursor != "" {
+			cursor, err := models.DecodeCursor(request.Cursor, keyset.Sort)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid cursor"))
+				return
+			}
+			pageQuery = pageQuery.Where(keyset.AfterSQL(), keyset.AfterArgs(cursor)...)
 		}
 	}
 
 	// Step 3: Count total products
 	var total int64
-	if err := query.Count(&total).Error; err != nil {
+	if request != nil && !request.ExactTotal {
+		estimate, err := config.EstimateCount(query.Session(&gorm.Session{}))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count products"))
+			return
+		}
+		total = int64(estimate)
+	} else if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count products"))
 		return
 	}
 
-	// Step 4: Fetch products with subcategory info
+	// Step 4: Fetch products with subcategory info (one more in cursor mode, to tell
+	// whether there's a next page)
+	fetchLimit := limit
+	if request != nil {
+		fetchLimit = limit + 1
+	}
 	products := make([]models.Product, 0)
-	if err := query.
-		Order("created_at DESC").
-		Limit(limit).
+	if err := pageQuery.
+		Order(order).
+		Limit(fetchLimit).
 		Offset(offset).
 		Preload("SubCategory", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, name, parent_id, parent_name")
@@ -67,6 +109,17 @@ func GetProducts(c *gin.Context) {
 		return
 	}
 
+	nextCursor := ""
+	if request != nil && len(products) > limit {
+		products = products[:limit]
+		last := products[limit-1]
+		key := last.CreatedAt
+		if scheduled {
+			key = *last.PublishAt
+		}
+		nextCursor = keyset.NextCursor(key.Format(time.RFC3339Nano), last.ID)
+	}
+
 	// Step 5: Transform products into structured response format
 	productResponses := make([]gin.H, 0, len(products))
 	for _, product := range products {
@@ -93,6 +146,14 @@ func GetProducts(c *gin.Context) {
 				SubCategoryName: product.SubCategoryName,
 				SubCategoryPath: &subCategoryPath,
 				Status:          product.Status,
+				PublishAt:       product.PublishAt,
+				UnpublishAt:     product.UnpublishAt,
+				CompareAtPrice:  product.CompareAtPrice,
+				SalePrice:       product.SalePrice,
+				SaleStartsAt:    product.SaleStartsAt,
+				SaleEndsAt:      product.SaleEndsAt,
+				DisplayStatus:   product.DisplayStatus,
+				ProductType:     product.ProductType,
 				Tags:            []string(product.Tags),
 				CreatedAt:       product.CreatedAt,
 				UpdatedAt:       product.UpdatedAt,
@@ -112,6 +173,9 @@ func GetProducts(c *gin.Context) {
 		Total:      int(total),
 		TotalPages: totalPages,
 	}
+	if request != nil {
+		meta = models.CursorPagination(limit, int(total), !request.ExactTotal, nextCursor)
+	}
 
 	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Products fetched successfully", productResponses, meta))
 }
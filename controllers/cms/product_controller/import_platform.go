@@ -0,0 +1,252 @@
+package product_controller
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+)
+
+// Shared helpers for the Shopify and WooCommerce import adapters. The adapters only
+// translate the platform's CSV into importedProducts; validation, matching and saving
+// go through the same runProductImport pipeline as our own layout.
+
+// defaultComboName is the single inventory combo given to products without variants
+const defaultComboName = "Default"
+
+// seoDescriptionLength is how much of the description is used when a file has no SEO description
+const seoDescriptionLength = 160
+
+// platformCSV reads a platform export whose columns are addressed by header name.
+// It counts the non-empty values of every column so columns the adapter doesn't map
+// can be listed in the mapping report.
+type platformCSV struct {
+	reader  *csv.Reader
+	header  []string
+	columns map[string]int // Lowercase header → index
+	filled  []int          // Non-empty values seen per column
+	first   []int          // First row with a value, per column
+	example []string       // First value seen, per column
+}
+
+// platformRow is one data row of a platformCSV
+type platformRow struct {
+	line   int
+	record []string
+	file   *platformCSV
+}
+
+// openPlatformCSV reads the header and checks the columns the adapter can't work without
+func openPlatformCSV(r io.Reader, required ...string) (*platformCSV, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	f := &platformCSV{
+		reader:  reader,
+		header:  make([]string, len(header)),
+		columns: make(map[string]int, len(header)),
+		filled:  make([]int, len(header)),
+		first:   make([]int, len(header)),
+		example: make([]string, len(header)),
+	}
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		f.header[i] = name
+		if _, exists := f.columns[strings.ToLower(name)]; !exists {
+			f.columns[strings.ToLower(name)] = i
+		}
+	}
+	for _, column := range required {
+		if _, ok := f.columns[column]; !ok {
+			return nil, fmt.Errorf("missing required column %q", column)
+		}
+	}
+	return f, nil
+}
+
+// readAll returns every non-blank data row
+func (f *platformCSV) readAll() ([]platformRow, error) {
+	rows := make([]platformRow, 0)
+	for {
+		record, err := f.reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("invalid CSV on line %d: %w", parseErr.Line, parseErr.Err)
+			}
+			return nil, err
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		line, _ := f.reader.FieldPos(0)
+
+		for i, value := range record {
+			if i >= len(f.filled) || strings.TrimSpace(value) == "" {
+				continue
+			}
+			if f.filled[i] == 0 {
+				f.first[i] = line
+				f.example[i] = strings.TrimSpace(value)
+			}
+			f.filled[i]++
+		}
+		rows = append(rows, platformRow{line: line, record: record, file: f})
+	}
+}
+
+// unmappedColumns reports every column with data that isn't in mapped (lowercase names)
+func (f *platformCSV) unmappedColumns(mapped map[string]bool) []models.ProductImportNote {
+	notes := make([]models.ProductImportNote, 0)
+	for i, name := range f.header {
+		if f.filled[i] == 0 || mapped[strings.ToLower(name)] {
+			continue
+		}
+		example := f.example[i]
+		if runes := []rune(example); len(runes) > 100 {
+			example = string(runes[:100]) + "…"
+		}
+		notes = append(notes, models.ProductImportNote{
+			Row:     f.first[i],
+			Field:   name,
+			Value:   example,
+			Message: fmt.Sprintf("Column not imported (%d row(s) had a value)", f.filled[i]),
+		})
+	}
+	return notes
+}
+
+// get returns the trimmed value of a column (by lowercase header name), or "" if absent
+func (r platformRow) get(column string) string {
+	if i, ok := r.file.columns[column]; ok && i < len(r.record) {
+		return strings.TrimSpace(r.record[i])
+	}
+	return ""
+}
+
+// ═══════════════════════════════════════════════════════════
+// Conversion Helpers
+// ═══════════════════════════════════════════════════════════
+
+var (
+	htmlBreak  = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6])>`)
+	htmlTag    = regexp.MustCompile(`<[^>]*>`)
+	blankLines = regexp.MustCompile(`\n\s*\n\s*`)
+)
+
+// htmlToText turns a platform's HTML description into the plain text we store
+func htmlToText(s string) string {
+	s = htmlBreak.ReplaceAllString(s, "\n")
+	s = htmlTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	s = strings.Join(lines, "\n")
+	s = blankLines.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+// fillMissingSEO derives SEO fields from the name and description, which the
+// platforms leave optional but we require
+func fillMissingSEO(p *importedProduct) {
+	req := &p.Request
+	if req.SEO.SEOTitle == "" {
+		req.SEO.SEOTitle = req.Name
+	}
+	if req.SEO.SEODescription == "" {
+		description := strings.Join(strings.Fields(req.Description), " ")
+		if runes := []rune(description); len(runes) > seoDescriptionLength {
+			description = strings.TrimSpace(string(runes[:seoDescriptionLength-1])) + "…"
+		}
+		req.SEO.SEODescription = description
+	}
+}
+
+// categoryCandidates turns a platform taxonomy path ("Apparel > Clothing > Shirts")
+// into references our resolver understands, most specific first
+func categoryCandidates(path string) []string {
+	parts := make([]string, 0)
+	for _, part := range strings.Split(path, ">") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	candidates := make([]string, 0, 3)
+	switch n := len(parts); {
+	case n >= 2:
+		candidates = append(candidates, parts[n-2]+" > "+parts[n-1], parts[n-1], parts[n-2])
+	case n == 1:
+		candidates = append(candidates, parts[0])
+	}
+	return candidates
+}
+
+// setCategoryCandidates makes the first candidate the category and the rest its fallbacks
+func setCategoryCandidates(p *importedProduct, candidates ...string) {
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if p.Category == "" {
+			p.Category = candidate
+			continue
+		}
+		p.CategoryFallbacks = append(p.CategoryFallbacks, candidate)
+	}
+}
+
+// appendOption adds an option value to a variant type, keeping first-seen order
+func appendOption(variant *models.ProductVariant, value string) {
+	for _, existing := range variant.Options {
+		if strings.EqualFold(existing, value) {
+			return
+		}
+	}
+	variant.Options = append(variant.Options, value)
+}
+
+// addDefaultCombo gives a product without variants its single inventory combo
+func addDefaultCombo(p *importedProduct, row int, item models.InventoryField) {
+	item.Combo = []string{defaultComboName}
+	item.VariantName = defaultComboName
+	p.Request.Inventory = append(p.Request.Inventory, item)
+	p.InventoryRows = append(p.InventoryRows, row)
+}
+
+// splitList splits a delimited cell, trimming entries and dropping empty ones
+func splitList(s, sep string) []string {
+	out := make([]string, 0)
+	for _, part := range strings.Split(s, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// mappedColumns builds the lookup set for unmappedColumns
+func mappedColumns(columns ...string) map[string]bool {
+	set := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		set[column] = true
+	}
+	return set
+}
@@ -0,0 +1,121 @@
+package product_controller
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize caps catalogue uploads
+const maxImportFileSize = 10 << 20 // 10 MB
+
+// ImportProducts godoc
+// @Summary Import products from CSV
+// @Description Create or update products from a catalogue CSV: our own layout (same as the export), a Shopify product export or a WooCommerce product export. Products are matched by id, then by SKU, so re-importing a file is safe. Files in our layout from before the pricing, bundle, quantity rule, backorder and schedule columns leave those fields as they are; bundle components must already exist. Platform product types/categories are mapped onto our category tree, and anything that couldn't be converted is listed under "unmapped". With dry_run=true nothing is written and the validation report is returned. If any row is invalid, nothing is imported.
+// @Tags CMS - Products
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Catalogue CSV"
+// @Param format query string false "File format: modeva, shopify or woocommerce (default: modeva)"
+// @Param default_category query string false "Category (name, \"Parent > Child\" path or ID) for products whose own category doesn't match"
+// @Param dry_run query bool false "Validate only (default: false)"
+// @Success 200 {object} models.ApiResponse{data=models.ProductImportReport}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 422 {object} models.ApiResponse{data=models.ProductImportReport} "Validation errors"
+// @Router /api/v1/admin/products/import [post]
+func ImportProducts(c *gin.Context) {
+	// Step 1: Read upload
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	format := strings.ToLower(c.DefaultQuery("format", models.ProductImportFormatModeva))
+	if format != models.ProductImportFormatModeva &&
+		format != models.ProductImportFormatShopify &&
+		format != models.ProductImportFormatWooCommerce {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid format. Must be modeva, shopify or woocommerce"))
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "CSV file is required (form field \"file\")"))
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "CSV file must be 10 MB or smaller"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Failed to read uploaded file"))
+		return
+	}
+	defer file.Close()
+
+	// Step 2: Parse rows into products
+	var (
+		rows       int
+		products   []*importedProduct
+		fileErrors []models.ProductImportError
+		notes      = make([]models.ProductImportNote, 0)
+	)
+	switch format {
+	case models.ProductImportFormatShopify:
+		rows, products, fileErrors, notes, err = parseShopifyCSV(file)
+	case models.ProductImportFormatWooCommerce:
+		rows, products, fileErrors, notes, err = parseWooCommerceCSV(file)
+	default:
+		rows, products, fileErrors, err = parseProductCSV(file)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid CSV: "+err.Error()))
+		return
+	}
+
+	if defaultCategory := strings.TrimSpace(c.Query("default_category")); defaultCategory != "" {
+		for _, p := range products {
+			p.CategoryFallbacks = append(p.CategoryFallbacks, defaultCategory)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	// Step 3: Validate and (unless dry run) upsert
+	report, err := runProductImport(ctx, c, rows, products, dryRun || len(fileErrors) > 0)
+	if err != nil {
+		log.Printf("[admin.import] import failed: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to import products"))
+		return
+	}
+	report.Format = format
+	report.DryRun = dryRun
+	report.Errors = append(fileErrors, report.Errors...)
+	report.Unmapped = append(notes, report.Unmapped...)
+
+	respondImportReport(c, report)
+}
+
+// respondImportReport writes the report: 422 when rows failed validation, 200 otherwise
+func respondImportReport(c *gin.Context, report *models.ProductImportReport) {
+	if len(report.Errors) > 0 {
+		resp := models.ErrorResponse(c, "Import has validation errors; nothing was imported")
+		resp.Data = report
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	message := "Products imported successfully"
+	if report.DryRun {
+		message = "Dry run passed; no changes were made"
+	} else {
+		log.Printf("[admin.import] imported %d product(s): %d created, %d updated", report.Products, report.Created, report.Updated)
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse(c, message, report))
+}
@@ -0,0 +1,243 @@
+package product_controller
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/utils"
+)
+
+// shopifyMappedColumns are the Shopify product CSV columns the adapter converts;
+// anything else with data ends up in the mapping report
+var shopifyMappedColumns = mappedColumns(
+	"handle", "title", "body (html)", "type", "product category", "tags", "published", "status",
+	"option1 name", "option1 value", "option2 name", "option2 value", "option3 name", "option3 value",
+	"variant sku", "variant inventory qty", "variant price", "variant barcode",
+	"image src", "image position", "seo title", "seo description",
+)
+
+// shopifyImage is one Image Src row of a product
+type shopifyImage struct {
+	url      string
+	position int
+}
+
+// parseShopifyCSV reads a standard Shopify product export. Rows are grouped by Handle:
+// the first row carries the product, every row with option values or a Variant SKU is
+// a variant, and Image Src rows add images.
+func parseShopifyCSV(r io.Reader) (int, []*importedProduct, []models.ProductImportError, []models.ProductImportNote, error) {
+	file, err := openPlatformCSV(r, "handle", "title")
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	rows, err := file.readAll()
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+
+	fileErrors := make([]models.ProductImportError, 0)
+	groups := make(map[string][]platformRow)
+	order := make([]string, 0)
+	for _, row := range rows {
+		handle := row.get("handle")
+		if handle == "" {
+			fileErrors = append(fileErrors, models.ProductImportError{Row: row.line, Field: "Handle", Message: "Handle is required"})
+			continue
+		}
+		if _, exists := groups[handle]; !exists {
+			order = append(order, handle)
+		}
+		groups[handle] = append(groups[handle], row)
+	}
+
+	products := make([]*importedProduct, 0, len(order))
+	for _, handle := range order {
+		p, err := convertShopifyProduct(handle, groups[handle])
+		if err != nil {
+			fileErrors = append(fileErrors, *err)
+			continue
+		}
+		products = append(products, p)
+	}
+
+	return len(rows), products, fileErrors, file.unmappedColumns(shopifyMappedColumns), nil
+}
+
+// convertShopifyProduct builds one product from the rows sharing a handle
+func convertShopifyProduct(handle string, rows []platformRow) (*importedProduct, *models.ProductImportError) {
+	// Step 1: Product fields come from the first row with a Title
+	var head *platformRow
+	for i := range rows {
+		if rows[i].get("title") != "" {
+			head = &rows[i]
+			break
+		}
+	}
+	if head == nil {
+		return nil, &models.ProductImportError{
+			Row:     rows[0].line,
+			Handle:  handle,
+			Field:   "Title",
+			Message: fmt.Sprintf("No row with a Title for handle %q", handle),
+		}
+	}
+
+	p := newImportedProduct(head.line, handle)
+	req := &p.Request
+	req.Name = head.get("title")
+	req.Description = htmlToText(head.get("body (html)"))
+	req.Tags = splitList(head.get("tags"), ",")
+	req.SEO = models.Seo{
+		SEOTitle:       head.get("seo title"),
+		SEODescription: head.get("seo description"),
+	}
+	fillMissingSEO(p)
+
+	switch status := strings.ToLower(head.get("status")); status {
+	case "active":
+		req.Status = "Active"
+	case "draft":
+		req.Status = "Draft"
+	case "archived":
+		req.Status = "Draft"
+		p.addNote(head.line, "Status", status, "Archived products are imported as Draft")
+	default:
+		req.Status = "Draft"
+		if strings.EqualFold(head.get("published"), "true") {
+			req.Status = "Active"
+		}
+	}
+
+	// Product type first, then the Shopify taxonomy path
+	setCategoryCandidates(p, append([]string{head.get("type")}, categoryCandidates(head.get("product category"))...)...)
+
+	// Step 2: Option names live on the first row only. A single "Title" option
+	// is Shopify's placeholder for products without variants.
+	var optionNames [3]string
+	for i := range optionNames {
+		optionNames[i] = head.get(fmt.Sprintf("option%d name", i+1))
+	}
+	hasVariants := optionNames[0] != "" &&
+		!(strings.EqualFold(optionNames[0], "Title") && strings.EqualFold(head.get("option1 value"), "Default Title"))
+
+	variants := make([]*models.ProductVariant, 0, len(optionNames))
+	if hasVariants {
+		for _, name := range optionNames {
+			if name != "" {
+				variants = append(variants, &models.ProductVariant{Type: name, Options: make([]string, 0)})
+			}
+		}
+	}
+
+	// Step 3: Variant rows and images
+	images := make([]shopifyImage, 0)
+	seenImages := make(map[string]bool)
+	priceSet, priceNoted := false, false
+
+	for _, row := range rows {
+		if url := row.get("image src"); url != "" && !seenImages[url] {
+			seenImages[url] = true
+			position, _ := strconv.Atoi(row.get("image position"))
+			images = append(images, shopifyImage{url: url, position: position})
+		}
+
+		if row.get("option1 value") == "" && row.get("variant sku") == "" && row.get("variant price") == "" {
+			continue // Image-only row
+		}
+
+		item := models.InventoryField{SKU: row.get("variant sku")}
+		item.Barcode = shopifyBarcode(p, row)
+
+		if raw := row.get("variant inventory qty"); raw != "" {
+			quantity, err := strconv.Atoi(raw)
+			switch {
+			case err != nil:
+				p.addError(row.line, "Variant Inventory Qty", fmt.Sprintf("Invalid quantity %q", raw))
+				continue
+			case quantity < 0:
+				p.addNote(row.line, "Variant Inventory Qty", raw, "Negative stock (oversold) imported as 0")
+			default:
+				item.Quantity = quantity
+			}
+		}
+
+		if raw := row.get("variant price"); raw != "" {
+			price, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				p.addError(row.line, "Variant Price", fmt.Sprintf("Invalid price %q", raw))
+				continue
+			}
+			if !priceSet {
+				req.Price, priceSet = price, true
+			} else if price != req.Price && !priceNoted {
+				priceNoted = true
+				p.addNote(row.line, "Variant Price", raw,
+					fmt.Sprintf("Variant prices differ; the product price is %.2f from the first variant", req.Price))
+			}
+		}
+
+		if !hasVariants {
+			if len(req.Inventory) == 0 {
+				addDefaultCombo(p, row.line, item)
+			}
+			continue
+		}
+
+		combo := make([]string, 0, len(variants))
+		for i, name := range optionNames {
+			if name == "" {
+				continue
+			}
+			value := row.get(fmt.Sprintf("option%d value", i+1))
+			if value == "" {
+				p.addError(row.line, fmt.Sprintf("Option%d Value", i+1), fmt.Sprintf("Variant has no %s", name))
+				combo = nil
+				break
+			}
+			appendOption(variants[len(combo)], value)
+			combo = append(combo, value)
+		}
+		if combo == nil {
+			continue
+		}
+		item.Combo = combo
+		item.VariantName = strings.Join(combo, " / ")
+		req.Inventory = append(req.Inventory, item)
+		p.InventoryRows = append(p.InventoryRows, row.line)
+	}
+
+	for _, variant := range variants {
+		req.Variants = append(req.Variants, *variant)
+	}
+
+	// Step 4: Images in Shopify's order; the first becomes the primary image
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].position != 0 && (images[j].position == 0 || images[i].position < images[j].position)
+	})
+	for i, img := range images {
+		if i == 0 {
+			req.Media.Primary = models.MediaURL{URL: img.url}
+			continue
+		}
+		order := i - 1
+		req.Media.Other = append(req.Media.Other, models.MediaURL{URL: img.url, Order: &order})
+	}
+
+	return p, nil
+}
+
+// shopifyBarcode returns the variant's barcode, dropping values that aren't a GTIN/EAN
+// (Shopify accepts any string, we don't)
+func shopifyBarcode(p *importedProduct, row platformRow) string {
+	// Exports often prefix barcodes with ' so spreadsheets keep leading zeros
+	barcode := strings.TrimPrefix(row.get("variant barcode"), "'")
+	if barcode != "" && !utils.IsValidGTIN(barcode) {
+		p.addNote(row.line, "Variant Barcode", barcode, "Not a valid GTIN/EAN; barcode not imported")
+		return ""
+	}
+	return barcode
+}
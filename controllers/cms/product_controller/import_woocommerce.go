@@ -0,0 +1,350 @@
+package product_controller
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/utils"
+)
+
+// wooMappedColumns are the WooCommerce export columns the adapter converts
+// (plus every "Attribute N ..." column); anything else with data ends up in the mapping report
+var wooMappedColumns = mappedColumns(
+	"id", "type", "sku", "name", "published", "short description", "description",
+	"regular price", "categories", "tags", "images", "parent",
+	"in stock?", "stock", "low stock amount",
+)
+
+// wooProduct is a simple or variable product row with the variations attached to it
+type wooProduct struct {
+	row        platformRow
+	p          *importedProduct
+	variable   bool
+	attributes []models.ProductVariant // Parent attributes in column order
+	priceSet   bool
+	priceNoted bool
+}
+
+// parseWooCommerceCSV reads the WooCommerce product CSV export. Simple and variable
+// products become products; variation rows (linked through Parent as "id:123" or the
+// parent's SKU) become inventory combos. Grouped and external products are skipped.
+func parseWooCommerceCSV(r io.Reader) (int, []*importedProduct, []models.ProductImportError, []models.ProductImportNote, error) {
+	file, err := openPlatformCSV(r, "type", "name")
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	rows, err := file.readAll()
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+
+	mapped := make(map[string]bool, len(wooMappedColumns))
+	for column := range wooMappedColumns {
+		mapped[column] = true
+	}
+	for column := range file.columns {
+		if strings.HasPrefix(column, "attribute ") {
+			mapped[column] = true
+		}
+	}
+	notes := file.unmappedColumns(mapped)
+	fileErrors := make([]models.ProductImportError, 0)
+
+	// Step 1: Parent products (a variation may appear before its parent)
+	parents := make([]*wooProduct, 0)
+	byRef := make(map[string]*wooProduct) // "id:123" and lowercase SKU
+	variations := make([]platformRow, 0)
+
+	for _, row := range rows {
+		types := splitWooList(strings.ToLower(row.get("type")))
+		switch {
+		case slices.Contains(types, "variation"):
+			variations = append(variations, row)
+		case slices.Contains(types, "variable"), slices.Contains(types, "simple"):
+			wp := convertWooProduct(row, slices.Contains(types, "variable"))
+			parents = append(parents, wp)
+			if id := row.get("id"); id != "" {
+				byRef["id:"+id] = wp
+			}
+			if sku := row.get("sku"); sku != "" {
+				byRef[strings.ToLower(sku)] = wp
+			}
+		default:
+			notes = append(notes, models.ProductImportNote{
+				Row:     row.line,
+				Handle:  wooHandle(row),
+				Field:   "Type",
+				Value:   row.get("type"),
+				Message: "Only simple and variable products can be imported; row skipped",
+			})
+		}
+	}
+
+	// Step 2: Variations become inventory combos of their parent
+	children := make(map[*wooProduct][]platformRow)
+	for _, row := range variations {
+		ref := row.get("parent")
+		wp := byRef[ref]
+		if wp == nil {
+			wp = byRef[strings.ToLower(ref)]
+		}
+		if wp == nil || !wp.variable {
+			fileErrors = append(fileErrors, models.ProductImportError{
+				Row:     row.line,
+				Handle:  wooHandle(row),
+				Field:   "Parent",
+				Message: fmt.Sprintf("Parent variable product %q not found in this file", ref),
+			})
+			continue
+		}
+		children[wp] = append(children[wp], row)
+	}
+
+	products := make([]*importedProduct, 0, len(parents))
+	for _, wp := range parents {
+		if wp.variable {
+			addWooVariations(wp, children[wp])
+		}
+		products = append(products, wp.p)
+	}
+
+	return len(rows), products, fileErrors, notes, nil
+}
+
+// convertWooProduct builds a product from a simple or variable product row
+func convertWooProduct(row platformRow, variable bool) *wooProduct {
+	p := newImportedProduct(row.line, wooHandle(row))
+	wp := &wooProduct{row: row, p: p, variable: variable}
+	req := &p.Request
+
+	req.Name = row.get("name")
+	req.Description = htmlToText(row.get("description"))
+	if short := htmlToText(row.get("short description")); short != "" {
+		if req.Description == "" {
+			req.Description = short
+		} else {
+			p.addNote(row.line, "Short description", short, "Short description not imported; the product uses Description")
+		}
+	}
+	req.Tags = splitWooList(row.get("tags"))
+	fillMissingSEO(p)
+
+	switch published := row.get("published"); published {
+	case "1":
+		req.Status = "Active"
+	case "-1":
+		req.Status = "Draft"
+		p.addNote(row.line, "Published", published, "Private products are imported as Draft")
+	default:
+		req.Status = "Draft"
+	}
+
+	// Products have one category here; try each WooCommerce category in turn
+	categories := splitWooList(row.get("categories"))
+	for _, category := range categories {
+		setCategoryCandidates(p, categoryCandidates(category)...)
+	}
+	if len(categories) > 1 {
+		p.addNote(row.line, "Categories", row.get("categories"), "Only one category is kept: the first that matches")
+	}
+
+	if raw := row.get("low stock amount"); raw != "" {
+		threshold, err := strconv.Atoi(raw)
+		if err != nil || threshold < 0 {
+			p.addError(row.line, "Low stock amount", fmt.Sprintf("Invalid low stock amount %q", raw))
+		} else {
+			req.LowStockThreshold = &threshold
+		}
+	}
+
+	for i, url := range splitWooList(row.get("images")) {
+		if i == 0 {
+			req.Media.Primary = models.MediaURL{URL: url}
+			continue
+		}
+		order := i - 1
+		req.Media.Other = append(req.Media.Other, models.MediaURL{URL: url, Order: &order})
+	}
+
+	if !variable {
+		setWooPrice(wp, row)
+		item := models.InventoryField{SKU: row.get("sku")}
+		if quantity, ok := wooStock(p, row); ok {
+			item.Quantity = quantity
+			addDefaultCombo(p, row.line, item)
+		}
+		return wp
+	}
+
+	// Variable products: SKUs belong to the variations, options come from the attributes
+	if sku := row.get("sku"); sku != "" {
+		p.addNote(row.line, "SKU", sku, "Parent SKU not imported; each variation keeps its own SKU")
+	}
+	for n := 1; ; n++ {
+		nameColumn := fmt.Sprintf("attribute %d name", n)
+		if _, exists := row.file.columns[nameColumn]; !exists {
+			break
+		}
+		if name := row.get(nameColumn); name != "" {
+			wp.attributes = append(wp.attributes, models.ProductVariant{
+				Type:    name,
+				Options: splitWooList(row.get(fmt.Sprintf("attribute %d value(s)", n))),
+			})
+		}
+	}
+	return wp
+}
+
+// addWooVariations turns a variable product's variations into inventory combos. Attributes
+// no variation uses are descriptive (e.g. Material) and become composition entries.
+func addWooVariations(wp *wooProduct, rows []platformRow) {
+	p := wp.p
+	if len(rows) == 0 {
+		p.addError(wp.row.line, "Type", "Variable product has no variations")
+		return
+	}
+
+	// The variation's attribute columns may be in a different order from the parent's
+	values := make([]map[string]string, len(rows))
+	used := make(map[string]bool)
+	for i, row := range rows {
+		values[i] = wooAttributeValues(row)
+		for name := range values[i] {
+			used[name] = true
+		}
+	}
+
+	variantIndex := make([]int, 0, len(wp.attributes)) // wp.attributes index of each variant
+	for i, attr := range wp.attributes {
+		if !used[strings.ToLower(attr.Type)] {
+			p.Request.Composition = append(p.Request.Composition, models.Composition{
+				Label:   attr.Type,
+				Content: strings.Join(attr.Options, ", "),
+			})
+			continue
+		}
+		p.Request.Variants = append(p.Request.Variants, attr)
+		variantIndex = append(variantIndex, i)
+	}
+
+	for i, row := range rows {
+		addWooVariation(wp, row, values[i], variantIndex)
+	}
+	if len(p.Request.Inventory) == 0 {
+		p.addError(wp.row.line, "Type", "None of the product's variations could be imported")
+	}
+}
+
+// addWooVariation adds one variation row as an inventory combo
+func addWooVariation(wp *wooProduct, row platformRow, values map[string]string, variantIndex []int) {
+	p := wp.p
+
+	combo := make([]string, 0, len(variantIndex))
+	for i, attrIndex := range variantIndex {
+		name := wp.attributes[attrIndex].Type
+		value := values[strings.ToLower(name)]
+		if value == "" {
+			// "Any Size" variations don't map onto concrete combos
+			p.addNote(row.line, "Attribute value(s)", "Any "+name, "Variations for any value of an attribute can't be imported; row skipped")
+			return
+		}
+		appendOption(&p.Request.Variants[i], value)
+		combo = append(combo, value)
+	}
+
+	quantity, ok := wooStock(p, row)
+	if !ok {
+		return
+	}
+	setWooPrice(wp, row)
+
+	p.Request.Inventory = append(p.Request.Inventory, models.InventoryField{
+		Combo:       combo,
+		VariantName: strings.Join(combo, " / "),
+		Quantity:    quantity,
+		SKU:         row.get("sku"),
+	})
+	p.InventoryRows = append(p.InventoryRows, row.line)
+}
+
+// wooAttributeValues maps a variation's lowercase attribute names to their values
+func wooAttributeValues(row platformRow) map[string]string {
+	values := make(map[string]string)
+	for n := 1; ; n++ {
+		nameColumn := fmt.Sprintf("attribute %d name", n)
+		if _, exists := row.file.columns[nameColumn]; !exists {
+			return values
+		}
+		name, value := row.get(nameColumn), row.get(fmt.Sprintf("attribute %d value(s)", n))
+		if name != "" && value != "" {
+			values[strings.ToLower(name)] = value
+		}
+	}
+}
+
+// setWooPrice takes the product price from the first row with a regular price
+func setWooPrice(wp *wooProduct, row platformRow) {
+	raw := row.get("regular price")
+	if raw == "" {
+		return
+	}
+	price, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		wp.p.addError(row.line, "Regular price", fmt.Sprintf("Invalid price %q", raw))
+		return
+	}
+	if !wp.priceSet {
+		wp.p.Request.Price, wp.priceSet = price, true
+	} else if price != wp.p.Request.Price && !wp.priceNoted {
+		wp.priceNoted = true
+		wp.p.addNote(row.line, "Regular price", raw,
+			fmt.Sprintf("Variation prices differ; the product price is %.2f from the first variation", wp.p.Request.Price))
+	}
+}
+
+// wooStock reads a row's stock; untracked stock is imported as 0
+func wooStock(p *importedProduct, row platformRow) (int, bool) {
+	raw := row.get("stock")
+	if raw == "" {
+		if row.get("in stock?") == "1" {
+			p.addNote(row.line, "Stock", "", "Stock isn't tracked for this item; imported as 0")
+		}
+		return 0, true
+	}
+	quantity, err := strconv.Atoi(raw)
+	if err != nil {
+		p.addError(row.line, "Stock", fmt.Sprintf("Invalid stock %q", raw))
+		return 0, false
+	}
+	if quantity < 0 {
+		p.addNote(row.line, "Stock", raw, "Negative stock (backordered) imported as 0")
+		return 0, true
+	}
+	return quantity, true
+}
+
+// wooHandle identifies a WooCommerce row in the report: its SKU, else its ID, else its name
+func wooHandle(row platformRow) string {
+	if sku := row.get("sku"); sku != "" {
+		return sku
+	}
+	if id := row.get("id"); id != "" {
+		return "id:" + id
+	}
+	return utils.Slugify(row.get("name"))
+}
+
+// splitWooList splits a WooCommerce list cell on commas, honouring "\," escapes
+func splitWooList(s string) []string {
+	const placeholder = "\x00"
+	s = strings.ReplaceAll(s, `\,`, placeholder)
+	out := splitList(s, ",")
+	for i, part := range out {
+		out[i] = strings.ReplaceAll(part, placeholder, ",")
+	}
+	return out
+}
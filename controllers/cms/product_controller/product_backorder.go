@@ -0,0 +1,64 @@
+package product_controller
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+)
+
+// validateBackorders checks a product's backorder policy and its combos' overrides as they
+// will be saved. Bundles take their stock from their components, so they can't be
+// backordered. The storefront-only available counts are dropped.
+func validateBackorders(productType string, policy *models.BackorderPolicy, inventory []models.InventoryField) error {
+	policy.Available = nil
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if productType == models.ProductTypeBundle && policy.Allowed() {
+		return errors.New("bundles can't be backordered; allow backorders on their components instead")
+	}
+	for i := range inventory {
+		override := inventory[i].Backorder
+		if override == nil {
+			continue
+		}
+		override.Available = nil
+		if err := override.Validate(); err != nil {
+			return fmt.Errorf("Inventory %q: %w", inventory[i].VariantName, err)
+		}
+	}
+	return nil
+}
+
+// applyBackorderUpdates adds a new backorder policy to the update map and validates the
+// product's resulting policy and combo overrides (a new inventory or product type may
+// already be in the map)
+func applyBackorderUpdates(product *models.Product, updates map[string]interface{}, policy *models.BackorderPolicy) error {
+	_, inventoryChanged := updates["inventory"]
+	_, typeChanged := updates["product_type"]
+	if policy == nil && !inventoryChanged && !typeChanged {
+		return nil
+	}
+
+	newPolicy := product.Backorder
+	if policy != nil {
+		newPolicy = *policy
+	}
+	productType := product.ProductType
+	if t, ok := updates["product_type"].(string); ok {
+		productType = t
+	}
+	inventory := []models.InventoryField(product.Inventory)
+	if inv, ok := updates["inventory"].(models.InventoryList); ok {
+		inventory = inv
+	}
+
+	if err := validateBackorders(productType, &newPolicy, inventory); err != nil {
+		return err
+	}
+	if policy != nil {
+		updates["backorder"] = newPolicy
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package product_controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// validateBundle checks a product's type and components as they will be saved: bundles
+// need components and hold no stock of their own; standard products have no components.
+// Component SKUs are normalised in place.
+func validateBundle(
+	ctx context.Context,
+	productID uuid.UUID,
+	productType string,
+	components []models.BundleComponent,
+	inventory []models.InventoryField,
+) error {
+	if productType != models.ProductTypeBundle {
+		if len(components) > 0 {
+			return fmt.Errorf("%w: bundle_components need product_type bundle", services.ErrInvalidBundle)
+		}
+		return nil
+	}
+	if len(inventory) > 0 {
+		return fmt.Errorf("%w: bundles take their stock from their components; leave inventory empty", services.ErrInvalidBundle)
+	}
+	return services.GetBundleService().Validate(ctx, productID, components)
+}
+
+// applyBundleUpdates adds the bundle fields of an update to the update map and validates
+// the product's resulting type, components and inventory (a new inventory may already be
+// in the map). Switching a bundle back to standard drops its components.
+func applyBundleUpdates(
+	ctx context.Context,
+	product *models.Product,
+	updates map[string]interface{},
+	productType *string,
+	components *[]models.BundleComponent,
+) error {
+	_, inventoryChanged := updates["inventory"]
+	if productType == nil && components == nil && !inventoryChanged {
+		return nil
+	}
+
+	newType := product.ProductType
+	if productType != nil {
+		newType = *productType
+		updates["product_type"] = newType
+	}
+
+	newComponents := []models.BundleComponent(product.BundleComponents)
+	if components != nil {
+		newComponents = *components
+	} else if newType != models.ProductTypeBundle && len(newComponents) > 0 {
+		newComponents = nil
+	}
+
+	inventory := []models.InventoryField(product.Inventory)
+	if inv, ok := updates["inventory"].(models.InventoryList); ok {
+		inventory = inv
+	}
+
+	if err := validateBundle(ctx, product.ID, newType, newComponents, inventory); err != nil {
+		return err
+	}
+	if components != nil || newType != product.ProductType {
+		updates["bundle_components"] = models.BundleComponentList(newComponents)
+	}
+	return nil
+}
+
+// respondBundleError writes a 400 for bundle validation problems and a 500 for anything else
+func respondBundleError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidBundle) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+}
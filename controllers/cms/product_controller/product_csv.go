@@ -0,0 +1,478 @@
+package product_controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/utils"
+	"github.com/google/uuid"
+)
+
+// productCSVColumns is the layout shared by the catalogue import and export.
+//
+// Every product has one "product" row plus child rows with the same handle:
+// one "variant" row per variant type and one "inventory" row per combo.
+// List values (tags, images, options, combo, composition) are separated by "|";
+// composition entries are written as "Label: Content". Times are RFC 3339, and
+// bundle_components, quantity_rules and backorder are written as JSON, as in the API.
+// Inventory rows use sale_price, sale_starts_at, sale_ends_at and backorder for the
+// combo's own sale and backorder settings. Bundle components must already be in the
+// catalogue when the file is imported.
+var productCSVColumns = []string{
+	"handle", "row_type", "id",
+	"name", "description", "price", "status", "category", "tags",
+	"compare_at_price", "sale_price", "sale_starts_at", "sale_ends_at",
+	"sku_pattern", "low_stock_threshold",
+	"product_type", "bundle_components", "quantity_rules", "backorder",
+	"publish_at", "unpublish_at",
+	"primary_image", "other_images", "composition",
+	"seo_title", "seo_description",
+	"variant_type", "variant_options",
+	"variant_name", "combo", "sku", "barcode", "quantity",
+}
+
+// csvExtendedColumn marks files in the current layout; older files don't carry the
+// pricing, bundle, rule, backorder and schedule columns, so imports leave those as they are
+const csvExtendedColumn = "product_type"
+
+const (
+	csvRowProduct   = "product"
+	csvRowVariant   = "variant"
+	csvRowInventory = "inventory"
+	csvListSep      = "|"
+)
+
+// parseProductCSV reads a catalogue CSV into products. Row-level problems are recorded on
+// the product (or returned as orphan errors when a row can't be tied to a product);
+// the error return is reserved for files that can't be read as CSV at all.
+func parseProductCSV(r io.Reader) (int, []*importedProduct, []models.ProductImportError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	for _, required := range []string{"handle", "row_type"} {
+		if _, ok := columns[required]; !ok {
+			return 0, nil, nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	_, extended := columns[csvExtendedColumn]
+
+	byHandle := make(map[string]*importedProduct)
+	order := make([]*importedProduct, 0)
+	fileErrors := make([]models.ProductImportError, 0)
+	rows := 0
+
+	// Child rows may come before their product row (e.g. after sorting in a spreadsheet)
+	productFor := func(handle string, row int) *importedProduct {
+		p, ok := byHandle[handle]
+		if !ok {
+			p = newImportedProduct(row, handle)
+			p.Row = 0 // Set once the product row is seen
+			p.Extended = extended
+			byHandle[handle] = p
+			order = append(order, p)
+		}
+		return p
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return rows, nil, nil, fmt.Errorf("invalid CSV on line %d: %w", parseErr.Line, parseErr.Err)
+			}
+			return rows, nil, nil, err
+		}
+		rows++
+		row, _ := reader.FieldPos(0)
+
+		get := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		handle := get("handle")
+		if handle == "" {
+			if strings.TrimSpace(strings.Join(record, "")) == "" {
+				continue // Blank line
+			}
+			fileErrors = append(fileErrors, models.ProductImportError{Row: row, Field: "handle", Message: "handle is required"})
+			continue
+		}
+
+		switch rowType := strings.ToLower(get("row_type")); rowType {
+		case csvRowProduct:
+			p := productFor(handle, row)
+			if p.Row != 0 {
+				p.addError(row, "handle", fmt.Sprintf("Duplicate product row for handle %q (first on row %d)", handle, p.Row))
+				continue
+			}
+			p.Row = row
+			parseProductRow(p, row, get)
+
+		case csvRowVariant:
+			p := productFor(handle, row)
+			variant := models.ProductVariant{
+				Type:    get("variant_type"),
+				Options: splitCSVList(get("variant_options")),
+			}
+			if variant.Type == "" || len(variant.Options) == 0 {
+				p.addError(row, "variant_type", "Variant rows need variant_type and variant_options")
+				continue
+			}
+			p.Request.Variants = append(p.Request.Variants, variant)
+
+		case csvRowInventory:
+			p := productFor(handle, row)
+			combo := splitCSVList(get("combo"))
+			variantName := get("variant_name")
+			if variantName == "" && len(combo) > 0 {
+				variantName = strings.Join(combo, " / ")
+			}
+			quantity := 0
+			if raw := get("quantity"); raw != "" {
+				q, err := strconv.Atoi(raw)
+				if err != nil || q < 0 {
+					p.addError(row, "quantity", fmt.Sprintf("Invalid quantity %q", raw))
+					continue
+				}
+				quantity = q
+			}
+			item := models.InventoryField{
+				Combo:        combo,
+				VariantName:  variantName,
+				Quantity:     quantity,
+				SKU:          get("sku"),
+				Barcode:      get("barcode"),
+				SalePrice:    parseCSVPrice(p, row, "sale_price", get),
+				SaleStartsAt: parseCSVTime(p, row, "sale_starts_at", get),
+				SaleEndsAt:   parseCSVTime(p, row, "sale_ends_at", get),
+			}
+			var backorder models.BackorderPolicy
+			if parseCSVJSON(p, row, "backorder", get, &backorder) {
+				item.Backorder = &backorder
+			}
+			p.Request.Inventory = append(p.Request.Inventory, item)
+			p.InventoryRows = append(p.InventoryRows, row)
+
+		default:
+			fileErrors = append(fileErrors, models.ProductImportError{
+				Row:     row,
+				Handle:  handle,
+				Field:   "row_type",
+				Message: fmt.Sprintf("Unknown row_type %q (expected product, variant or inventory)", rowType),
+			})
+		}
+	}
+
+	products := make([]*importedProduct, 0, len(order))
+	for _, p := range order {
+		if p.Row == 0 {
+			row := 0
+			if len(p.InventoryRows) > 0 {
+				row = p.InventoryRows[0]
+			}
+			fileErrors = append(fileErrors, models.ProductImportError{
+				Row:     row,
+				Handle:  p.Handle,
+				Message: fmt.Sprintf("No product row for handle %q", p.Handle),
+			})
+			continue
+		}
+		products = append(products, p)
+	}
+
+	return rows, products, fileErrors, nil
+}
+
+// parseProductRow fills the product-level fields of an imported product
+func parseProductRow(p *importedProduct, row int, get func(string) string) {
+	req := &p.Request
+
+	if raw := get("id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			p.addError(row, "id", fmt.Sprintf("Invalid product id %q", raw))
+		} else {
+			p.ID = &id
+		}
+	}
+
+	req.Name = get("name")
+	req.Description = get("description")
+	req.Status = get("status")
+	req.SKUPattern = get("sku_pattern")
+	req.Tags = splitCSVList(get("tags"))
+	p.Category = get("category")
+
+	if price := parseCSVPrice(p, row, "price", get); price != nil {
+		req.Price = *price
+	}
+	req.CompareAtPrice = parseCSVPrice(p, row, "compare_at_price", get)
+	req.SalePrice = parseCSVPrice(p, row, "sale_price", get)
+	req.SaleStartsAt = parseCSVTime(p, row, "sale_starts_at", get)
+	req.SaleEndsAt = parseCSVTime(p, row, "sale_ends_at", get)
+	req.PublishAt = parseCSVTime(p, row, "publish_at", get)
+	req.UnpublishAt = parseCSVTime(p, row, "unpublish_at", get)
+
+	req.ProductType = strings.ToLower(get("product_type"))
+	parseCSVJSON(p, row, "bundle_components", get, &req.BundleComponents)
+	var rules models.QuantityRules
+	if parseCSVJSON(p, row, "quantity_rules", get, &rules) {
+		req.QuantityRules = &rules
+	}
+	var backorder models.BackorderPolicy
+	if parseCSVJSON(p, row, "backorder", get, &backorder) {
+		req.Backorder = &backorder
+	}
+
+	if raw := get("low_stock_threshold"); raw != "" {
+		threshold, err := strconv.Atoi(raw)
+		if err != nil || threshold < 0 {
+			p.addError(row, "low_stock_threshold", fmt.Sprintf("Invalid low_stock_threshold %q", raw))
+		} else {
+			req.LowStockThreshold = &threshold
+		}
+	}
+
+	req.Media.Primary = models.MediaURL{URL: get("primary_image")}
+	for i, url := range splitCSVList(get("other_images")) {
+		order := i
+		req.Media.Other = append(req.Media.Other, models.MediaURL{URL: url, Order: &order})
+	}
+
+	for _, entry := range splitCSVList(get("composition")) {
+		label, content, found := strings.Cut(entry, ":")
+		if !found {
+			p.addError(row, "composition", fmt.Sprintf("Composition entry %q must be \"Label: Content\"", entry))
+			continue
+		}
+		req.Composition = append(req.Composition, models.Composition{
+			Label:   strings.TrimSpace(label),
+			Content: strings.TrimSpace(content),
+		})
+	}
+
+	req.SEO = models.Seo{
+		SEOTitle:       get("seo_title"),
+		SEODescription: get("seo_description"),
+	}
+}
+
+// parseCSVPrice reads an optional price cell (nil when empty or invalid)
+func parseCSVPrice(p *importedProduct, row int, column string, get func(string) string) *float64 {
+	raw := get(column)
+	if raw == "" {
+		return nil
+	}
+	price, err := strconv.ParseFloat(strings.TrimPrefix(raw, "$"), 64)
+	if err != nil {
+		p.addError(row, column, fmt.Sprintf("Invalid %s %q", column, raw))
+		return nil
+	}
+	return &price
+}
+
+// parseCSVTime reads an optional RFC 3339 time cell (nil when empty or invalid)
+func parseCSVTime(p *importedProduct, row int, column string, get func(string) string) *time.Time {
+	raw := get(column)
+	if raw == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		p.addError(row, column, fmt.Sprintf("Invalid %s %q (expected RFC 3339, e.g. 2026-11-27T00:00:00Z)", column, raw))
+		return nil
+	}
+	return &t
+}
+
+// parseCSVJSON reads an optional JSON cell into target; returns whether it was set
+func parseCSVJSON(p *importedProduct, row int, column string, get func(string) string, target interface{}) bool {
+	raw := get(column)
+	if raw == "" {
+		return false
+	}
+	if err := json.Unmarshal([]byte(raw), target); err != nil {
+		p.addError(row, column, fmt.Sprintf("Invalid %s: %v", column, err))
+		return false
+	}
+	return true
+}
+
+// writeProductCSV writes products in the import layout. categoryPaths maps category
+// IDs to the "Parent > Child" path the import resolves back to the same category.
+func writeProductCSV(w io.Writer, products []models.Product, categoryPaths map[uuid.UUID]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(productCSVColumns); err != nil {
+		return err
+	}
+
+	handles := make(map[string]int)
+	for _, product := range products {
+		// Handles only need to be unique within the file; the id is the real key
+		handle := utils.Slugify(product.Name)
+		if handle == "" {
+			handle = "product"
+		}
+		if n := handles[handle]; n > 0 {
+			handles[handle] = n + 1
+			handle = fmt.Sprintf("%s-%d", handle, n+1)
+		} else {
+			handles[handle] = 1
+		}
+
+		otherImages := make([]string, 0, len(product.Media.Other))
+		for _, img := range product.Media.Other {
+			otherImages = append(otherImages, img.URL)
+		}
+		composition := make([]string, 0, len(product.Composition))
+		for _, comp := range product.Composition {
+			composition = append(composition, comp.Label+": "+comp.Content)
+		}
+		threshold := ""
+		if product.LowStockThreshold != nil {
+			threshold = strconv.Itoa(*product.LowStockThreshold)
+		}
+		components := ""
+		if len(product.BundleComponents) > 0 {
+			components = csvJSON(product.BundleComponents)
+		}
+		rules := ""
+		if !product.QuantityRules.IsZero() {
+			rules = csvJSON(product.QuantityRules)
+		}
+		backorder := ""
+		if product.Backorder != (models.BackorderPolicy{}) {
+			backorder = csvJSON(product.Backorder)
+		}
+
+		records := [][]string{csvRecord(map[string]string{
+			"handle":              handle,
+			"row_type":            csvRowProduct,
+			"id":                  product.ID.String(),
+			"name":                product.Name,
+			"description":         product.Description,
+			"price":               strconv.FormatFloat(product.Price, 'f', 2, 64),
+			"status":              product.Status,
+			"category":            categoryPaths[product.SubCategoryID],
+			"tags":                strings.Join(product.Tags, csvListSep),
+			"compare_at_price":    csvPrice(product.CompareAtPrice),
+			"sale_price":          csvPrice(product.SalePrice),
+			"sale_starts_at":      csvTime(product.SaleStartsAt),
+			"sale_ends_at":        csvTime(product.SaleEndsAt),
+			"sku_pattern":         product.SKUPattern,
+			"low_stock_threshold": threshold,
+			"product_type":        product.ProductType,
+			"bundle_components":   components,
+			"quantity_rules":      rules,
+			"backorder":           backorder,
+			"publish_at":          csvTime(product.PublishAt),
+			"unpublish_at":        csvTime(product.UnpublishAt),
+			"primary_image":       product.Media.Primary.URL,
+			"other_images":        strings.Join(otherImages, csvListSep),
+			"composition":         strings.Join(composition, csvListSep),
+			"seo_title":           product.SEO.SEOTitle,
+			"seo_description":     product.SEO.SEODescription,
+		})}
+		for _, variant := range product.Variants {
+			records = append(records, csvRecord(map[string]string{
+				"handle":          handle,
+				"row_type":        csvRowVariant,
+				"variant_type":    variant.Type,
+				"variant_options": strings.Join(variant.Options, csvListSep),
+			}))
+		}
+		for _, item := range product.Inventory {
+			itemBackorder := ""
+			if item.Backorder != nil {
+				itemBackorder = csvJSON(item.Backorder)
+			}
+			records = append(records, csvRecord(map[string]string{
+				"handle":         handle,
+				"row_type":       csvRowInventory,
+				"variant_name":   item.VariantName,
+				"combo":          strings.Join(item.Combo, csvListSep),
+				"sku":            item.SKU,
+				"barcode":        item.Barcode,
+				"quantity":       strconv.Itoa(item.Quantity),
+				"sale_price":     csvPrice(item.SalePrice),
+				"sale_starts_at": csvTime(item.SaleStartsAt),
+				"sale_ends_at":   csvTime(item.SaleEndsAt),
+				"backorder":      itemBackorder,
+			}))
+		}
+
+		if err := writer.WriteAll(records); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvRecord lays out named values in productCSVColumns order
+func csvRecord(values map[string]string) []string {
+	record := make([]string, len(productCSVColumns))
+	for i, column := range productCSVColumns {
+		record[i] = values[column]
+	}
+	return record
+}
+
+// csvPrice writes an optional price ("" when unset)
+func csvPrice(price *float64) string {
+	if price == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*price, 'f', 2, 64)
+}
+
+// csvTime writes an optional time as RFC 3339 ("" when unset)
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// csvJSON writes a structured value as it appears in the API
+func csvJSON(v interface{}) string {
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
+
+// splitCSVList splits a "|"-separated cell, dropping empty entries
+func splitCSVList(s string) []string {
+	out := make([]string, 0)
+	for _, part := range strings.Split(s, csvListSep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
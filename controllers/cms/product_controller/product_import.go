@@ -0,0 +1,595 @@
+package product_controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	category_cache "github.com/Modeva-Ecommerce/modeva-cms-backend/cache"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// importedProduct is one product read from an import file, before validation
+type importedProduct struct {
+	Row               int        // File row of the product row
+	Handle            string     // Groups the product's rows within the file
+	ID                *uuid.UUID // Optional; upsert key alongside the SKUs
+	Category          string     // Category name, "Parent > Child" path or ID
+	CategoryFallbacks []string   // Tried in order when Category doesn't resolve
+	Request           models.ProductRequest
+	Extended          bool  // Carries pricing, bundle, rule, backorder and schedule fields (updates leave them otherwise)
+	InventoryRows     []int // File row of each inventory combo
+	Errors            []models.ProductImportError
+	Notes             []models.ProductImportNote
+
+	existingID *uuid.UUID // Set when the product matches one already in the catalogue
+}
+
+// addError records a validation problem against a row of the product
+func (p *importedProduct) addError(row int, field, message string) {
+	p.Errors = append(p.Errors, models.ProductImportError{
+		Row:     row,
+		Handle:  p.Handle,
+		Field:   field,
+		Message: message,
+	})
+}
+
+// addNote records source data that couldn't be converted as-is
+func (p *importedProduct) addNote(row int, field, value, message string) {
+	p.Notes = append(p.Notes, models.ProductImportNote{
+		Row:     row,
+		Handle:  p.Handle,
+		Field:   field,
+		Value:   value,
+		Message: message,
+	})
+}
+
+// newImportedProduct starts a product with the empty (non-nil) lists ProductRequest requires
+func newImportedProduct(row int, handle string) *importedProduct {
+	return &importedProduct{
+		Row:    row,
+		Handle: handle,
+		Request: models.ProductRequest{
+			Composition: make([]models.Composition, 0),
+			Tags:        make([]string, 0),
+			Variants:    make([]models.ProductVariant, 0),
+			Inventory:   make([]models.InventoryField, 0),
+		},
+		InventoryRows:     make([]int, 0),
+		CategoryFallbacks: make([]string, 0),
+		Errors:            make([]models.ProductImportError, 0),
+		Notes:             make([]models.ProductImportNote, 0),
+	}
+}
+
+// runProductImport validates the parsed products and, unless this is a dry run or any
+// row failed validation, upserts them all in a single transaction. Products are matched
+// to the catalogue by ID, then by SKU, so re-importing the same file changes nothing.
+func runProductImport(
+	ctx context.Context,
+	c *gin.Context,
+	rows int,
+	products []*importedProduct,
+	dryRun bool,
+) (*models.ProductImportReport, error) {
+	report := &models.ProductImportReport{
+		DryRun:   dryRun,
+		Rows:     rows,
+		Products: len(products),
+		Errors:   make([]models.ProductImportError, 0),
+		Unmapped: make([]models.ProductImportNote, 0),
+		Results:  make([]models.ProductImportResult, 0, len(products)),
+	}
+
+	categories, err := loadCategoryResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSKUs := make(map[string]string)   // lowercase SKU → handle
+	fileIDs := make(map[uuid.UUID]string) // product ID → handle
+
+	// Step 1: Validate every product
+	for _, p := range products {
+		if p.Request.Status == "" {
+			p.Request.Status = "Draft"
+		}
+
+		if p.ID != nil {
+			if other, exists := fileIDs[*p.ID]; exists {
+				p.addError(p.Row, "id", fmt.Sprintf("Product id is also used by %q in this file", other))
+			}
+			fileIDs[*p.ID] = p.Handle
+		}
+
+		if p.Request.SubCategoryID == uuid.Nil {
+			categoryID, err := categories.resolve(p.Category)
+			for _, fallback := range p.CategoryFallbacks {
+				if err == nil {
+					break
+				}
+				if id, fallbackErr := categories.resolve(fallback); fallbackErr == nil {
+					categoryID, err = id, nil
+					message := fmt.Sprintf("Category not found; used %q", fallback)
+					if strings.TrimSpace(p.Category) == "" {
+						message = fmt.Sprintf("No category given; used %q", fallback)
+					}
+					p.addNote(p.Row, "category", p.Category, message)
+				}
+			}
+			if err != nil {
+				p.addError(p.Row, "category", err.Error())
+			} else {
+				p.Request.SubCategoryID = categoryID
+			}
+		}
+
+		if err := binding.Validator.ValidateStruct(&p.Request); err != nil {
+			for _, fieldErr := range splitValidationErrors(err) {
+				// Category problems are already reported above, and "required" can't tell a
+				// sold-out combo (quantity 0) from a missing one; the parser validates quantities
+				if fieldErr.field == "sub_category_id" ||
+					(fieldErr.rule == "required" && strings.HasPrefix(fieldErr.field, "inventory[") && strings.HasSuffix(fieldErr.field, ".quantity")) {
+					continue
+				}
+				p.addError(p.Row, fieldErr.field, fieldErr.message)
+			}
+		}
+		for i, item := range p.Request.Inventory {
+			if len(item.Combo) == 0 || item.VariantName == "" {
+				p.addError(p.InventoryRows[i], "combo", "Inventory rows need a combo and a variant_name")
+			}
+		}
+
+		if err := matchExistingProduct(ctx, p); err != nil {
+			var skuErr *skuValidationError
+			if !errors.As(err, &skuErr) {
+				return nil, err
+			}
+			p.addError(p.Row, "id", skuErr.Error())
+		}
+
+		if len(p.Errors) == 0 {
+			productID := uuid.Nil
+			if p.existingID != nil {
+				productID = *p.existingID
+			} else if p.ID != nil {
+				productID = *p.ID
+			}
+			if err := validateImportedFields(ctx, p, productID); err != nil {
+				return nil, err
+			}
+			if err := prepareInventorySKUs(ctx, productID, p.Request.Name, p.Request.SKUPattern, p.Request.Variants, p.Request.Inventory); err != nil {
+				var skuErr *skuValidationError
+				if !errors.As(err, &skuErr) {
+					return nil, err
+				}
+				p.addError(p.Row, "sku", skuErr.Error())
+			}
+		}
+
+		// SKUs must also be unique across the products in the file
+		for i, item := range p.Request.Inventory {
+			if item.SKU == "" {
+				continue
+			}
+			key := strings.ToLower(item.SKU)
+			if other, exists := fileSKUs[key]; exists && other != p.Handle {
+				p.addError(p.InventoryRows[i], "sku", fmt.Sprintf("SKU %q is also used by %q in this file", item.SKU, other))
+				continue
+			}
+			fileSKUs[key] = p.Handle
+		}
+
+		report.Errors = append(report.Errors, p.Errors...)
+		report.Unmapped = append(report.Unmapped, p.Notes...)
+		if len(p.Errors) > 0 {
+			continue
+		}
+
+		result := models.ProductImportResult{
+			Row:       p.Row,
+			Handle:    p.Handle,
+			Name:      p.Request.Name,
+			Action:    models.ProductImportCreate,
+			ProductID: p.ID,
+		}
+		if p.existingID != nil {
+			result.Action = models.ProductImportUpdate
+			result.ProductID = p.existingID
+			report.Updated++
+		} else {
+			report.Created++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	// Step 2: Upsert everything in one transaction (with stock ledger entries)
+	productIDs := make([]uuid.UUID, 0, len(products))
+	err = config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, p := range products {
+			productID, err := upsertImportedProduct(tx, c, p)
+			if err != nil {
+				return fmt.Errorf("row %d (%s): %w", p.Row, p.Handle, err)
+			}
+			report.Results[i].ProductID = &productID
+			productIDs = append(productIDs, productID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	category_cache.Invalidate() // Product counts
+	services.GetStockAlertService().CheckProductsAsync(productIDs...)
+	services.GetBackInStockService().NotifyRestockedAsync(productIDs...)
+
+	return report, nil
+}
+
+// upsertImportedProduct creates or fully replaces one validated product and records the revision and price history
+func upsertImportedProduct(tx *gorm.DB, c *gin.Context, p *importedProduct) (uuid.UUID, error) {
+	productID, err := saveImportedProduct(tx, c, p)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	meta := revisionMetaFromContext(c, models.RevisionSourceImport)
+	if _, err := services.GetProductRevisionService().Record(tx, productID, meta); err != nil {
+		return uuid.Nil, err
+	}
+	return productID, services.GetPriceHistoryService().Record(tx, productID, meta)
+}
+
+// saveImportedProduct writes one validated product along with its stock ledger entries
+func saveImportedProduct(tx *gorm.DB, c *gin.Context, p *importedProduct) (uuid.UUID, error) {
+	req := p.Request
+	inventory := models.InventoryList(req.Inventory)
+	var quantityRules models.QuantityRules
+	if req.QuantityRules != nil {
+		quantityRules = *req.QuantityRules
+	}
+	var backorder models.BackorderPolicy
+	if req.Backorder != nil {
+		backorder = *req.Backorder
+	}
+
+	if p.existingID == nil {
+		product := models.Product{
+			Name:              req.Name,
+			Description:       req.Description,
+			Composition:       models.CompositionList(req.Composition),
+			Price:             req.Price,
+			CompareAtPrice:    req.CompareAtPrice,
+			SalePrice:         req.SalePrice,
+			SaleStartsAt:      req.SaleStartsAt,
+			SaleEndsAt:        req.SaleEndsAt,
+			SubCategoryID:     req.SubCategoryID,
+			Status:            req.Status,
+			Tags:              models.TagsList(req.Tags),
+			Media:             req.Media,
+			Variants:          models.VariantsList(req.Variants),
+			Inventory:         inventory,
+			ProductType:       req.ProductType,
+			BundleComponents:  models.BundleComponentList(req.BundleComponents),
+			QuantityRules:     quantityRules,
+			Backorder:         backorder,
+			SKUPattern:        req.SKUPattern,
+			LowStockThreshold: req.LowStockThreshold,
+			PublishAt:         req.PublishAt,
+			UnpublishAt:       req.UnpublishAt,
+			SEO:               req.SEO,
+		}
+		if p.ID != nil {
+			product.ID = *p.ID
+		}
+		if err := tx.Create(&product).Error; err != nil {
+			return uuid.Nil, err
+		}
+		return product.ID, services.GetStockService().RecordInventoryDiff(
+			tx, product.ID, nil, inventory, stockMetaFromContext(c, models.StockReasonInitial),
+		)
+	}
+
+	productID := *p.existingID
+	var current models.Product
+	if err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, inventory").
+		First(&current, "id = ?", productID).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	updates := map[string]interface{}{
+		"name":                req.Name,
+		"description":         req.Description,
+		"composition":         models.CompositionList(req.Composition),
+		"price":               req.Price,
+		"sub_category_id":     req.SubCategoryID,
+		"status":              req.Status,
+		"tags":                models.TagsList(req.Tags),
+		"media":               req.Media,
+		"variants":            models.VariantsList(req.Variants),
+		"inventory":           inventory,
+		"sku_pattern":         req.SKUPattern,
+		"low_stock_threshold": req.LowStockThreshold,
+		"seo":                 req.SEO,
+	}
+	if p.Extended {
+		updates["compare_at_price"] = priceColumn(req.CompareAtPrice)
+		updates["sale_price"] = priceColumn(req.SalePrice)
+		updates["sale_starts_at"] = scheduleColumn(req.SaleStartsAt)
+		updates["sale_ends_at"] = scheduleColumn(req.SaleEndsAt)
+		updates["product_type"] = req.ProductType
+		updates["bundle_components"] = models.BundleComponentList(req.BundleComponents)
+		updates["quantity_rules"] = quantityRules
+		updates["backorder"] = backorder
+		updates["publish_at"] = scheduleColumn(req.PublishAt)
+		updates["unpublish_at"] = scheduleColumn(req.UnpublishAt)
+	}
+	if err := tx.Model(&current).Updates(updates).Error; err != nil {
+		return uuid.Nil, err
+	}
+	return productID, services.GetStockService().RecordInventoryDiff(
+		tx, productID, current.Inventory, inventory, stockMetaFromContext(c, models.StockReasonCorrection),
+	)
+}
+
+// validateImportedFields runs the checks creating a product by hand does on the sale,
+// schedule, quantity rule, bundle and backorder fields. Bundle components are normalised
+// in place.
+func validateImportedFields(ctx context.Context, p *importedProduct, productID uuid.UUID) error {
+	req := &p.Request
+	if req.BundleComponents == nil {
+		req.BundleComponents = make([]models.BundleComponent, 0)
+	}
+	if req.ProductType == "" {
+		req.ProductType = models.ProductTypeStandard
+	}
+
+	if err := validateSchedule(req.Status, req.PublishAt, req.UnpublishAt); err != nil {
+		p.addError(p.Row, "publish_at", err.Error())
+	}
+	if err := validateSale(req.Price, req.SalePrice, req.SaleStartsAt, req.SaleEndsAt, req.Inventory); err != nil {
+		p.addError(p.Row, "sale_price", err.Error())
+	}
+	if req.QuantityRules != nil {
+		if err := req.QuantityRules.Validate(); err != nil {
+			p.addError(p.Row, "quantity_rules", err.Error())
+		}
+	}
+	if err := validateBundle(ctx, productID, req.ProductType, req.BundleComponents, req.Inventory); err != nil {
+		if !errors.Is(err, services.ErrInvalidBundle) {
+			return err
+		}
+		p.addError(p.Row, "bundle_components", err.Error())
+	}
+
+	var backorder models.BackorderPolicy
+	if req.Backorder != nil {
+		backorder = *req.Backorder
+	}
+	if err := validateBackorders(req.ProductType, &backorder, req.Inventory); err != nil {
+		p.addError(p.Row, "backorder", err.Error())
+	}
+	req.Backorder = &backorder
+	return nil
+}
+
+// priceColumn is the update map value for an optional price (NULL when unset)
+func priceColumn(price *float64) interface{} {
+	if price == nil {
+		return nil
+	}
+	return *price
+}
+
+// matchExistingProduct finds the catalogue product an imported product refers to:
+// by ID when one is given, otherwise by any of its SKUs
+func matchExistingProduct(ctx context.Context, p *importedProduct) error {
+	if p.ID != nil {
+		if err := checkNotTrashed(ctx, *p.ID); err != nil {
+			return err
+		}
+		var count int64
+		if err := config.CmsGorm.WithContext(ctx).
+			Model(&models.Product{}).
+			Where("id = ?", *p.ID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			p.existingID = p.ID
+		}
+		return nil
+	}
+
+	skus := make([]string, 0, len(p.Request.Inventory))
+	for _, item := range p.Request.Inventory {
+		if sku := strings.TrimSpace(item.SKU); sku != "" {
+			skus = append(skus, strings.ToLower(sku))
+		}
+	}
+	if len(skus) == 0 {
+		return nil
+	}
+
+	var productIDs []uuid.UUID
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`SELECT DISTINCT product_id FROM product_skus WHERE LOWER(sku) IN ?`, skus).
+		Scan(&productIDs).Error; err != nil {
+		return err
+	}
+	switch len(productIDs) {
+	case 0:
+		return nil
+	case 1:
+		if err := checkNotTrashed(ctx, productIDs[0]); err != nil {
+			return err
+		}
+		p.existingID = &productIDs[0]
+		return nil
+	default:
+		return &skuValidationError{message: "SKUs match more than one existing product; add the product id to pick one"}
+	}
+}
+
+// checkNotTrashed rejects importing over a product in the trash: it keeps its ID and SKUs
+// until it is purged, so it has to be restored (or purged) first
+func checkNotTrashed(ctx context.Context, productID uuid.UUID) error {
+	var count int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Unscoped().
+		Model(&models.Product{}).
+		Where("id = ? AND deleted_at IS NOT NULL", productID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return &skuValidationError{message: fmt.Sprintf("Product %s is in the trash; restore it before importing over it", productID)}
+	}
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════
+// Category Resolution
+// ═══════════════════════════════════════════════════════════
+
+// categoryResolver maps category names, "Parent > Child" paths and IDs to category IDs
+type categoryResolver struct {
+	ids    map[uuid.UUID]bool
+	byPath map[string][]uuid.UUID // "parent > child" (lowercase)
+	byName map[string][]uuid.UUID // subcategory name (lowercase)
+	top    map[string][]uuid.UUID // top-level category name (lowercase)
+}
+
+// loadCategoryResolver loads the whole category tree once per import
+func loadCategoryResolver(ctx context.Context) (*categoryResolver, error) {
+	var categories []models.Category
+	if err := config.CmsGorm.WithContext(ctx).
+		Select("id, name, parent_id, parent_name").
+		Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	r := &categoryResolver{
+		ids:    make(map[uuid.UUID]bool, len(categories)),
+		byPath: make(map[string][]uuid.UUID),
+		byName: make(map[string][]uuid.UUID),
+		top:    make(map[string][]uuid.UUID),
+	}
+	for _, cat := range categories {
+		name := strings.ToLower(strings.TrimSpace(cat.Name))
+		r.ids[cat.ID] = true
+		if cat.ParentID == nil {
+			r.top[name] = append(r.top[name], cat.ID)
+			continue
+		}
+		r.byName[name] = append(r.byName[name], cat.ID)
+		if cat.ParentName != nil {
+			path := strings.ToLower(strings.TrimSpace(*cat.ParentName)) + " > " + name
+			r.byPath[path] = append(r.byPath[path], cat.ID)
+		}
+	}
+	return r, nil
+}
+
+// resolve prefers subcategories; a bare name must match exactly one category
+func (r *categoryResolver) resolve(ref string) (uuid.UUID, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return uuid.Nil, errors.New("category is required")
+	}
+	if id, err := uuid.Parse(ref); err == nil {
+		if r.ids[id] {
+			return id, nil
+		}
+		return uuid.Nil, fmt.Errorf("category %q not found", ref)
+	}
+
+	var matches []uuid.UUID
+	if parts := strings.Split(ref, ">"); len(parts) == 2 {
+		path := strings.ToLower(strings.TrimSpace(parts[0])) + " > " + strings.ToLower(strings.TrimSpace(parts[1]))
+		matches = r.byPath[path]
+	} else {
+		name := strings.ToLower(ref)
+		matches = r.byName[name]
+		if len(matches) == 0 {
+			matches = r.top[name]
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("category %q not found", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return uuid.Nil, fmt.Errorf("category %q is ambiguous; use \"Parent > Child\"", ref)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════
+// Validation Helpers
+// ═══════════════════════════════════════════════════════════
+
+// validationLine matches one line of a gin/validator error message
+var validationLine = regexp.MustCompile(`Key: '[^.']*\.([^']+)' Error:Field validation for '[^']+' failed on the '([^']+)' tag`)
+
+type fieldError struct {
+	field   string
+	rule    string
+	message string
+}
+
+// splitValidationErrors turns a ProductRequest validation error into per-field messages
+func splitValidationErrors(err error) []fieldError {
+	var out []fieldError
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if m := validationLine.FindStringSubmatch(line); m != nil {
+			out = append(out, fieldError{
+				field:   toSnakeCase(m[1]),
+				rule:    m[2],
+				message: fmt.Sprintf("failed the %q rule", m[2]),
+			})
+		} else if strings.TrimSpace(line) != "" {
+			out = append(out, fieldError{message: line})
+		}
+	}
+	return out
+}
+
+// toSnakeCase converts a validator namespace like "Media.Primary.URL" to "media.primary.url"
+// and "SubCategoryID" to "sub_category_id"
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		isUpper := r >= 'A' && r <= 'Z'
+		if isUpper && i > 0 && runes[i-1] != '.' {
+			prevLower := runes[i-1] >= 'a' && runes[i-1] <= 'z'
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			prevUpper := runes[i-1] >= 'A' && runes[i-1] <= 'Z'
+			if prevLower || (prevUpper && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteString(strings.ToLower(string(r)))
+	}
+	return b.String()
+}
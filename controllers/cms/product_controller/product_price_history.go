@@ -0,0 +1,106 @@
+package product_controller
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetProductPriceHistory godoc
+// @Summary List a product's price history
+// @Description Paginated pricing changes of a product, newest first. Rows without a sku are the product's own pricing (price, compare-at price, sale and window); rows with a sku are that combo's own sale. Written on every create, update, import and restore that changes pricing.
+// @Tags CMS - Products
+// @Produce json
+// @Param id path string true "Product ID (UUID)"
+// @Param sku query string false "Only this combo's rows"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.PriceHistory}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/products/{id}/price-history [get]
+func GetProductPriceHistory(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Verify product exists
+	var productCount int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ?", productID).
+		Count(&productCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if productCount == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	query := config.CmsGorm.WithContext(ctx).
+		Model(&models.PriceHistory{}).
+		Where("product_id = ?", productID)
+	if sku := strings.TrimSpace(c.Query("sku")); sku != "" {
+		query = query.Where("sku = ?", sku)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("[admin.prices] failed to count price history: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	history := make([]models.PriceHistory, 0)
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&history).Error; err != nil {
+		log.Printf("[admin.prices] failed to fetch price history: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	meta := &models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Price history fetched successfully", history, meta))
+}
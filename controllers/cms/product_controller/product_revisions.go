@@ -0,0 +1,195 @@
+package product_controller
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetProductRevisions godoc
+// @Summary List a product's revisions
+// @Description Paginated revision history of a product, newest first. Every create, update, import and restore saves a snapshot; changed_fields lists the top-level fields each save changed.
+// @Tags CMS - Products
+// @Produce json
+// @Param id path string true "Product ID (UUID)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.ProductRevisionSummary}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/products/{id}/revisions [get]
+func GetProductRevisions(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Verify product exists
+	var productCount int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ?", productID).
+		Count(&productCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if productCount == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	query := config.CmsGorm.WithContext(ctx).
+		Model(&models.ProductRevision{}).
+		Where("product_id = ?", productID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("[admin.revisions] failed to count revisions: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	revisions := make([]models.ProductRevisionSummary, 0)
+	if err := query.
+		Select("id, product_id, revision, changed_fields, source, restored_from, admin_id, admin_email, created_at").
+		Order("revision DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&revisions).Error; err != nil {
+		log.Printf("[admin.revisions] failed to fetch revisions: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	meta := &models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Product revisions fetched successfully", revisions, meta))
+}
+
+// GetProductRevision godoc
+// @Summary Get a product revision with its diff
+// @Description Returns the revision's snapshot and a field-by-field diff against the previous revision (or the revision given in against). List fields are compared by identity: variants by type, inventory by combo, composition by label and images by URL.
+// @Tags CMS - Products
+// @Produce json
+// @Param id path string true "Product ID (UUID)"
+// @Param revision path int true "Revision number"
+// @Param against query int false "Revision number to compare with (default: the previous revision)"
+// @Success 200 {object} models.ApiResponse{data=models.ProductRevisionDiff}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/products/{id}/revisions/{revision} [get]
+func GetProductRevision(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid revision number"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Load the revision
+	revision, ok := loadProductRevision(c, config.CmsGorm.WithContext(ctx), productID, number)
+	if !ok {
+		return
+	}
+
+	// Step 2: Load the revision to compare with
+	var base *models.ProductRevision
+	if raw := c.Query("against"); raw != "" {
+		against, err := strconv.Atoi(raw)
+		if err != nil || against < 1 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid against revision number"))
+			return
+		}
+		if base, ok = loadProductRevision(c, config.CmsGorm.WithContext(ctx), productID, against); !ok {
+			return
+		}
+	} else {
+		var previous models.ProductRevision
+		err := config.CmsGorm.WithContext(ctx).
+			Where("product_id = ? AND revision < ?", productID, number).
+			Order("revision DESC").
+			First(&previous).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+			return
+		}
+		if err == nil {
+			base = &previous
+		}
+	}
+
+	// Step 3: Diff
+	diff := models.ProductRevisionDiff{Revision: *revision}
+	var before *models.ProductSnapshot
+	if base != nil {
+		diff.Against = &base.Revision
+		before = &base.Snapshot
+	}
+	if diff.Changes, err = services.GetProductRevisionService().Diff(before, &revision.Snapshot); err != nil {
+		log.Printf("[admin.revisions] failed to diff revisions: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to compare revisions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Product revision fetched successfully", diff))
+}
+
+// loadProductRevision fetches one revision by number, writing the error response on failure
+func loadProductRevision(c *gin.Context, db *gorm.DB, productID uuid.UUID, number int) (*models.ProductRevision, bool) {
+	var revision models.ProductRevision
+	if err := db.
+		Where("product_id = ? AND revision = ?", productID, number).
+		First(&revision).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Revision not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return nil, false
+	}
+	return &revision, true
+}
@@ -0,0 +1,123 @@
+package product_controller
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+)
+
+// saleInput is the sale part of a product update. nil leaves a field as it is; a price
+// of -1 or an empty time clears it (clearing sale_price clears the whole sale).
+type saleInput struct {
+	CompareAtPrice *float64
+	SalePrice      *float64
+	SaleStartsAt   *string
+	SaleEndsAt     *string
+}
+
+// validateSale checks a product's prices as they will be saved: sale prices must be
+// below the regular price and sale windows must end after they start
+func validateSale(price float64, salePrice *float64, startsAt, endsAt *time.Time, inventory []models.InventoryField) error {
+	if err := validateSaleWindow("", price, salePrice, startsAt, endsAt); err != nil {
+		return err
+	}
+	for _, item := range inventory {
+		if err := validateSaleWindow(item.VariantName, price, item.SalePrice, item.SaleStartsAt, item.SaleEndsAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSaleWindow checks one sale; combo names the inventory combo ("" for the product)
+func validateSaleWindow(combo string, price float64, salePrice *float64, startsAt, endsAt *time.Time) error {
+	prefix := ""
+	if combo != "" {
+		prefix = fmt.Sprintf("Inventory %q: ", combo)
+	}
+	if salePrice == nil {
+		if startsAt != nil || endsAt != nil {
+			return errors.New(prefix + "sale_starts_at and sale_ends_at need a sale_price")
+		}
+		return nil
+	}
+	if *salePrice >= price {
+		return errors.New(prefix + "sale_price must be below the product price")
+	}
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return errors.New(prefix + "sale_ends_at must be after sale_starts_at")
+	}
+	return nil
+}
+
+// applySaleUpdates adds the sale fields of an update to the update map and validates the
+// product's resulting prices (including a new price or inventory already in the map)
+func applySaleUpdates(product *models.Product, updates map[string]interface{}, in saleInput) error {
+	if in.CompareAtPrice != nil {
+		if *in.CompareAtPrice < 0 {
+			updates["compare_at_price"] = nil
+		} else {
+			updates["compare_at_price"] = *in.CompareAtPrice
+		}
+	}
+
+	salePrice, startsAt, endsAt := product.SalePrice, product.SaleStartsAt, product.SaleEndsAt
+	if in.SaleStartsAt != nil {
+		t, err := parseScheduleTime("sale_starts_at", *in.SaleStartsAt)
+		if err != nil {
+			return err
+		}
+		startsAt = t
+		updates["sale_starts_at"] = scheduleColumn(t)
+	}
+	if in.SaleEndsAt != nil {
+		t, err := parseScheduleTime("sale_ends_at", *in.SaleEndsAt)
+		if err != nil {
+			return err
+		}
+		endsAt = t
+		updates["sale_ends_at"] = scheduleColumn(t)
+	}
+	if in.SalePrice != nil {
+		if *in.SalePrice < 0 {
+			salePrice, startsAt, endsAt = nil, nil, nil
+			updates["sale_price"] = nil
+			updates["sale_starts_at"] = nil
+			updates["sale_ends_at"] = nil
+		} else {
+			value := *in.SalePrice
+			salePrice = &value
+			updates["sale_price"] = value
+		}
+	}
+
+	// Only validate when something prices depend on changes
+	_, priceSet := updates["price"]
+	inventory, inventorySet := updates["inventory"].(models.InventoryList)
+	if !priceSet && !inventorySet && in.SalePrice == nil && in.SaleStartsAt == nil && in.SaleEndsAt == nil {
+		return nil
+	}
+
+	price := product.Price
+	if p, ok := updates["price"].(float64); ok {
+		price = p
+	}
+	if !inventorySet {
+		inventory = product.Inventory
+	}
+	return validateSale(price, salePrice, startsAt, endsAt, inventory)
+}
+
+// parseFormPrice reads a multipart price field; empty, invalid or negative values become
+// -1 (clear)
+func parseFormPrice(raw string) *float64 {
+	value := -1.0
+	if parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil && parsed >= 0 {
+		value = parsed
+	}
+	return &value
+}
@@ -0,0 +1,98 @@
+package product_controller
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+)
+
+// validateSchedule checks a product's schedule as it will be saved. The scheduler only
+// publishes Drafts, so a product can't be Active while waiting on a future publish_at.
+func validateSchedule(status string, publishAt, unpublishAt *time.Time) error {
+	now := time.Now()
+	if publishAt != nil {
+		if !publishAt.After(now) {
+			return errors.New("publish_at must be in the future")
+		}
+		if status == models.ProductStatusActive {
+			return errors.New("A product with a publish_at must be Draft until it is published")
+		}
+	}
+	if unpublishAt != nil {
+		if !unpublishAt.After(now) {
+			return errors.New("unpublish_at must be in the future")
+		}
+		if publishAt != nil && !unpublishAt.After(*publishAt) {
+			return errors.New("unpublish_at must be after publish_at")
+		}
+	}
+	return nil
+}
+
+// parseScheduleTime reads a publish_at/unpublish_at value from an update: "" clears it
+func parseScheduleTime(field, raw string) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, errors.New("Invalid " + field + ": expected an RFC 3339 timestamp such as 2026-11-01T00:00:00Z")
+	}
+	return &t, nil
+}
+
+// applyScheduleUpdates adds publish_at/unpublish_at to an update map and validates the
+// product's resulting schedule. nil leaves a column as it is. Setting the status to
+// Active without a new publish_at publishes now, cancelling a pending publish_at.
+func applyScheduleUpdates(product *models.Product, updates map[string]interface{}, publishRaw, unpublishRaw *string) error {
+	status := product.Status
+	statusSet := false
+	if s, ok := updates["status"].(string); ok {
+		status, statusSet = s, true
+	}
+
+	publishAt, unpublishAt := product.PublishAt, product.UnpublishAt
+	if publishRaw != nil {
+		t, err := parseScheduleTime("publish_at", *publishRaw)
+		if err != nil {
+			return err
+		}
+		publishAt = t
+		updates["publish_at"] = scheduleColumn(t)
+	} else if statusSet && status == models.ProductStatusActive && publishAt != nil {
+		publishAt = nil
+		updates["publish_at"] = nil
+	}
+	if unpublishRaw != nil {
+		t, err := parseScheduleTime("unpublish_at", *unpublishRaw)
+		if err != nil {
+			return err
+		}
+		unpublishAt = t
+		updates["unpublish_at"] = scheduleColumn(t)
+	}
+
+	// Only validate what this update touches: a stored time that has just passed is
+	// about to be picked up by the scheduler and shouldn't block unrelated edits
+	if publishRaw == nil && unpublishRaw == nil && !statusSet {
+		return nil
+	}
+	if publishRaw == nil && publishAt != nil && !publishAt.After(time.Now()) && status != models.ProductStatusActive {
+		publishAt = nil
+	}
+	if unpublishRaw == nil && unpublishAt != nil && !unpublishAt.After(time.Now()) {
+		unpublishAt = nil
+	}
+	return validateSchedule(status, publishAt, unpublishAt)
+}
+
+// scheduleColumn is the update map value for a schedule time (NULL when cleared)
+func scheduleColumn(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
@@ -0,0 +1,163 @@
+package product_controller
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	category_cache "github.com/Modeva-Ecommerce/modeva-cms-backend/cache"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetTrashedProducts godoc
+// @Summary List trashed products
+// @Description Paginated products in the trash, most recently deleted first, with the time each one will be purged for good.
+// @Tags CMS - Products
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.TrashedProduct}
+// @Failure 500 {object} models.ApiResponse
+// @Router /api/v1/admin/products/trash [get]
+func GetTrashedProducts(c *gin.Context) {
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	query := config.CmsGorm.WithContext(ctx).
+		Unscoped().
+		Model(&models.Product{}).
+		Where("deleted_at IS NOT NULL")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("[admin.trash] failed to count trashed products: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	products := make([]models.Product, 0)
+	if err := query.
+		Select("id, name, price, status, sub_category_id, media, deleted_at, deleted_by").
+		Preload("SubCategory", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name, parent_id, parent_name")
+		}).
+		Order("deleted_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&products).Error; err != nil {
+		log.Printf("[admin.trash] failed to fetch trashed products: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	retention := services.GetProductTrashService().Retention()
+	trashed := make([]models.TrashedProduct, 0, len(products))
+	for _, product := range products {
+		item := models.TrashedProduct{
+			ID:              product.ID,
+			Name:            product.Name,
+			Price:           product.Price,
+			Status:          product.Status,
+			SubCategoryID:   product.SubCategoryID,
+			SubCategoryName: product.SubCategoryName,
+			DeletedAt:       product.DeletedAt.Time,
+			DeletedBy:       product.DeletedBy,
+			PurgeAt:         product.DeletedAt.Time.Add(retention),
+		}
+		if product.Media.Primary.URL != "" {
+			item.PrimaryImage = &product.Media.Primary.URL
+		}
+		trashed = append(trashed, item)
+	}
+
+	meta := &models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Trashed products fetched successfully", trashed, meta))
+}
+
+// RestoreTrashedProduct godoc
+// @Summary Restore a product from the trash
+// @Description Brings a trashed product back with its SKUs, revisions, stock and images. It returns with the status it had when it was deleted.
+// @Tags CMS - Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=models.Product}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse "Not in the trash"
+// @Router /api/v1/admin/products/{id}/restore [post]
+func RestoreTrashedProduct(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Take it out of the trash
+	result := config.CmsGorm.WithContext(ctx).
+		Unscoped().
+		Model(&models.Product{}).
+		Where("id = ? AND deleted_at IS NOT NULL", productID).
+		Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": nil})
+	if result.Error != nil {
+		log.Printf("[admin.trash] failed to restore product %s: %v", productID, result.Error)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to restore product"))
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found in the trash"))
+		return
+	}
+
+	// Step 2: Refresh category counts, stock alerts and waiting back-in-stock subscribers
+	category_cache.Invalidate()
+	services.GetStockAlertService().CheckProductsAsync(productID)
+	services.GetBackInStockService().NotifyRestockedAsync(productID)
+
+	// Step 3: Reload with subcategory
+	var product models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Preload("SubCategory", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name, parent_id, parent_name")
+		}).
+		First(&product, "id = ?", productID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to reload product"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Product restored from trash", product))
+}
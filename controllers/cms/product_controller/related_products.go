@@ -0,0 +1,187 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetRelatedProducts godoc
+// @Summary Get a product's recommendations
+// @Description The product's curated related products in display order, and the products most often bought with it (mined from completed orders by a periodic job)
+// @Tags CMS - Products
+// @Produce json
+// @Param id path string true "Product ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=object{related=[]models.RelatedProductSummary,frequently_bought_together=[]models.CoPurchaseSummary}}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/products/{id}/related [get]
+func GetRelatedProducts(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Verify product exists
+	var productCount int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ?", productID).
+		Count(&productCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if productCount == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	related := make([]models.RelatedProductSummary, 0)
+	if err := config.CmsGorm.WithContext(ctx).
+		Table("related_products r").
+		Select(`
+			p.id,
+			p.name,
+			COALESCE(p.media->'primary'->>'url', '') AS image,
+			p.status,
+			r.position
+		`).
+		Joins("JOIN products p ON p.id = r.related_product_id AND p.deleted_at IS NULL").
+		Where("r.product_id = ?", productID).
+		Order("r.position ASC, r.created_at ASC").
+		Scan(&related).Error; err != nil {
+		log.Printf("[admin.related] failed to fetch related products of %s: %v", productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch related products"))
+		return
+	}
+
+	boughtTogether := make([]models.CoPurchaseSummary, 0)
+	if err := config.CmsGorm.WithContext(ctx).
+		Table("product_co_purchases cp").
+		Select(`
+			p.id,
+			p.name,
+			COALESCE(p.media->'primary'->>'url', '') AS image,
+			p.status,
+			cp.orders,
+			cp.confidence,
+			cp.computed_at
+		`).
+		Joins("JOIN products p ON p.id = cp.related_product_id AND p.deleted_at IS NULL").
+		Where("cp.product_id = ?", productID).
+		Order("cp.orders DESC, cp.confidence DESC").
+		Scan(&boughtTogether).Error; err != nil {
+		log.Printf("[admin.related] failed to fetch co-purchases of %s: %v", productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch related products"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Related products fetched successfully", gin.H{
+		"related":                    related,
+		"frequently_bought_together": boughtTogether,
+	}))
+}
+
+// SetRelatedProducts godoc
+// @Summary Set a product's related products
+// @Description Replace the product's curated related products (up to 20) with product_ids, in display order. An empty list removes them all. They're recommended on the storefront ahead of "frequently bought together" products, while active and in stock.
+// @Tags CMS - Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID (UUID)"
+// @Param related body models.SetRelatedProductsRequest true "Related product IDs, in order"
+// @Success 200 {object} models.ApiResponse{data=[]models.RelatedProduct}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/products/{id}/related [put]
+func SetRelatedProducts(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	// Step 1: Validate JSON input
+	var req models.SetRelatedProductsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Verify product exists
+	var productCount int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ?", productID).
+		Count(&productCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if productCount == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	// Step 2: Each related product once, never the product itself, and all existing
+	rows := make([]models.RelatedProduct, 0, len(req.ProductIDs))
+	seen := make(map[uuid.UUID]bool, len(req.ProductIDs))
+	for _, id := range req.ProductIDs {
+		if id == productID {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "A product can't be related to itself"))
+			return
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		rows = append(rows, models.RelatedProduct{ProductID: productID, RelatedProductID: id, Position: len(rows)})
+	}
+	if len(rows) > 0 {
+		ids := make([]uuid.UUID, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.RelatedProductID)
+		}
+		var count int64
+		if err := config.CmsGorm.WithContext(ctx).
+			Model(&models.Product{}).
+			Where("id IN ?", ids).
+			Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+			return
+		}
+		if int(count) != len(ids) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "One or more related products were not found"))
+			return
+		}
+	}
+
+	// Step 3: Replace the list
+	if err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&models.RelatedProduct{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	}); err != nil {
+		log.Printf("[admin.related] failed to save related products of %s: %v", productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to save related products"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Related products updated successfully", rows))
+}
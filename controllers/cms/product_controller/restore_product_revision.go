@@ -0,0 +1,252 @@
+package product_controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RestoreProductRevision godoc
+// @Summary Restore a product revision
+// @Description Saves the revision's content as a new revision (the history is never rewritten). Optionally restore only some fields; variants and inventory are always restored together. Stock quantities are not rolled back: combos that still exist keep their current quantity and re-added combos start at 0. Images deleted from storage since the revision can't be recovered, and schedule times that have passed are cleared. Bundle components are only restored on bundles, and only if they still point at existing combos.
+// @Tags CMS - Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID (UUID)"
+// @Param revision path int true "Revision number"
+// @Param request body models.RestoreProductRevisionRequest false "Fields to restore (default: all)"
+// @Success 200 {object} models.ApiResponse{data=models.ProductRestoreResponse}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "The revision's category no longer exists"
+// @Router /api/v1/admin/products/{id}/revisions/{revision}/restore [post]
+func RestoreProductRevision(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid revision number"))
+		return
+	}
+
+	var req models.RestoreProductRevisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid request: "+err.Error()))
+			return
+		}
+	}
+	fields := req.Fields
+	if len(fields) == 0 {
+		fields = models.RestorableProductFields
+	}
+	restores := func(field string) bool { return slices.Contains(fields, field) }
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Load the revision and the current product
+	revision, ok := loadProductRevision(c, config.CmsGorm.WithContext(ctx), productID, number)
+	if !ok {
+		return
+	}
+	var product models.Product
+	if err := config.CmsGorm.WithContext(ctx).First(&product, "id = ?", productID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+	snapshot := revision.Snapshot
+
+	// Step 2: Build the update from the snapshot
+	updates := make(map[string]interface{})
+	if restores("name") {
+		updates["name"] = snapshot.Name
+	}
+	if restores("description") {
+		updates["description"] = snapshot.Description
+	}
+	if restores("price") {
+		updates["price"] = snapshot.Price
+	}
+	if restores("status") {
+		updates["status"] = snapshot.Status
+	}
+	if restores("tags") {
+		updates["tags"] = snapshot.Tags
+	}
+	if restores("composition") {
+		updates["composition"] = snapshot.Composition
+	}
+	if restores("media") {
+		updates["media"] = snapshot.Media
+	}
+	if restores("sku_pattern") {
+		updates["sku_pattern"] = snapshot.SKUPattern
+	}
+	if restores("low_stock_threshold") {
+		updates["low_stock_threshold"] = snapshot.LowStockThreshold
+	}
+	if restores("quantity_rules") {
+		updates["quantity_rules"] = snapshot.QuantityRules
+	}
+	if restores("backorder") {
+		updates["backorder"] = snapshot.Backorder
+	}
+	if restores("seo") {
+		updates["seo"] = snapshot.SEO
+	}
+	if restores("compare_at_price") {
+		updates["compare_at_price"] = snapshot.CompareAtPrice
+	}
+	if restores("sale_price") {
+		updates["sale_price"] = snapshot.SalePrice
+	}
+	if restores("sale_starts_at") {
+		updates["sale_starts_at"] = scheduleColumn(snapshot.SaleStartsAt)
+	}
+	if restores("sale_ends_at") {
+		updates["sale_ends_at"] = scheduleColumn(snapshot.SaleEndsAt)
+	}
+
+	// A schedule time that has passed since the revision isn't brought back
+	if restores("publish_at") {
+		updates["publish_at"] = scheduleColumn(upcoming(snapshot.PublishAt))
+	}
+	if restores("unpublish_at") {
+		updates["unpublish_at"] = scheduleColumn(upcoming(snapshot.UnpublishAt))
+	}
+	status := product.Status
+	if restores("status") {
+		status = snapshot.Status
+	}
+	if status == models.ProductStatusActive {
+		updates["publish_at"] = nil // Already published
+	}
+
+	if restores("sub_category_id") {
+		var count int64
+		if err := config.CmsGorm.WithContext(ctx).
+			Model(&models.Category{}).
+			Where("id = ?", snapshot.SubCategoryID).
+			Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+			return
+		}
+		if count == 0 {
+			c.JSON(http.StatusConflict, models.ErrorResponse(c, "The revision's category no longer exists; restore the other fields or recreate the category"))
+			return
+		}
+		updates["sub_category_id"] = snapshot.SubCategoryID
+	}
+
+	// Variants and inventory only make sense together
+	if restores("variants") || restores("inventory") {
+		inventory := restoredInventory(snapshot.Inventory, product.Inventory)
+
+		name, pattern := product.Name, product.SKUPattern
+		if restores("name") {
+			name = snapshot.Name
+		}
+		if restores("sku_pattern") {
+			pattern = snapshot.SKUPattern
+		}
+		if err := prepareInventorySKUs(ctx, productID, name, pattern, snapshot.Variants, inventory); err != nil {
+			respondSKUError(c, err)
+			return
+		}
+		updates["variants"] = snapshot.Variants
+		updates["inventory"] = models.InventoryList(inventory)
+	}
+
+	// Bundle components only apply to bundles, and must still point at existing combos
+	if restores("bundle_components") && product.IsBundle() && len(snapshot.BundleComponents) > 0 {
+		components := []models.BundleComponent(snapshot.BundleComponents)
+		if err := services.GetBundleService().Validate(ctx, productID, components); err != nil {
+			if errors.Is(err, services.ErrInvalidBundle) {
+				c.JSON(http.StatusConflict, models.ErrorResponse(c, "The revision's bundle components are no longer valid ("+err.Error()+"); restore the other fields or edit the bundle"))
+			} else {
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+			}
+			return
+		}
+		updates["bundle_components"] = models.BundleComponentList(components)
+	}
+
+	// Step 3: Save as a new revision
+	meta := revisionMetaFromContext(c, models.RevisionSourceRestore)
+	meta.RestoredFrom = &revision.Revision
+	newRevision, err := saveProductUpdates(ctx, c, &product, updates, meta)
+	if err != nil {
+		log.Printf("[admin.revisions] failed to restore revision %d of %s: %v", number, productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to restore revision"))
+		return
+	}
+
+	// Step 4: Reload with subcategory
+	if err := config.CmsGorm.WithContext(ctx).
+		Preload("SubCategory", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name, parent_id, parent_name")
+		}).
+		First(&product, "id = ?", productID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to reload product"))
+		return
+	}
+
+	resp := models.ProductRestoreResponse{Product: product}
+	if newRevision != nil {
+		resp.Revision = *newRevision
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Product restored to revision "+strconv.Itoa(number), resp))
+}
+
+// upcoming returns t if it is still in the future
+func upcoming(t *time.Time) *time.Time {
+	if t == nil || !t.After(time.Now()) {
+		return nil
+	}
+	return t
+}
+
+// restoredInventory takes the revision's combos with today's quantities: stock is owned
+// by the stock ledger, so a restore never brings back old quantities
+func restoredInventory(revision, current models.InventoryList) []models.InventoryField {
+	quantities := make(map[string]int, len(current))
+	for _, item := range current {
+		quantities[comboKey(item)] = item.Quantity
+	}
+
+	inventory := make([]models.InventoryField, len(revision))
+	for i, item := range revision {
+		item.Combo = slices.Clone(item.Combo)
+		item.Quantity = quantities[comboKey(item)] // 0 for combos that no longer exist
+		inventory[i] = item
+	}
+	return inventory
+}
+
+// comboKey identifies a combo by its option values (case-insensitive)
+func comboKey(item models.InventoryField) string {
+	if len(item.Combo) == 0 {
+		return strings.ToLower(item.VariantName)
+	}
+	return strings.ToLower(strings.Join(item.Combo, "\x00"))
+}
@@ -1,6 +1,7 @@
 package product_controller
 
 import (
+	"fmt"
 	"math"
 	"net/http"
 	"strconv"
@@ -8,12 +9,13 @@ import (
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 // SearchProducts godoc
 // @Summary Search products
-// @Description Search products by name, description, or tags (case-insensitive). Returns paginated results with subcategory info.
+// @Description Full-text search over name, tags, category path and description (stemmed, so "dresses" finds "dress"), falling back to near matches on the name for typos. Results are ranked by relevance, with subcategory info and highlight: the name and best-matching description snippet with matched words in <mark>.
 // @Tags CMS - Products
 // @Produce json
 // @Param query query string true "Search keyword"
@@ -46,22 +48,19 @@ func SearchProducts(c *gin.Context) {
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
-	// Step 3: Build search query
-	searchPattern := "%" + queryParam + "%"
+	// Step 3: Build search query (see models.SearchJoinSQL), run with models.RunSearch
+	searchQuery := func(db *gorm.DB) *gorm.DB {
+		return db.
+			Table("products p").
+			Joins(models.SearchJoinSQL, queryParam, queryParam).
+			Where("p.deleted_at IS NULL AND " + models.SearchMatchSQL)
+	}
 
-	// Count total matches (using Raw SQL for JSONB array search)
+	// Count total matches
 	var total int64
-	if err := config.CmsGorm.WithContext(ctx).
-		Model(&models.Product{}).
-		Where(`
-			name ILIKE ? OR 
-			description ILIKE ? OR 
-			EXISTS (
-				SELECT 1 FROM jsonb_array_elements_text(tags) AS tag
-				WHERE tag ILIKE ?
-			)
-		`, searchPattern, searchPattern, searchPattern).
-		Count(&total).Error; err != nil {
+	if err := models.RunSearch(config.CmsGorm.WithContext(ctx), true, func(db *gorm.DB) error {
+		return searchQuery(db).Count(&total).Error
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count products"))
 		return
 	}
@@ -78,31 +77,50 @@ func SearchProducts(c *gin.Context) {
 		return
 	}
 
-	// Step 5: Fetch matching products with subcategory
-	products := make([]models.Product, 0)
+	// Step 5: Rank the matches, then fetch that page of products with subcategory
+	var matches []struct {
+		ID               uuid.UUID
+		HighlightName    string
+		HighlightSnippet string
+	}
+	if err := models.RunSearch(config.CmsGorm.WithContext(ctx), true, func(db *gorm.DB) error {
+		return searchQuery(db).
+			Select(fmt.Sprintf("p.id, %s AS highlight_name, %s AS highlight_snippet", models.SearchHeadlineNameSQL, models.SearchSnippetSQL)).
+			Order(models.SearchRankSQL + " DESC, p.created_at DESC").
+			Limit(limit).
+			Offset(offset).
+			Scan(&matches).Error
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch products"))
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+	found := make([]models.Product, 0, len(ids))
 	if err := config.CmsGorm.WithContext(ctx).
-		Where(`
-			name ILIKE ? OR 
-			description ILIKE ? OR 
-			EXISTS (
-				SELECT 1 FROM jsonb_array_elements_text(tags) AS tag
-				WHERE tag ILIKE ?
-			)
-		`, searchPattern, searchPattern, searchPattern).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
+		Where("id IN ?", ids).
 		Preload("SubCategory", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, name, parent_id, parent_name")
 		}).
-		Find(&products).Error; err != nil {
+		Find(&found).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch products"))
 		return
 	}
+	byID := make(map[uuid.UUID]models.Product, len(found))
+	for _, p := range found {
+		byID[p.ID] = p
+	}
 
 	// Step 6: Transform to ProductResponse format
-	responses := make([]models.ProductResponse, 0, len(products))
-	for _, p := range products {
+	responses := make([]models.ProductResponse, 0, len(matches))
+	for _, match := range matches {
+		p, ok := byID[match.ID]
+		if !ok {
+			continue // Trashed since it was ranked
+		}
 		responses = append(responses, models.ProductResponse{
 			BasicInfo: models.ProductBase{
 				ID:              p.ID,
@@ -113,6 +131,14 @@ func SearchProducts(c *gin.Context) {
 				SubCategoryID:   p.SubCategoryID,
 				SubCategoryName: p.SubCategoryName,
 				Status:          p.Status,
+				PublishAt:       p.PublishAt,
+				UnpublishAt:     p.UnpublishAt,
+				CompareAtPrice:  p.CompareAtPrice,
+				SalePrice:       p.SalePrice,
+				SaleStartsAt:    p.SaleStartsAt,
+				SaleEndsAt:      p.SaleEndsAt,
+				DisplayStatus:   p.DisplayStatus,
+				ProductType:     p.ProductType,
 				Tags:            []string(p.Tags),
 				CreatedAt:       p.CreatedAt,
 				UpdatedAt:       p.UpdatedAt,
@@ -121,6 +147,10 @@ func SearchProducts(c *gin.Context) {
 			Media:     p.Media,
 			Variants:  []models.ProductVariant(p.Variants),
 			Inventory: []models.InventoryField(p.Inventory),
+			Highlight: &models.SearchHighlight{
+				Name:    match.HighlightName,
+				Snippet: match.HighlightSnippet,
+			},
 		})
 	}
 
@@ -0,0 +1,117 @@
+package product_controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/utils"
+	"github.com/google/uuid"
+)
+
+// skuValidationError is returned when the submitted SKUs or barcodes can't be saved as-is
+type skuValidationError struct {
+	message string
+}
+
+func (e *skuValidationError) Error() string {
+	return e.message
+}
+
+// prepareInventorySKUs fills in missing SKUs from the product's SKU pattern and validates
+// that every SKU and barcode is well-formed and not used by another product.
+// The inventory slice is modified in place.
+func prepareInventorySKUs(
+	ctx context.Context,
+	productID uuid.UUID,
+	productName string,
+	pattern string,
+	variants []models.ProductVariant,
+	inventory []models.InventoryField,
+) error {
+	if len(inventory) == 0 {
+		return nil
+	}
+
+	variantTypes := make([]string, len(variants))
+	for i, v := range variants {
+		variantTypes[i] = v.Type
+	}
+
+	seenSKUs := make(map[string]string, len(inventory))
+	seenBarcodes := make(map[string]string, len(inventory))
+	skus := make([]string, 0, len(inventory))
+	barcodes := make([]string, 0, len(inventory))
+
+	for i := range inventory {
+		item := &inventory[i]
+
+		item.SKU = strings.TrimSpace(item.SKU)
+		if item.SKU == "" {
+			item.SKU = utils.GenerateSKU(pattern, productName, variantTypes, item.Combo)
+		}
+		if item.SKU == "" {
+			return &skuValidationError{message: fmt.Sprintf("Could not generate a SKU for %q; provide one or adjust sku_pattern", item.VariantName)}
+		}
+		if utf8.RuneCountInString(item.SKU) > utils.MaxSKULength {
+			return &skuValidationError{message: fmt.Sprintf("SKU for %q exceeds %d characters", item.VariantName, utils.MaxSKULength)}
+		}
+
+		key := strings.ToLower(item.SKU)
+		if other, exists := seenSKUs[key]; exists {
+			return &skuValidationError{message: fmt.Sprintf("SKU %q is used by both %q and %q", item.SKU, other, item.VariantName)}
+		}
+		seenSKUs[key] = item.VariantName
+		skus = append(skus, item.SKU)
+
+		item.Barcode = strings.TrimSpace(item.Barcode)
+		if item.Barcode != "" {
+			if !utils.IsValidGTIN(item.Barcode) {
+				return &skuValidationError{message: fmt.Sprintf("Barcode %q for %q is not a valid GTIN/EAN", item.Barcode, item.VariantName)}
+			}
+			if other, exists := seenBarcodes[item.Barcode]; exists {
+				return &skuValidationError{message: fmt.Sprintf("Barcode %q is used by both %q and %q", item.Barcode, other, item.VariantName)}
+			}
+			seenBarcodes[item.Barcode] = item.VariantName
+			barcodes = append(barcodes, item.Barcode)
+		}
+	}
+
+	// Check for clashes with other products (case-insensitive for SKUs)
+	lowered := make([]string, len(skus))
+	for i, sku := range skus {
+		lowered[i] = strings.ToLower(sku)
+	}
+
+	var clash struct {
+		SKU     string
+		Barcode *string
+	}
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`
+			SELECT sku, barcode
+			FROM product_skus
+			WHERE product_id <> ?
+			  AND (LOWER(sku) IN ? OR barcode IN ?)
+			LIMIT 1
+		`, productID, lowered, append(barcodes, "")). // "" keeps the IN list valid when no barcodes were sent
+		Scan(&clash).Error; err != nil {
+		return fmt.Errorf("failed to check SKU uniqueness: %w", err)
+	}
+
+	if clash.SKU != "" {
+		for _, sku := range skus {
+			if strings.EqualFold(sku, clash.SKU) {
+				return &skuValidationError{message: fmt.Sprintf("SKU %q is already used by another product", sku)}
+			}
+		}
+		if clash.Barcode != nil {
+			return &skuValidationError{message: fmt.Sprintf("Barcode %q is already used by another product", *clash.Barcode)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,312 @@
+package product_controller
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AdjustProductStock godoc
+// @Summary Adjust stock for an inventory combo
+// @Description Manually add or remove stock for one combo (identified by SKU or variant name) with a reason. Without location_id, stock is added to the default location and removed by allocation priority. Every adjustment is recorded in the stock movement ledger.
+// @Tags CMS - Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID (UUID)"
+// @Param adjustment body models.StockAdjustmentRequest true "Stock adjustment"
+// @Success 201 {object} models.ApiResponse{data=[]models.StockMovement}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Not enough stock"
+// @Router /api/v1/admin/products/{id}/stock-adjustments [post]
+func AdjustProductStock(c *gin.Context) {
+	// Step 1: Validate product ID and payload
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	var req models.StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid request: "+err.Error()))
+		return
+	}
+
+	req.SKU = strings.TrimSpace(req.SKU)
+	req.VariantName = strings.TrimSpace(req.VariantName)
+	if req.SKU == "" && req.VariantName == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "sku or variant_name is required"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Apply the change and record the movement
+	meta := stockMetaFromContext(c, req.Reason)
+	meta.ReferenceType = req.ReferenceType
+	meta.ReferenceID = req.ReferenceID
+	meta.Note = req.Note
+
+	var movements []models.StockMovement
+	err = config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var applyErr error
+		movements, applyErr = services.GetStockService().ApplyChanges(tx, productID, []services.StockChange{{
+			SKU:         req.SKU,
+			VariantName: req.VariantName,
+			LocationID:  req.LocationID,
+			Delta:       req.Delta,
+		}}, meta)
+		return applyErr
+	})
+
+	if err != nil {
+		var stockErr *services.InsufficientStockError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		case errors.Is(err, services.ErrStockComboNotFound):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Inventory combination not found for this product"))
+		case errors.Is(err, services.ErrStockLocationNotFound), errors.Is(err, services.ErrNoDefaultLocation):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		case errors.As(err, &stockErr):
+			c.JSON(http.StatusConflict, models.ErrorResponse(c, stockErr.Error()))
+		default:
+			log.Printf("[admin.stock] failed to adjust stock for %s: %v", productID, err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to adjust stock"))
+		}
+		return
+	}
+
+	services.GetStockAlertService().CheckProductsAsync(productID)
+	if req.Delta > 0 {
+		services.GetBackInStockService().NotifyRestockedAsync(productID)
+	}
+
+	log.Printf("[admin.stock] %s %+d (%s) on product %s across %d location(s)", movements[0].VariantName, req.Delta, req.Reason, productID, len(movements))
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Stock adjusted successfully", movements))
+}
+
+// GetProductStockMovements godoc
+// @Summary Get stock movement history for a product
+// @Description Paginated ledger of every stock change for a product, newest first
+// @Tags CMS - Products
+// @Produce json
+// @Param id path string true "Product ID (UUID)"
+// @Param sku query string false "Filter by SKU"
+// @Param reason query string false "Filter by reason (initial, sale, cancellation, return, damage, adjustment, correction, restock, transfer)"
+// @Param location_id query string false "Filter by stock location ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.StockMovement}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/products/{id}/stock-movements [get]
+func GetProductStockMovements(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Verify product exists
+	var productCount int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ?", productID).
+		Count(&productCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if productCount == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	query := config.CmsGorm.WithContext(ctx).
+		Model(&models.StockMovement{}).
+		Where("product_id = ?", productID)
+
+	if sku := strings.TrimSpace(c.Query("sku")); sku != "" {
+		query = query.Where("LOWER(sku) = LOWER(?)", sku)
+	}
+	if reason := strings.TrimSpace(c.Query("reason")); reason != "" {
+		query = query.Where("reason = ?", reason)
+	}
+	if locationIDStr := strings.TrimSpace(c.Query("location_id")); locationIDStr != "" {
+		locationID, err := uuid.Parse(locationIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid location_id"))
+			return
+		}
+		query = query.Where("location_id = ?", locationID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("[admin.stock] failed to count movements: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	movements := make([]models.StockMovement, 0)
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&movements).Error; err != nil {
+		log.Printf("[admin.stock] failed to fetch movements: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	meta := &models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(c, "Stock movements fetched successfully", movements, meta))
+}
+
+// ═══════════════════════════════════════════════════════════
+// Helper Functions
+// ═══════════════════════════════════════════════════════════
+
+// stockMetaFromContext builds movement metadata for a change made by the logged-in admin
+func stockMetaFromContext(c *gin.Context, reason string) services.StockMovementMeta {
+	meta := services.StockMovementMeta{Reason: reason}
+
+	if raw, exists := c.Get("adminID"); exists {
+		if idStr, ok := raw.(string); ok {
+			if adminID, err := uuid.Parse(idStr); err == nil {
+				meta.AdminID = &adminID
+			}
+		}
+	}
+	if raw, exists := c.Get("adminEmail"); exists {
+		if email, ok := raw.(string); ok && email != "" {
+			meta.AdminEmail = &email
+		}
+	}
+
+	return meta
+}
+
+// revisionMetaFromContext builds product revision metadata from the authenticated admin
+func revisionMetaFromContext(c *gin.Context, source string) services.ProductRevisionMeta {
+	stockMeta := stockMetaFromContext(c, "")
+	return services.ProductRevisionMeta{
+		Source:     source,
+		AdminID:    stockMeta.AdminID,
+		AdminEmail: stockMeta.AdminEmail,
+	}
+}
+
+// saveProductUpdates writes the update map, snapshots the result as a new revision and,
+// when the inventory JSONB is replaced, records the quantity differences in the stock
+// ledger, all in the same transaction. Changes that can move a combo across its low-stock
+// threshold trigger an alert check, and restocked combos notify their back-in-stock subscribers.
+// Returns the new revision (nil when the save changed nothing).
+func saveProductUpdates(
+	ctx context.Context,
+	c *gin.Context,
+	product *models.Product,
+	updates map[string]interface{},
+	revisionMeta services.ProductRevisionMeta,
+) (*models.ProductRevision, error) {
+	var revision *models.ProductRevision
+	err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		inventory, hasInventory := updates["inventory"].(models.InventoryList)
+
+		// Lock the row so the diff is taken against the quantities we're replacing
+		var current models.Product
+		if hasInventory {
+			if err := tx.
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id, inventory").
+				First(&current, "id = ?", product.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(product).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if hasInventory {
+			if err := services.GetStockService().RecordInventoryDiff(
+				tx,
+				product.ID,
+				current.Inventory,
+				inventory,
+				stockMetaFromContext(c, models.StockReasonCorrection),
+			); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		revision, err = services.GetProductRevisionService().Record(tx, product.ID, revisionMeta)
+		if err != nil {
+			return err
+		}
+		return services.GetPriceHistoryService().Record(tx, product.ID, revisionMeta)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range []string{"inventory", "low_stock_threshold", "sub_category_id"} {
+		if _, ok := updates[field]; ok {
+			services.GetStockAlertService().CheckProductsAsync(product.ID)
+			break
+		}
+	}
+
+	// Restocked (or newly published) combos release waiting back-in-stock subscribers
+	_, hasInventory := updates["inventory"]
+	_, hasStatus := updates["status"]
+	if hasInventory || hasStatus {
+		services.GetBackInStockService().NotifyRestockedAsync(product.ID)
+	}
+	return revision, nil
+}
@@ -3,8 +3,10 @@ package product_controller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,7 +19,7 @@ import (
 
 // UpdateProduct godoc
 // @Summary Update an existing product
-// @Description Update product details by ID with support for both text and image updates
+// @Description Update product details by ID with support for both text and image updates. publish_at/unpublish_at schedule the product (RFC 3339; "" clears); setting status to Active without a publish_at publishes now and cancels a pending publish_at. compare_at_price and sale_price take -1 to clear (clearing sale_price ends the sale); sale_starts_at/sale_ends_at are RFC 3339 ("" clears). bundle_components replaces a bundle's whole component list; switching product_type back to standard drops it. quantity_rules replaces all of the product's quantity rules ({} clears them). backorder replaces the product's backorder policy ({} turns backorders off).
 // @Tags CMS - Products
 // @Accept json
 // @Produce json
@@ -111,12 +113,69 @@ func updateProductTextOnly(c *gin.Context, productID uuid.UUID) {
 	if input.Variants != nil {
 		updates["variants"] = models.VariantsList(*input.Variants)
 	}
+	if input.SKUPattern != nil {
+		updates["sku_pattern"] = strings.TrimSpace(*input.SKUPattern)
+	}
 	if input.Inventory != nil {
+		skuPattern := product.SKUPattern
+		if input.SKUPattern != nil {
+			skuPattern = strings.TrimSpace(*input.SKUPattern)
+		}
+		productName := product.Name
+		if input.Name != nil {
+			productName = *input.Name
+		}
+		variants := []models.ProductVariant(product.Variants)
+		if input.Variants != nil {
+			variants = *input.Variants
+		}
+
+		if err := prepareInventorySKUs(c.Request.Context(), productID, productName, skuPattern, variants, *input.Inventory); err != nil {
+			respondSKUError(c, err)
+			return
+		}
 		updates["inventory"] = models.InventoryList(*input.Inventory)
 	}
 	if input.SEO != nil {
 		updates["seo"] = *input.SEO
 	}
+	if input.QuantityRules != nil {
+		if err := input.QuantityRules.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+			return
+		}
+		updates["quantity_rules"] = *input.QuantityRules
+	}
+	if input.LowStockThreshold != nil {
+		if *input.LowStockThreshold < 0 {
+			updates["low_stock_threshold"] = nil // Inherit from category
+		} else {
+			updates["low_stock_threshold"] = *input.LowStockThreshold
+		}
+	}
+
+	if err := applyScheduleUpdates(&product, updates, input.PublishAt, input.UnpublishAt); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	if err := applySaleUpdates(&product, updates, saleInput{
+		CompareAtPrice: input.CompareAtPrice,
+		SalePrice:      input.SalePrice,
+		SaleStartsAt:   input.SaleStartsAt,
+		SaleEndsAt:     input.SaleEndsAt,
+	}); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	if err := applyBundleUpdates(c.Request.Context(), &product, updates, input.ProductType, input.BundleComponents); err != nil {
+		respondBundleError(c, err)
+		return
+	}
+	if err := applyBackorderUpdates(&product, updates, input.Backorder); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
 
 	// Step 4: Update product
 	if len(updates) == 0 {
@@ -124,9 +183,7 @@ func updateProductTextOnly(c *gin.Context, productID uuid.UUID) {
 		return
 	}
 
-	if err := config.CmsGorm.
-		Model(&product).
-		Updates(updates).Error; err != nil {
+	if _, err := saveProductUpdates(c.Request.Context(), c, &product, updates, revisionMetaFromContext(c, models.RevisionSourceUpdate)); err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update product"))
 		return
 	}
@@ -217,9 +274,27 @@ func updateProductWithImages(c *gin.Context, productID uuid.UUID) {
 			updates["variants"] = models.VariantsList(variants)
 		}
 	}
+	skuPattern := product.SKUPattern
+	if patternStr, ok := c.GetPostForm("sku_pattern"); ok {
+		skuPattern = strings.TrimSpace(patternStr)
+		updates["sku_pattern"] = skuPattern
+	}
 	if inventoryStr := c.PostForm("inventory"); inventoryStr != "" {
 		var inventory []models.InventoryField
 		if err := json.Unmarshal([]byte(inventoryStr), &inventory); err == nil {
+			productName := product.Name
+			if name, ok := updates["name"].(string); ok {
+				productName = name
+			}
+			variants := []models.ProductVariant(product.Variants)
+			if v, ok := updates["variants"].(models.VariantsList); ok {
+				variants = v
+			}
+
+			if err := prepareInventorySKUs(ctx, productID, productName, skuPattern, variants, inventory); err != nil {
+				respondSKUError(c, err)
+				return
+			}
 			updates["inventory"] = models.InventoryList(inventory)
 		}
 	}
@@ -229,6 +304,85 @@ func updateProductWithImages(c *gin.Context, productID uuid.UUID) {
 			updates["seo"] = seo
 		}
 	}
+	if rulesStr := c.PostForm("quantity_rules"); rulesStr != "" {
+		var rules models.QuantityRules
+		if err := json.Unmarshal([]byte(rulesStr), &rules); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid quantity_rules"))
+			return
+		}
+		if err := rules.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+			return
+		}
+		updates["quantity_rules"] = rules
+	}
+	if thresholdStr, ok := c.GetPostForm("low_stock_threshold"); ok {
+		// Empty or negative clears it (inherit from category)
+		if threshold, err := strconv.Atoi(strings.TrimSpace(thresholdStr)); err == nil && threshold >= 0 {
+			updates["low_stock_threshold"] = threshold
+		} else {
+			updates["low_stock_threshold"] = nil
+		}
+	}
+	var publishAt, unpublishAt *string // Empty clears the schedule
+	if raw, ok := c.GetPostForm("publish_at"); ok {
+		publishAt = &raw
+	}
+	if raw, ok := c.GetPostForm("unpublish_at"); ok {
+		unpublishAt = &raw
+	}
+	if err := applyScheduleUpdates(&product, updates, publishAt, unpublishAt); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	var sale saleInput // Empty or -1 clears a price, empty clears a time
+	if raw, ok := c.GetPostForm("compare_at_price"); ok {
+		sale.CompareAtPrice = parseFormPrice(raw)
+	}
+	if raw, ok := c.GetPostForm("sale_price"); ok {
+		sale.SalePrice = parseFormPrice(raw)
+	}
+	if raw, ok := c.GetPostForm("sale_starts_at"); ok {
+		sale.SaleStartsAt = &raw
+	}
+	if raw, ok := c.GetPostForm("sale_ends_at"); ok {
+		sale.SaleEndsAt = &raw
+	}
+	if err := applySaleUpdates(&product, updates, sale); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	var productType *string
+	if raw := c.PostForm("product_type"); raw == models.ProductTypeStandard || raw == models.ProductTypeBundle {
+		productType = &raw
+	}
+	var components *[]models.BundleComponent
+	if componentsStr := c.PostForm("bundle_components"); componentsStr != "" {
+		var parsed []models.BundleComponent
+		if err := json.Unmarshal([]byte(componentsStr), &parsed); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid bundle_components"))
+			return
+		}
+		components = &parsed
+	}
+	if err := applyBundleUpdates(ctx, &product, updates, productType, components); err != nil {
+		respondBundleError(c, err)
+		return
+	}
+	var backorder *models.BackorderPolicy
+	if backorderStr := c.PostForm("backorder"); backorderStr != "" {
+		var parsed models.BackorderPolicy
+		if err := json.Unmarshal([]byte(backorderStr), &parsed); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid backorder"))
+			return
+		}
+		backorder = &parsed
+	}
+	if err := applyBackorderUpdates(&product, updates, backorder); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
 
 	// Product folder for Cloudinary
 	productFolder := fmt.Sprintf("modeva/products/%s", productID.String())
@@ -324,9 +478,7 @@ func updateProductWithImages(c *gin.Context, productID uuid.UUID) {
 
 	// Step 2: Update database
 	if len(updates) > 0 {
-		if err := config.CmsGorm.WithContext(ctx).
-			Model(&product).
-			Updates(updates).Error; err != nil {
+		if _, err := saveProductUpdates(ctx, c, &product, updates, revisionMetaFromContext(c, models.RevisionSourceUpdate)); err != nil {
 			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update product: "+err.Error()))
 			return
 		}
@@ -383,6 +535,16 @@ func extractPublicIDFromURL(url string) string {
 	return afterUpload
 }
 
+// respondSKUError writes a 400 for SKU/barcode validation problems and a 500 for anything else
+func respondSKUError(c *gin.Context, err error) {
+	var skuErr *skuValidationError
+	if errors.As(err, &skuErr) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, skuErr.Error()))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+}
+
 // findImagesToDelete finds images that exist in the database but are not in the keep list
 func findImagesToDelete(existingImages, keepImages []models.MediaURL) []models.MediaURL {
 	var toDelete []models.MediaURL
@@ -0,0 +1,165 @@
+package question_controller
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetQuestions godoc
+// @Summary List product questions
+// @Description The question queue: unanswered questions (pending or approved, without a published answer) by default, oldest first, each with every answer so far. Pass status for pending, answered or rejected questions, or all.
+// @Tags CMS - Questions
+// @Produce json
+// @Param status query string false "Queue" Enums(unanswered, pending, answered, rejected, all) default(unanswered)
+// @Param product_id query string false "Product ID (UUID)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.AdminQuestion,meta=models.Pagination}
+// @Failure 400 {object} models.ApiResponse
+// @Router /api/v1/admin/questions [get]
+func GetQuestions(c *gin.Context) {
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	query := config.EcommerceGorm.WithContext(ctx).Table("product_questions q")
+
+	order := "q.created_at DESC"
+	switch c.DefaultQuery("status", "unanswered") {
+	case "unanswered":
+		order = "q.created_at ASC" // Oldest waiting first
+		query = query.Where("q.status <> ? AND q.answered_at IS NULL", models.QuestionRejected)
+	case models.QuestionPending:
+		order = "q.created_at ASC"
+		query = query.Where("q.status = ?", models.QuestionPending)
+	case "answered":
+		order = "q.answered_at DESC"
+		query = query.Where("q.status <> ? AND q.answered_at IS NOT NULL", models.QuestionRejected)
+	case models.QuestionRejected:
+		query = query.Where("q.status = ?", models.QuestionRejected)
+	case "all":
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "status must be unanswered, pending, answered, rejected or all"))
+		return
+	}
+	if raw := c.Query("product_id"); raw != "" {
+		productID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product_id"))
+			return
+		}
+		query = query.Where("q.product_id = ?", productID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch questions"))
+		return
+	}
+
+	questions := make([]models.AdminQuestion, 0)
+	if err := query.
+		Select("q.*, u.name AS customer_name, u.email AS customer_email").
+		Joins("JOIN users u ON u.id = q.user_id").
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Scan(&questions).Error; err != nil {
+		log.Printf("[admin.questions] failed to fetch questions: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch questions"))
+		return
+	}
+
+	// Answers, and product names from the CMS database
+	questionIDs := make([]uuid.UUID, 0, len(questions))
+	productIDs := make([]uuid.UUID, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+		productIDs = append(productIDs, question.ProductID)
+	}
+	answers, err := services.GetQuestionService().AdminAnswers(ctx, questionIDs)
+	if err != nil {
+		log.Printf("[admin.questions] failed to fetch answers: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch questions"))
+		return
+	}
+	names, err := services.GetReviewService().ProductNames(ctx, productIDs)
+	if err != nil {
+		log.Printf("[admin.questions] failed to look up product names: %v", err)
+	}
+	for i := range questions {
+		questions[i].ProductName = names[questions[i].ProductID]
+		questions[i].Answers = answers[questions[i].ID]
+		if questions[i].Answers == nil {
+			questions[i].Answers = make([]models.AdminAnswer, 0)
+		}
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(
+		c,
+		"Questions fetched successfully",
+		questions,
+		&models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: (int(total) + limit - 1) / limit,
+		},
+	))
+}
+
+// GetQuestionCounts godoc
+// @Summary Get question queue counts
+// @Description How many questions are waiting for moderation, unanswered and answered, and how many customers' answers are waiting for moderation
+// @Tags CMS - Questions
+// @Produce json
+// @Success 200 {object} models.ApiResponse{data=models.QuestionQueueCounts}
+// @Router /api/v1/admin/questions/stats [get]
+func GetQuestionCounts(c *gin.Context) {
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	var counts models.QuestionQueueCounts
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Raw(`
+			SELECT
+				COUNT(*) FILTER (WHERE status = ?) AS pending,
+				COUNT(*) FILTER (WHERE status <> ? AND answered_at IS NULL) AS unanswered,
+				COUNT(*) FILTER (WHERE status <> ? AND answered_at IS NOT NULL) AS answered,
+				(SELECT COUNT(*) FROM product_answers WHERE status = ?) AS pending_answers
+			FROM product_questions
+		`, models.QuestionPending, models.QuestionRejected, models.QuestionRejected, models.QuestionPending).
+		Scan(&counts).Error; err != nil {
+		log.Printf("[admin.questions] failed to count questions: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to count questions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Question counts fetched successfully", counts))
+}
@@ -0,0 +1,291 @@
+package question_controller
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApproveQuestion godoc
+// @Summary Approve a question
+// @Description Let a question appear on the storefront (once answered) and be answered by buyers
+// @Tags CMS - Questions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Question ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=models.ProductQuestion}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/questions/{id}/approve [post]
+func ApproveQuestion(c *gin.Context) {
+	moderateQuestion(c, models.QuestionApproved, nil)
+}
+
+// RejectQuestion godoc
+// @Summary Reject a question
+// @Description Keep a question off the storefront, optionally noting why for other admins
+// @Tags CMS - Questions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Question ID (UUID)"
+// @Param rejection body models.RejectQuestionRequest false "Reason"
+// @Success 200 {object} models.ApiResponse{data=models.ProductQuestion}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/questions/{id}/reject [post]
+func RejectQuestion(c *gin.Context) {
+	var req models.RejectQuestionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+			return
+		}
+	}
+	var reason *string
+	if trimmed := strings.TrimSpace(req.Reason); trimmed != "" {
+		reason = &trimmed
+	}
+	moderateQuestion(c, models.QuestionRejected, reason)
+}
+
+// AnswerQuestion godoc
+// @Summary Answer a question as the store
+// @Description Publish the store's answer to a question straight away, approving the question if it was pending. The asker is emailed when their question gets its first answer.
+// @Tags CMS - Questions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Question ID (UUID)"
+// @Param answer body models.ProductAnswerRequest true "Answer"
+// @Success 201 {object} models.ApiResponse{data=models.ProductAnswer}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Question was rejected"
+// @Router /api/v1/admin/questions/{id}/answer [post]
+func AnswerQuestion(c *gin.Context) {
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid question ID"))
+		return
+	}
+
+	var req models.ProductAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	req.Answer = strings.TrimSpace(req.Answer)
+	if req.Answer == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "answer is required"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	question, ok := loadQuestion(c, ctx, questionID)
+	if !ok {
+		return
+	}
+	if question.Status == models.QuestionRejected {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "This question was rejected; approve it before answering"))
+		return
+	}
+
+	adminID := adminFromContext(c)
+	if adminID == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse(c, "Unauthorized"))
+		return
+	}
+
+	now := time.Now()
+	answer := models.ProductAnswer{
+		QuestionID:  questionID,
+		AdminID:     adminID,
+		Answer:      req.Answer,
+		Status:      models.QuestionApproved,
+		ModeratedBy: adminID,
+		ModeratedAt: &now,
+	}
+	if err := config.EcommerceGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if question.Status == models.QuestionPending {
+			if err := tx.Model(question).Updates(map[string]interface{}{
+				"status":       models.QuestionApproved,
+				"moderated_by": adminID,
+				"moderated_at": now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&answer).Error
+	}); err != nil {
+		log.Printf("[admin.questions] failed to answer question %s: %v", questionID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to save answer"))
+		return
+	}
+
+	syncAnswered(ctx, questionID)
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Answer published successfully", answer))
+}
+
+// ApproveAnswer godoc
+// @Summary Approve a customer's answer
+// @Description Publish a buyer's answer under its question. The asker is emailed when their question gets its first answer.
+// @Tags CMS - Questions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Answer ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=models.ProductAnswer}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/answers/{id}/approve [post]
+func ApproveAnswer(c *gin.Context) {
+	moderateAnswer(c, models.QuestionApproved)
+}
+
+// RejectAnswer godoc
+// @Summary Reject an answer
+// @Description Take an answer (a customer's or the store's) off the storefront
+// @Tags CMS - Questions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Answer ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=models.ProductAnswer}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/answers/{id}/reject [post]
+func RejectAnswer(c *gin.Context) {
+	moderateAnswer(c, models.QuestionRejected)
+}
+
+// moderateQuestion sets a question's status; approving an answered question emails the
+// asker if they haven't been yet
+func moderateQuestion(c *gin.Context, status string, reason *string) {
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid question ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	question, ok := loadQuestion(c, ctx, questionID)
+	if !ok {
+		return
+	}
+
+	adminID := adminFromContext(c)
+	now := time.Now()
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Model(question).
+		Updates(map[string]interface{}{
+			"status":           status,
+			"rejection_reason": reason,
+			"moderated_by":     adminID,
+			"moderated_at":     now,
+		}).Error; err != nil {
+		log.Printf("[admin.questions] failed to set question %s to %s: %v", questionID, status, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update question"))
+		return
+	}
+	question.Status = status
+	question.RejectionReason = reason
+	question.ModeratedBy = adminID
+	question.ModeratedAt = &now
+
+	if status == models.QuestionApproved {
+		services.GetQuestionService().NotifyAskerAsync(questionID)
+	}
+
+	log.Printf("[admin.questions] question %s %s", questionID, status)
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Question "+status+" successfully", question))
+}
+
+// moderateAnswer sets an answer's status and whether its question counts as answered
+func moderateAnswer(c *gin.Context, status string) {
+	answerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid answer ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	var answer models.ProductAnswer
+	if err := config.EcommerceGorm.WithContext(ctx).First(&answer, "id = ?", answerID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Answer not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	adminID := adminFromContext(c)
+	now := time.Now()
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Model(&answer).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"moderated_by": adminID,
+			"moderated_at": now,
+		}).Error; err != nil {
+		log.Printf("[admin.questions] failed to set answer %s to %s: %v", answerID, status, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update answer"))
+		return
+	}
+	answer.Status = status
+	answer.ModeratedBy = adminID
+	answer.ModeratedAt = &now
+
+	syncAnswered(ctx, answer.QuestionID)
+
+	log.Printf("[admin.questions] answer %s %s", answerID, status)
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Answer "+status+" successfully", answer))
+}
+
+// syncAnswered updates whether the question counts as answered (and emails the asker)
+func syncAnswered(ctx context.Context, questionID uuid.UUID) {
+	if err := services.GetQuestionService().SyncAnswered(ctx, questionID); err != nil {
+		// The answer is saved; the question catches up on its next moderation
+		log.Printf("[admin.questions] ERROR failed to update answered state of question %s: %v", questionID, err)
+	}
+}
+
+// loadQuestion fetches a question, writing a 404 or 500 if it can't
+func loadQuestion(c *gin.Context, ctx context.Context, questionID uuid.UUID) (*models.ProductQuestion, bool) {
+	var question models.ProductQuestion
+	if err := config.EcommerceGorm.WithContext(ctx).
+		First(&question, "id = ?", questionID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Question not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return nil, false
+	}
+	return &question, true
+}
+
+// adminFromContext returns the logged-in admin's ID (set by AdminAuthMiddleware)
+func adminFromContext(c *gin.Context) *uuid.UUID {
+	if v, ok := c.Get("adminID"); ok {
+		if parsed, err := uuid.Parse(v.(string)); err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package review_controller
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetReviews godoc
+// @Summary List product reviews
+// @Description The review moderation queue: pending reviews by default, oldest first. Pass status to see approved or rejected reviews (newest first), or all.
+// @Tags CMS - Reviews
+// @Produce json
+// @Param status query string false "Review status" Enums(pending, approved, rejected, all) default(pending)
+// @Param product_id query string false "Product ID (UUID)"
+// @Param rating query int false "Star rating (1-5)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} models.ApiResponse{data=[]models.AdminReview,meta=models.Pagination}
+// @Failure 400 {object} models.ApiResponse
+// @Router /api/v1/admin/reviews [get]
+func GetReviews(c *gin.Context) {
+	// Pagination
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 100 {
+				parsed = 100 // Max 100 items per page
+			}
+			limit = parsed
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	query := config.EcommerceGorm.WithContext(ctx).Table("product_reviews r")
+
+	status := c.DefaultQuery("status", models.ReviewPending)
+	order := "r.created_at DESC"
+	switch status {
+	case models.ReviewPending:
+		order = "r.created_at ASC" // Oldest waiting first
+		query = query.Where("r.status = ?", status)
+	case models.ReviewApproved, models.ReviewRejected:
+		query = query.Where("r.status = ?", status)
+	case "all":
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "status must be pending, approved, rejected or all"))
+		return
+	}
+	if raw := c.Query("product_id"); raw != "" {
+		productID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product_id"))
+			return
+		}
+		query = query.Where("r.product_id = ?", productID)
+	}
+	if raw := c.Query("rating"); raw != "" {
+		rating, err := strconv.Atoi(raw)
+		if err != nil || rating < 1 || rating > 5 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "rating must be between 1 and 5"))
+			return
+		}
+		query = query.Where("r.rating = ?", rating)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch reviews"))
+		return
+	}
+
+	reviews := make([]models.AdminReview, 0)
+	if err := query.
+		Select("r.*, u.name AS customer_name, u.email AS customer_email").
+		Joins("JOIN users u ON u.id = r.user_id").
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Scan(&reviews).Error; err != nil {
+		log.Printf("[admin.reviews] failed to fetch reviews: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch reviews"))
+		return
+	}
+
+	// Product names come from the CMS database
+	productIDs := make([]uuid.UUID, 0, len(reviews))
+	for _, review := range reviews {
+		productIDs = append(productIDs, review.ProductID)
+	}
+	names, err := services.GetReviewService().ProductNames(ctx, productIDs)
+	if err != nil {
+		log.Printf("[admin.reviews] failed to look up product names: %v", err)
+	}
+	for i := range reviews {
+		reviews[i].ProductName = names[reviews[i].ProductID]
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(
+		c,
+		"Reviews fetched successfully",
+		reviews,
+		&models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: (int(total) + limit - 1) / limit,
+		},
+	))
+}
@@ -0,0 +1,180 @@
+package review_controller
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApproveReview godoc
+// @Summary Approve a review
+// @Description Publish a review on the storefront; it counts towards the product's rating from now on
+// @Tags CMS - Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review ID (UUID)"
+// @Success 200 {object} models.ApiResponse{data=models.ProductReview}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/reviews/{id}/approve [post]
+func ApproveReview(c *gin.Context) {
+	moderateReview(c, models.ReviewApproved, nil)
+}
+
+// RejectReview godoc
+// @Summary Reject a review
+// @Description Keep a review off the storefront (and out of the product's rating), optionally noting why for other admins
+// @Tags CMS - Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review ID (UUID)"
+// @Param rejection body models.RejectReviewRequest false "Reason"
+// @Success 200 {object} models.ApiResponse{data=models.ProductReview}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/reviews/{id}/reject [post]
+func RejectReview(c *gin.Context) {
+	var req models.RejectReviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+			return
+		}
+	}
+	var reason *string
+	if trimmed := strings.TrimSpace(req.Reason); trimmed != "" {
+		reason = &trimmed
+	}
+	moderateReview(c, models.ReviewRejected, reason)
+}
+
+// ReplyToReview godoc
+// @Summary Reply to a review
+// @Description Set the store's public reply to a review, shown under it on the storefront once it's approved. Replying again replaces the reply.
+// @Tags CMS - Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review ID (UUID)"
+// @Param reply body models.ReviewReplyRequest true "Reply"
+// @Success 200 {object} models.ApiResponse{data=models.ProductReview}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/reviews/{id}/reply [post]
+func ReplyToReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid review ID"))
+		return
+	}
+
+	var req models.ReviewReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	reply := strings.TrimSpace(req.Reply)
+	if reply == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "reply is required"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	review, ok := loadReview(c, ctx, reviewID)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Model(review).
+		Updates(map[string]interface{}{
+			"admin_reply": reply,
+			"replied_at":  now,
+		}).Error; err != nil {
+		log.Printf("[admin.reviews] failed to reply to review %s: %v", reviewID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to save reply"))
+		return
+	}
+	review.AdminReply = &reply
+	review.RepliedAt = &now
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Reply saved successfully", review))
+}
+
+// moderateReview sets a review's status and brings the product's rating up to date
+func moderateReview(c *gin.Context, status string, reason *string) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid review ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	review, ok := loadReview(c, ctx, reviewID)
+	if !ok {
+		return
+	}
+
+	var adminID *uuid.UUID
+	if v, ok := c.Get("adminID"); ok {
+		if parsed, err := uuid.Parse(v.(string)); err == nil {
+			adminID = &parsed
+		}
+	}
+
+	now := time.Now()
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Model(review).
+		Updates(map[string]interface{}{
+			"status":           status,
+			"rejection_reason": reason,
+			"moderated_by":     adminID,
+			"moderated_at":     now,
+		}).Error; err != nil {
+		log.Printf("[admin.reviews] failed to set review %s to %s: %v", reviewID, status, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update review"))
+		return
+	}
+	review.Status = status
+	review.RejectionReason = reason
+	review.ModeratedBy = adminID
+	review.ModeratedAt = &now
+
+	if err := services.GetReviewService().RefreshRating(ctx, review.ProductID); err != nil {
+		// The review is moderated; the rating catches up on the next moderation of this product
+		log.Printf("[admin.reviews] ERROR failed to refresh rating of %s: %v", review.ProductID, err)
+	}
+
+	log.Printf("[admin.reviews] review %s %s", reviewID, status)
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Review "+status+" successfully", review))
+}
+
+// loadReview fetches a review, writing a 404 or 500 if it can't
+func loadReview(c *gin.Context, ctx context.Context, reviewID uuid.UUID) (*models.ProductReview, bool) {
+	var review models.ProductReview
+	if err := config.EcommerceGorm.WithContext(ctx).
+		First(&review, "id = ?", reviewID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Review not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return nil, false
+	}
+	return &review, true
+}
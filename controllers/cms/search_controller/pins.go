@@ -0,0 +1,222 @@
+package search_controller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetPins godoc
+// @Summary Get pinned and buried search results
+// @Description List the products pinned to the top or buried at the bottom of searches, by query, then pins before buries in position order
+// @Tags CMS - Search
+// @Produce json
+// @Param query query string false "Only this search"
+// @Success 200 {object} models.ApiResponse{data=[]models.AdminSearchPin}
+// @Failure 500 {object} models.ApiResponse
+// @Router /api/v1/admin/search/pins [get]
+func GetPins(c *gin.Context) {
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	query := config.CmsGorm.WithContext(ctx).
+		Table("search_pins sp").
+		Select(`sp.*, p.name AS product_name, p.status AS product_status,
+			COALESCE(p.media->'primary'->>'url', '') AS product_image`).
+		Joins("JOIN products p ON p.id = sp.product_id")
+	if search := services.NormalizeSearchText(c.Query("query")); search != "" {
+		query = query.Where("sp.query = ?", search)
+	}
+
+	pins := make([]models.AdminSearchPin, 0)
+	if err := query.
+		Order("sp.query ASC, sp.action DESC, sp.position ASC, sp.created_at ASC").
+		Scan(&pins).Error; err != nil {
+		log.Printf("[admin.search] failed to fetch pins: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch pins"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Pins fetched successfully", pins))
+}
+
+// CreatePin godoc
+// @Summary Pin or bury a product for a search
+// @Description Pin a product to the top of a search's results (shown even if it doesn't match), or bury it at the bottom. The query is matched whole, ignoring case and extra spaces. Pins apply to relevance-sorted results only; other sorts are left as chosen.
+// @Tags CMS - Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pin body models.SearchPinRequest true "Query, product, pin or bury, and position"
+// @Success 201 {object} models.ApiResponse{data=models.SearchPin}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse "Product not found"
+// @Failure 409 {object} models.ApiResponse "The product is already pinned or buried for the search"
+// @Router /api/v1/admin/search/pins [post]
+func CreatePin(c *gin.Context) {
+	// Step 1: Validate JSON input
+	var req models.SearchPinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	pin := models.SearchPin{
+		Query:     services.NormalizeSearchText(req.Query),
+		ProductID: req.ProductID,
+		Action:    req.Action,
+		Position:  req.Position,
+	}
+	if pin.Query == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Query is required"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Ensure the product exists
+	var productCount int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ?", pin.ProductID).
+		Count(&productCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if productCount == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	// Step 3: Ensure it isn't pinned or buried for the search already
+	var existing int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.SearchPin{}).
+		Where("query = ? AND product_id = ?", pin.Query, pin.ProductID).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "This product is already pinned or buried for this search"))
+		return
+	}
+
+	// Step 4: Save
+	if err := config.CmsGorm.WithContext(ctx).Create(&pin).Error; err != nil {
+		log.Printf("[admin.search] failed to create pin: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create pin"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Pin created successfully", pin))
+}
+
+// UpdatePin godoc
+// @Summary Update a pinned or buried search result
+// @Description Move a pin, or switch it between pin and bury
+// @Tags CMS - Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pin ID (UUID)"
+// @Param pin body models.UpdateSearchPinRequest true "Fields to update"
+// @Success 200 {object} models.ApiResponse{data=models.SearchPin}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/search/pins/{id} [patch]
+func UpdatePin(c *gin.Context) {
+	pinID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid pin ID"))
+		return
+	}
+
+	var req models.UpdateSearchPinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find existing pin
+	var pin models.SearchPin
+	if err := config.CmsGorm.WithContext(ctx).First(&pin, "id = ?", pinID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Pin not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	// Step 2: Build update map (only non-nil fields)
+	updates := make(map[string]interface{})
+	if req.Action != nil {
+		pin.Action = *req.Action
+		updates["action"] = pin.Action
+	}
+	if req.Position != nil {
+		pin.Position = *req.Position
+		updates["position"] = pin.Position
+	}
+	if len(updates) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "No fields to update"))
+		return
+	}
+
+	// Step 3: Save
+	if err := config.CmsGorm.WithContext(ctx).Model(&pin).Updates(updates).Error; err != nil {
+		log.Printf("[admin.search] failed to update pin %s: %v", pinID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update pin"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Pin updated successfully", pin))
+}
+
+// DeletePin godoc
+// @Summary Delete a pinned or buried search result
+// @Description Unpin (or unbury) a product; it goes back to its relevance position
+// @Tags CMS - Search
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pin ID (UUID)"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/search/pins/{id} [delete]
+func DeletePin(c *gin.Context) {
+	pinID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid pin ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	result := config.CmsGorm.WithContext(ctx).Delete(&models.SearchPin{}, "id = ?", pinID)
+	if result.Error != nil {
+		log.Printf("[admin.search] failed to delete pin %s: %v", pinID, result.Error)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to delete pin"))
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Pin not found"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Pin deleted successfully", nil))
+}
@@ -0,0 +1,100 @@
+package search_controller
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PreviewSearch godoc
+// @Summary Preview a storefront search
+// @Description Test a search as the storefront runs it: the rules that apply (synonym expansion, redirect, pinned and buried products) and the first active products found, in relevance order. Rule changes reach every server within a minute.
+// @Tags CMS - Search
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Results to show (max 100)" default(24)
+// @Success 200 {object} models.ApiResponse{data=models.SearchPreview}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 500 {object} models.ApiResponse
+// @Router /api/v1/admin/search/preview [get]
+func PreviewSearch(c *gin.Context) {
+	searchQuery := strings.TrimSpace(c.Query("q"))
+	if searchQuery == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Search query is required"))
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "24"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 24
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Apply the rules
+	plan := services.GetSearchRuleService().Plan(ctx, searchQuery)
+	where := fmt.Sprintf("p.deleted_at IS NULL AND p.status = 'Active' AND %s", plan.MatchSQL())
+	args := []interface{}{plan.Expanded, plan.Query}
+
+	// Step 2: Run the search as the storefront does
+	var total int64
+	if err := models.RunSearch(config.CmsGorm.WithContext(ctx), true, func(db *gorm.DB) error {
+		return db.Raw(fmt.Sprintf(`SELECT COUNT(*) FROM products p %s WHERE %s`, models.SearchJoinSQL, where), args...).
+			Scan(&total).Error
+	}); err != nil {
+		log.Printf("[admin.search] failed to count preview results for %q: %v", searchQuery, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to preview search"))
+		return
+	}
+
+	results := make([]models.SearchPreviewResult, 0, limit)
+	if err := models.RunSearch(config.CmsGorm.WithContext(ctx), true, func(db *gorm.DB) error {
+		return db.Raw(fmt.Sprintf(`
+			SELECT
+				p.id,
+				p.name,
+				COALESCE(p.media->'primary'->>'url', '') AS image,
+				%s AS rank,
+				%s AS highlight
+			FROM products p %s
+			WHERE %s
+			ORDER BY %s, p.created_at DESC
+			LIMIT ?
+		`, models.SearchRankSQL, models.SearchHeadlineNameSQL, models.SearchJoinSQL, where, plan.RelevanceOrderSQL()),
+			append(args, limit)...).
+			Scan(&results).Error
+	}); err != nil {
+		log.Printf("[admin.search] failed to preview %q: %v", searchQuery, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to preview search"))
+		return
+	}
+
+	// Step 3: Mark what the rules moved
+	pinned := make(map[uuid.UUID]bool, len(plan.Pinned))
+	for _, id := range plan.Pinned {
+		pinned[id] = true
+	}
+	buried := make(map[uuid.UUID]bool, len(plan.Buried))
+	for _, id := range plan.Buried {
+		buried[id] = true
+	}
+	for i := range results {
+		results[i].Pinned = pinned[results[i].ID]
+		results[i].Buried = buried[results[i].ID]
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Search preview fetched successfully", models.SearchPreview{
+		Plan:    plan,
+		Total:   int(total),
+		Results: results,
+	}))
+}
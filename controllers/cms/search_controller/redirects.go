@@ -0,0 +1,214 @@
+package search_controller
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetRedirects godoc
+// @Summary Get search redirects
+// @Description List the searches that send customers to a page instead of the results, by query
+// @Tags CMS - Search
+// @Produce json
+// @Success 200 {object} models.ApiResponse{data=[]models.SearchRedirect}
+// @Failure 500 {object} models.ApiResponse
+// @Router /api/v1/admin/search/redirects [get]
+func GetRedirects(c *gin.Context) {
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	redirects := make([]models.SearchRedirect, 0)
+	if err := config.CmsGorm.WithContext(ctx).Order("query ASC").Find(&redirects).Error; err != nil {
+		log.Printf("[admin.search] failed to fetch redirects: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch redirects"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Redirects fetched successfully", redirects))
+}
+
+// CreateRedirect godoc
+// @Summary Create a search redirect
+// @Description Send a storefront search to a page (e.g. "sale" → /collections/sale). The query is matched whole, ignoring case and extra spaces. The URL is a storefront path or an absolute http(s) URL.
+// @Tags CMS - Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param redirect body models.SearchRedirectRequest true "Query and URL"
+// @Success 201 {object} models.ApiResponse{data=models.SearchRedirect}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "The query already redirects"
+// @Router /api/v1/admin/search/redirects [post]
+func CreateRedirect(c *gin.Context) {
+	// Step 1: Validate JSON input
+	var req models.SearchRedirectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	redirect, ok := redirectFromRequest(c, req, uuid.Nil)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Save
+	if err := config.CmsGorm.WithContext(ctx).Create(&redirect).Error; err != nil {
+		log.Printf("[admin.search] failed to create redirect: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create redirect"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Redirect created successfully", redirect))
+}
+
+// UpdateRedirect godoc
+// @Summary Update a search redirect
+// @Description Change a redirect's query or URL
+// @Tags CMS - Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Redirect ID (UUID)"
+// @Param redirect body models.SearchRedirectRequest true "Query and URL"
+// @Success 200 {object} models.ApiResponse{data=models.SearchRedirect}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "The query already redirects"
+// @Router /api/v1/admin/search/redirects/{id} [put]
+func UpdateRedirect(c *gin.Context) {
+	redirectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid redirect ID"))
+		return
+	}
+
+	var req models.SearchRedirectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find existing redirect
+	var existing models.SearchRedirect
+	if err := config.CmsGorm.WithContext(ctx).First(&existing, "id = ?", redirectID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Redirect not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	// Step 2: Validate the changes
+	redirect, ok := redirectFromRequest(c, req, redirectID)
+	if !ok {
+		return
+	}
+
+	// Step 3: Save
+	existing.Query, existing.URL = redirect.Query, redirect.URL
+	if err := config.CmsGorm.WithContext(ctx).Model(&existing).Updates(map[string]interface{}{
+		"query": redirect.Query,
+		"url":   redirect.URL,
+	}).Error; err != nil {
+		log.Printf("[admin.search] failed to update redirect %s: %v", redirectID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update redirect"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Redirect updated successfully", existing))
+}
+
+// DeleteRedirect godoc
+// @Summary Delete a search redirect
+// @Description Delete a redirect; the search goes back to showing results
+// @Tags CMS - Search
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Redirect ID (UUID)"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/search/redirects/{id} [delete]
+func DeleteRedirect(c *gin.Context) {
+	redirectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid redirect ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	result := config.CmsGorm.WithContext(ctx).Delete(&models.SearchRedirect{}, "id = ?", redirectID)
+	if result.Error != nil {
+		log.Printf("[admin.search] failed to delete redirect %s: %v", redirectID, result.Error)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to delete redirect"))
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Redirect not found"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Redirect deleted successfully", nil))
+}
+
+// redirectFromRequest normalizes a redirect's query, checks its URL and that no other
+// redirect (than exceptID) has the query, writing the error response when it can't be used
+func redirectFromRequest(c *gin.Context, req models.SearchRedirectRequest, exceptID uuid.UUID) (models.SearchRedirect, bool) {
+	redirect := models.SearchRedirect{
+		Query: services.NormalizeSearchText(req.Query),
+		URL:   strings.TrimSpace(req.URL),
+	}
+	if redirect.Query == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Query is required"))
+		return redirect, false
+	}
+	if !validRedirectURL(redirect.URL) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "URL must be a storefront path (starting with /) or an absolute http(s) URL"))
+		return redirect, false
+	}
+
+	var existing int64
+	if err := config.CmsGorm.WithContext(c.Request.Context()).
+		Model(&models.SearchRedirect{}).
+		Where("query = ? AND id <> ?", redirect.Query, exceptID).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return redirect, false
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "This search already redirects"))
+		return redirect, false
+	}
+	return redirect, true
+}
+
+// validRedirectURL accepts storefront paths ("/collections/sale", not "//host") and
+// absolute http(s) URLs
+func validRedirectURL(raw string) bool {
+	if strings.HasPrefix(raw, "/") {
+		return !strings.HasPrefix(raw, "//")
+	}
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
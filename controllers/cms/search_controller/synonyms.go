@@ -0,0 +1,200 @@
+package search_controller
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetSynonyms godoc
+// @Summary Get search synonym groups
+// @Description List the groups of interchangeable search terms, oldest first
+// @Tags CMS - Search
+// @Produce json
+// @Success 200 {object} models.ApiResponse{data=[]models.SearchSynonymGroup}
+// @Failure 500 {object} models.ApiResponse
+// @Router /api/v1/admin/search/synonyms [get]
+func GetSynonyms(c *gin.Context) {
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	groups := make([]models.SearchSynonymGroup, 0)
+	if err := config.CmsGorm.WithContext(ctx).Order("created_at ASC").Find(&groups).Error; err != nil {
+		log.Printf("[admin.search] failed to fetch synonym groups: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch synonym groups"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Synonym groups fetched successfully", groups))
+}
+
+// CreateSynonym godoc
+// @Summary Create a search synonym group
+// @Description Add a group of interchangeable terms: a storefront search containing any of them also matches the others (e.g. sneakers, trainers). A term can be in one group only.
+// @Tags CMS - Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group body models.SearchSynonymRequest true "Terms"
+// @Success 201 {object} models.ApiResponse{data=models.SearchSynonymGroup}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "A term is already in another group"
+// @Router /api/v1/admin/search/synonyms [post]
+func CreateSynonym(c *gin.Context) {
+	// Step 1: Validate JSON input
+	var req models.SearchSynonymRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+	terms, ok := synonymTerms(c, req.Terms, uuid.Nil)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Save
+	group := models.SearchSynonymGroup{Terms: terms}
+	if err := config.CmsGorm.WithContext(ctx).Create(&group).Error; err != nil {
+		log.Printf("[admin.search] failed to create synonym group: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create synonym group"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Synonym group created successfully", group))
+}
+
+// UpdateSynonym godoc
+// @Summary Update a search synonym group
+// @Description Replace the terms of a synonym group
+// @Tags CMS - Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Synonym group ID (UUID)"
+// @Param group body models.SearchSynonymRequest true "Terms"
+// @Success 200 {object} models.ApiResponse{data=models.SearchSynonymGroup}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "A term is already in another group"
+// @Router /api/v1/admin/search/synonyms/{id} [put]
+func UpdateSynonym(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid synonym group ID"))
+		return
+	}
+
+	var req models.SearchSynonymRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 1: Find existing group
+	var group models.SearchSynonymGroup
+	if err := config.CmsGorm.WithContext(ctx).First(&group, "id = ?", groupID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Synonym group not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	// Step 2: Validate the new terms
+	terms, ok := synonymTerms(c, req.Terms, groupID)
+	if !ok {
+		return
+	}
+
+	// Step 3: Save
+	group.Terms = terms
+	if err := config.CmsGorm.WithContext(ctx).Model(&group).Update("terms", terms).Error; err != nil {
+		log.Printf("[admin.search] failed to update synonym group %s: %v", groupID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to update synonym group"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Synonym group updated successfully", group))
+}
+
+// DeleteSynonym godoc
+// @Summary Delete a search synonym group
+// @Description Delete a synonym group; its terms go back to matching only themselves
+// @Tags CMS - Search
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Synonym group ID (UUID)"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /api/v1/admin/search/synonyms/{id} [delete]
+func DeleteSynonym(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid synonym group ID"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	result := config.CmsGorm.WithContext(ctx).Delete(&models.SearchSynonymGroup{}, "id = ?", groupID)
+	if result.Error != nil {
+		log.Printf("[admin.search] failed to delete synonym group %s: %v", groupID, result.Error)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to delete synonym group"))
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Synonym group not found"))
+		return
+	}
+	services.GetSearchRuleService().Invalidate()
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Synonym group deleted successfully", nil))
+}
+
+// synonymTerms normalizes and de-duplicates a group's terms and checks none is in another
+// group, writing the error response when they can't be used
+func synonymTerms(c *gin.Context, raw []string, groupID uuid.UUID) (models.SynonymTerms, bool) {
+	terms := make(models.SynonymTerms, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, term := range raw {
+		term = services.NormalizeSearchText(term)
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+	if len(terms) < 2 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "A synonym group needs at least two different terms"))
+		return nil, false
+	}
+
+	other, term, err := services.GetSearchRuleService().SynonymGroupWithTerm(c.Request.Context(), terms, groupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return nil, false
+	}
+	if other != nil {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, fmt.Sprintf("%q is already in another synonym group", term)))
+		return nil, false
+	}
+	return terms, true
+}
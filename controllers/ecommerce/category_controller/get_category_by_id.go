@@ -40,7 +40,7 @@ func GetCategoryByID(c *gin.Context) {
 			c.parent_id::text AS parent_id,
 			COUNT(DISTINCT p.id)::int AS product_count
 		FROM categories c
-		LEFT JOIN products p ON p.sub_category_id = c.id AND p.status = 'Active'
+		LEFT JOIN products p ON p.sub_category_id = c.id AND p.status = 'Active' AND p.deleted_at IS NULL
 		WHERE c.id = ? AND c.status = 'Active'
 		GROUP BY c.id, c.name, c.description, c.parent_id
 	`
@@ -67,7 +67,7 @@ func GetCategoryByID(c *gin.Context) {
 			c.parent_id::text AS parent_id,
 			COUNT(DISTINCT p.id)::int AS product_count
 		FROM categories c
-		LEFT JOIN products p ON p.sub_category_id = c.id AND p.status = 'Active'
+		LEFT JOIN products p ON p.sub_category_id = c.id AND p.status = 'Active' AND p.deleted_at IS NULL
 		WHERE c.parent_id = ? AND c.status = 'Active'
 		GROUP BY c.id, c.name, c.description, c.parent_id
 		ORDER BY c.name ASC
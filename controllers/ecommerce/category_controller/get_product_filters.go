@@ -1,6 +1,7 @@
 package category_controller
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
@@ -29,7 +30,7 @@ func GetProductFilters(c *gin.Context) {
 			c.name AS label,
 			COUNT(p.id)::int AS count
 		FROM categories c
-		LEFT JOIN products p ON p.sub_category_id = c.id AND p.status = 'Active'
+		LEFT JOIN products p ON p.sub_category_id = c.id AND p.status = 'Active' AND p.deleted_at IS NULL
 		WHERE c.status = 'Active'
 		GROUP BY c.id, c.name
 		HAVING COUNT(p.id) > 0
@@ -50,7 +51,7 @@ func GetProductFilters(c *gin.Context) {
 			variant->>'size' AS label,
 			COUNT(*)::int AS count
 		FROM products p, jsonb_array_elements(p.variants) AS variant
-		WHERE p.status = 'Active' AND variant->>'size' IS NOT NULL
+		WHERE p.status = 'Active' AND p.deleted_at IS NULL AND variant->>'size' IS NOT NULL
 		GROUP BY variant->>'size'
 		ORDER BY variant->>'size' ASC
 	`
@@ -62,14 +63,14 @@ func GetProductFilters(c *gin.Context) {
 		filters.Sizes = []models.FilterOption{}
 	}
 
-	// Get price range (use COALESCE for safety)
-	priceQuery := `
+	// Get price range, sale prices included (use COALESCE for safety)
+	priceQuery := fmt.Sprintf(`
 		SELECT 
-			COALESCE(MIN(price), 0)::float8 AS min, 
-			COALESCE(MAX(price), 0)::float8 AS max
-		FROM products
-		WHERE status = 'Active'
-	`
+			COALESCE(MIN(%[1]s), 0)::float8 AS min, 
+			COALESCE(MAX(%[1]s), 0)::float8 AS max
+		FROM products p
+		WHERE p.status = 'Active' AND p.deleted_at IS NULL
+	`, models.EffectivePriceSQL)
 
 	var priceRange models.PriceRange
 	if err := config.CmsGorm.WithContext(ctx).Raw(priceQuery).Scan(&priceRange).Error; err == nil {
@@ -1,6 +1,7 @@
 package filter_controller
 
 import (
+	"fmt"
 	"net/http"
 	"sync"
 
@@ -83,29 +84,18 @@ func GetFilterMetadata(c *gin.Context) {
 }
 
 // getAvailabilityCounts counts products with at least one variant in stock vs all variants out of stock
+// (bundles count as in stock when their components are)
 func getAvailabilityCounts(db *gorm.DB) (*models.AvailabilityData, error) {
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
-	query := `
+	query := fmt.Sprintf(`
 		SELECT 
-			COUNT(DISTINCT p.id) FILTER (
-				WHERE EXISTS (
-					SELECT 1 
-					FROM jsonb_array_elements(p.inventory) AS item
-					WHERE (item->>'quantity')::int > 0
-				)
-			)::int as in_stock,
-			COUNT(DISTINCT p.id) FILTER (
-				WHERE NOT EXISTS (
-					SELECT 1 
-					FROM jsonb_array_elements(p.inventory) AS item
-					WHERE (item->>'quantity')::int > 0
-				)
-			)::int as out_of_stock
+			COUNT(DISTINCT p.id) FILTER (WHERE %[1]s)::int as in_stock,
+			COUNT(DISTINCT p.id) FILTER (WHERE NOT %[1]s)::int as out_of_stock
 		FROM products p
-		WHERE p.status = 'Active'
-	`
+		WHERE p.status = 'Active' AND p.deleted_at IS NULL
+	`, models.InStockSQL)
 
 	var data models.AvailabilityData
 	err := db.WithContext(ctx).Raw(query).Scan(&data).Error
@@ -187,14 +177,16 @@ func getPriceRange(db *gorm.DB) (*models.PriceRangeData, error) {
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
-	query := `
+	// Sale prices included, matching the minPrice/maxPrice filters
+	query := fmt.Sprintf(`
 		SELECT 
-			COALESCE(MIN(price), 0)::float8 as min,
-			COALESCE(MAX(price), 1000)::float8 as max
-		FROM products
-		WHERE status = 'Active'
-			AND price > 0
-	`
+			COALESCE(MIN(%[1]s), 0)::float8 as min,
+			COALESCE(MAX(%[1]s), 1000)::float8 as max
+		FROM products p
+		WHERE p.status = 'Active'
+			AND p.deleted_at IS NULL
+			AND p.price > 0
+	`, models.EffectivePriceSQL)
 
 	var priceRange models.PriceRangeData
 	err := db.WithContext(ctx).Raw(query).Scan(&priceRange).Error
@@ -0,0 +1,102 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AnswerProductQuestion godoc
+// @Summary Answer another shopper's question
+// @Description Answer an approved question about a product you've bought (a completed order containing it). Answers are shown once approved by the store.
+// @Tags store
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Question ID"
+// @Param answer body models.ProductAnswerRequest true "Answer"
+// @Success 201 {object} models.ApiResponse{data=models.ProductAnswer}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 401 {object} models.ApiResponse
+// @Failure 403 {object} models.ApiResponse "Not a buyer of the product"
+// @Failure 404 {object} models.ApiResponse
+// @Router /store/questions/{id}/answers [post]
+func AnswerProductQuestion(c *gin.Context) {
+	// Step 1: Validate question ID, customer and payload
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid question ID"))
+		return
+	}
+
+	userID, ok := customerFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse(c, "Unauthorized"))
+		return
+	}
+
+	var req models.ProductAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid request: "+err.Error()))
+		return
+	}
+	req.Answer = strings.TrimSpace(req.Answer)
+	if req.Answer == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "answer is required"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: The question is public and the customer has bought the product
+	var question models.ProductQuestion
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Where("id = ? AND status = ?", questionID, models.QuestionApproved).
+		First(&question).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Question not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+	if question.UserID == userID {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "You can't answer your own question"))
+		return
+	}
+
+	orderID, err := services.GetReviewService().VerifiedOrder(ctx, userID, question.ProductID)
+	if err != nil {
+		log.Printf("[store.questions] failed to check orders of %s for %s: %v", userID, question.ProductID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if orderID == nil {
+		c.JSON(http.StatusForbidden, models.ErrorResponse(c, "Only customers with a completed order of this product can answer"))
+		return
+	}
+
+	// Step 3: Save it for moderation
+	answer := models.ProductAnswer{
+		QuestionID: questionID,
+		UserID:     &userID,
+		Answer:     req.Answer,
+		Status:     models.QuestionPending,
+	}
+	if err := config.EcommerceGorm.WithContext(ctx).Create(&answer).Error; err != nil {
+		log.Printf("[store.questions] failed to create answer to %s by %s: %v", questionID, userID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to submit answer"))
+		return
+	}
+
+	log.Printf("[store.questions] answer %s to %s submitted for moderation", answer.ID, questionID)
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Answer submitted; it will appear once approved", answer))
+}
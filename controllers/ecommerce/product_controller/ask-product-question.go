@@ -0,0 +1,85 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AskProductQuestion godoc
+// @Summary Ask a question about a product
+// @Description Ask about fit, fabric and the like. The question is shown on the product page once approved and answered, and you're emailed when it's answered.
+// @Tags store
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param question body models.ProductQuestionRequest true "Question"
+// @Success 201 {object} models.ApiResponse{data=models.ProductQuestion}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 401 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /store/products/{id}/questions [post]
+func AskProductQuestion(c *gin.Context) {
+	// Step 1: Validate product ID, customer and payload
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	userID, ok := customerFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse(c, "Unauthorized"))
+		return
+	}
+
+	var req models.ProductQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid request: "+err.Error()))
+		return
+	}
+	req.Question = strings.TrimSpace(req.Question)
+	if req.Question == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "question is required"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: The product is on sale
+	var count int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ? AND status = ?", productID, models.ProductStatusActive).
+		Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if count == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	// Step 3: Save it for moderation
+	question := models.ProductQuestion{
+		ProductID: productID,
+		UserID:    userID,
+		Question:  req.Question,
+		Status:    models.QuestionPending,
+	}
+	if err := config.EcommerceGorm.WithContext(ctx).Create(&question).Error; err != nil {
+		log.Printf("[store.questions] failed to create question on %s by %s: %v", productID, userID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to submit question"))
+		return
+	}
+
+	log.Printf("[store.questions] question %s on %s submitted for moderation", question.ID, productID)
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Question submitted; we'll email you when it's answered", question))
+}
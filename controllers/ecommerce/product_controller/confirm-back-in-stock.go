@@ -0,0 +1,69 @@
+package product_controller
+
+import (
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ConfirmBackInStock godoc
+// @Summary Confirm a back-in-stock subscription
+// @Description Confirm a guest's back-in-stock subscription using the token from the confirmation email. Confirmed subscriptions are emailed when the variant is restocked.
+// @Tags store
+// @Accept json
+// @Produce json
+// @Param request body models.BackInStockConfirmRequest true "Confirmation token"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Subscription was unsubscribed"
+// @Failure 429 {object} models.ApiResponse "Too many requests"
+// @Router /store/notifications/confirm [post]
+func ConfirmBackInStock(c *gin.Context) {
+	var req models.BackInStockConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid request: "+err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	var subscription models.BackInStockSubscription
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Where("unsubscribe_token = ?", req.Token).
+		Limit(1).
+		Find(&subscription).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if subscription.ID == uuid.Nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Subscription not found"))
+		return
+	}
+
+	switch subscription.Status {
+	case models.BackInStockUnsubscribed:
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "This subscription was cancelled"))
+		return
+	case models.BackInStockUnconfirmed:
+		if err := config.EcommerceGorm.WithContext(ctx).
+			Exec(`
+				UPDATE back_in_stock_subscriptions
+				SET status = ?, confirmed_at = NOW()
+				WHERE id = ? AND status = ?
+			`, models.BackInStockPending, subscription.ID, models.BackInStockUnconfirmed).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to confirm subscription"))
+			return
+		}
+		// The variant may have been restocked while the confirmation was in the inbox
+		services.GetBackInStockService().NotifyRestockedAsync(subscription.ProductID)
+	}
+
+	// Already confirmed links keep working
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Your email is confirmed. We'll let you know when it's back in stock", nil))
+}
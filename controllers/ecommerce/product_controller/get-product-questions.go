@@ -0,0 +1,56 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetProductQuestions godoc
+// @Summary Get a product's questions and answers
+// @Description Answered questions about a product, most recently answered first, with the store's and buyers' answers
+// @Tags store
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(12)
+// @Success 200 {object} models.ApiResponse{data=[]models.StorefrontQuestion,meta=models.Pagination}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 500 {object} models.ApiResponse
+// @Router /store/products/{id}/questions [get]
+func GetProductQuestions(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	page, limit := parsePagination(c)
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	questions, total, err := services.GetQuestionService().Answered(ctx, productID, limit, (page-1)*limit)
+	if err != nil {
+		log.Printf("[store.questions] failed to fetch questions of %s: %v", productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch questions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(
+		c,
+		"Questions fetched successfully",
+		questions,
+		&models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	))
+}
@@ -0,0 +1,112 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetProductReviews godoc
+// @Summary Get a product's reviews
+// @Description Approved reviews of a product, with the store's replies. Every review is from a customer who bought the product.
+// @Tags store
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param rating query int false "Only reviews with this star rating (1-5)"
+// @Param sortBy query string false "Sort by" Enums(newest, oldest, highest, lowest) default(newest)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(12)
+// @Success 200 {object} models.ApiResponse{data=[]models.StorefrontReview,meta=models.Pagination}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 500 {object} models.ApiResponse
+// @Router /store/products/{id}/reviews [get]
+func GetProductReviews(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	page, limit := parsePagination(c)
+	offset := (page - 1) * limit
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	query := config.EcommerceGorm.WithContext(ctx).
+		Table("product_reviews r").
+		Where("r.product_id = ? AND r.status = ?", productID, models.ReviewApproved)
+	if raw := c.Query("rating"); raw != "" {
+		rating, err := strconv.Atoi(raw)
+		if err != nil || rating < 1 || rating > 5 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "rating must be between 1 and 5"))
+			return
+		}
+		query = query.Where("r.rating = ?", rating)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch reviews"))
+		return
+	}
+
+	order := "r.created_at DESC"
+	switch c.Query("sortBy") {
+	case "oldest":
+		order = "r.created_at ASC"
+	case "highest":
+		order = "r.rating DESC, r.created_at DESC"
+	case "lowest":
+		order = "r.rating ASC, r.created_at DESC"
+	}
+
+	var rows []struct {
+		models.ProductReview
+		CustomerName string
+	}
+	if err := query.
+		Select("r.*, u.name AS customer_name").
+		Joins("JOIN users u ON u.id = r.user_id").
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error; err != nil {
+		log.Printf("[store.reviews] failed to fetch reviews of %s: %v", productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch reviews"))
+		return
+	}
+
+	reviews := make([]models.StorefrontReview, 0, len(rows))
+	for _, row := range rows {
+		reviews = append(reviews, models.StorefrontReview{
+			ID:               row.ID,
+			Rating:           row.Rating,
+			Title:            row.Title,
+			Body:             row.Body,
+			Photos:           row.Photos,
+			ReviewerName:     models.ReviewerDisplayName(row.CustomerName),
+			VerifiedPurchase: true,
+			AdminReply:       row.AdminReply,
+			RepliedAt:        row.RepliedAt,
+			CreatedAt:        row.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse(
+		c,
+		"Reviews fetched successfully",
+		reviews,
+		&models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: (int(total) + limit - 1) / limit,
+		},
+	))
+}
@@ -0,0 +1,46 @@
+package product_controller
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// searchSuggestTimeout bounds a suggestion lookup; the box is updated on every keystroke
+const searchSuggestTimeout = 2 * time.Second
+
+// GetSearchSuggestions godoc
+// @Summary Get search suggestions
+// @Description Suggestions for the search box as the customer types: active products whose name has a word starting with the text (with thumbnails), matching categories and subcategories, and popular searches that start with the text. The X-Search-Id header identifies the lookup for POST /store/search/{id}/click.
+// @Tags Storefront - Products
+// @Produce json
+// @Param q query string true "What the customer has typed so far"
+// @Success 200 {object} models.ApiResponse{data=models.SearchSuggestions}
+// @Failure 500 {object} models.ApiResponse
+// @Router /store/search/suggest [get]
+func GetSearchSuggestions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), searchSuggestTimeout)
+	defer cancel()
+
+	suggestions, err := services.GetSearchSuggestService().Suggest(ctx, c.Query("q"))
+	if err != nil {
+		log.Printf("[store.search] failed to suggest for %q: %v", c.Query("q"), err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch search suggestions"))
+		return
+	}
+
+	// Logged for the search analytics; X-Search-Id reports clicks on the suggestions
+	results := len(suggestions.Products) + len(suggestions.Categories) + len(suggestions.Subcategories)
+	searchID := services.GetSearchAnalyticsService().LogSearch(c.Query("q"), models.SearchSourceSuggest, results, nil, nil)
+	if searchID != uuid.Nil {
+		c.Header("X-Search-Id", searchID.String())
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Search suggestions fetched successfully", suggestions))
+}
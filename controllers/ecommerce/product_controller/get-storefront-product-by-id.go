@@ -2,17 +2,24 @@ package product_controller
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// productPageQuestions is how many answered questions come with a product
+const productPageQuestions = 5
+
 // GetStorefrontProductByID godoc
 // @Summary Get single product details for storefront
-// @Description Get detailed product information by ID
+// @Description Get detailed product information by ID. While a sale applies, lowest_price_30d is the lowest price in the 30 days before it began. Logged-in customers in a customer group get their group's prices and quantity tiers. Bundles have no inventory of their own; bundle lists their components and how many bundles are available. quantity_rules are the product's purchase limits; for logged-in customers with a per-customer limit, remaining_limit is how many more they can order. Combo quantities leave out stock held for backorders; combos that can be ordered beyond stock carry their backorder policy (mode, expected_ship_date and, when capped, how many units are still available). rating_average and rating_count come from approved reviews. questions are the most recently answered questions with their answers (the rest via /questions).
 // @Tags store
 // @Produce json
 // @Param id path string true "Product ID"
@@ -38,24 +45,44 @@ func GetStorefrontProductByID(c *gin.Context) {
 			p.name,
 			p.description,
 			p.price,
+			p.compare_at_price,
+			p.sale_price,
+			p.sale_starts_at,
+			p.sale_ends_at,
 			p.inventory,
+			p.product_type,
+			p.bundle_components,
+			p.quantity_rules,
+			p.backorder,
+			p.rating_average,
+			p.rating_count,
 			p.media,
 			p.variants,
 			c.name AS category_name
 		FROM products p
 		LEFT JOIN categories c ON c.id = p.sub_category_id
-		WHERE p.id = ? AND p.status = 'Active'
+		WHERE p.id = ? AND p.status = 'Active' AND p.deleted_at IS NULL
 	`
 
 	var result struct {
-		ID           string  `gorm:"column:id"`
-		Name         string  `gorm:"column:name"`
-		Description  string  `gorm:"column:description"`
-		Price        float64 `gorm:"column:price"`
-		Inventory    []byte  `gorm:"column:inventory"`
-		Media        []byte  `gorm:"column:media"`
-		Variants     []byte  `gorm:"column:variants"`
-		CategoryName *string `gorm:"column:category_name"`
+		ID             string                     `gorm:"column:id"`
+		Name           string                     `gorm:"column:name"`
+		Description    string                     `gorm:"column:description"`
+		Price          float64                    `gorm:"column:price"`
+		CompareAtPrice *float64                   `gorm:"column:compare_at_price"`
+		SalePrice      *float64                   `gorm:"column:sale_price"`
+		SaleStartsAt   *time.Time                 `gorm:"column:sale_starts_at"`
+		SaleEndsAt     *time.Time                 `gorm:"column:sale_ends_at"`
+		Inventory      models.InventoryList       `gorm:"column:inventory"`
+		ProductType    string                     `gorm:"column:product_type"`
+		Components     models.BundleComponentList `gorm:"column:bundle_components"`
+		QuantityRules  models.QuantityRules       `gorm:"column:quantity_rules"`
+		Backorder      models.BackorderPolicy     `gorm:"column:backorder"`
+		RatingAverage  float64                    `gorm:"column:rating_average"`
+		RatingCount    int                        `gorm:"column:rating_count"`
+		Media          []byte                     `gorm:"column:media"`
+		Variants       []byte                     `gorm:"column:variants"`
+		CategoryName   *string                    `gorm:"column:category_name"`
 	}
 
 	err = config.CmsGorm.WithContext(ctx).Raw(query, productID).Scan(&result).Error
@@ -82,15 +109,130 @@ func GetStorefrontProductByID(c *gin.Context) {
 		return
 	}
 
+	// Group prices for logged-in customers in a customer group
+	pricingService := services.GetCustomerPricingService()
+	group := customerGroupFromContext(c)
+	listPrices, err := pricingService.ListPrices(ctx, group, []uuid.UUID{productID})
+	if err != nil {
+		log.Printf("[store.products] failed to look up group prices of %s: %v", productID, err)
+	}
+	tiers, err := pricingService.Tiers(ctx, group, []uuid.UUID{productID})
+	if err != nil {
+		log.Printf("[store.products] failed to look up quantity tiers of %s: %v", productID, err)
+	}
+
+	// Stock that has arrived for earlier backorders isn't for sale
+	outstanding, err := services.GetBackorderService().Outstanding(ctx, []uuid.UUID{productID})
+	if err != nil {
+		log.Printf("[store.products] failed to look up backorders of %s: %v", productID, err)
+	}
+	held := outstanding[productID]
+
+	// Prices as of now: combos show the price they sell for, never upcoming sales
+	pricing := &models.Product{
+		Price:          result.Price,
+		CompareAtPrice: result.CompareAtPrice,
+		SalePrice:      result.SalePrice,
+		SaleStartsAt:   result.SaleStartsAt,
+		SaleEndsAt:     result.SaleEndsAt,
+		Inventory:      result.Inventory,
+		Backorder:      result.Backorder,
+	}
+	if listPrice, ok := listPrices[productID]; ok {
+		pricing = pricing.WithListPrice(&listPrice)
+	}
+	now := time.Now()
+	originalPrice := pricing.OriginalPrice()
+	inventory := make(models.InventoryList, len(result.Inventory))
+	for i, item := range result.Inventory {
+		item.SalePrice, item.SaleStartsAt, item.SaleEndsAt = nil, nil, nil
+		if price := pricing.PriceAt(&result.Inventory[i], now); price < originalPrice {
+			item.SalePrice = &price
+			item.SaleEndsAt = pricing.SaleEndAt(&result.Inventory[i], now)
+		}
+		heldForBackorders := held[strings.ToLower(item.SKU)]
+		item.Quantity = max(item.Quantity-heldForBackorders, 0)
+		item.Backorder = nil
+		if policy := pricing.BackorderFor(&result.Inventory[i]); policy.Allowed() {
+			if policy.Limit > 0 {
+				available := max(policy.Limit-heldForBackorders, 0)
+				policy.Available = &available
+			}
+			item.Backorder = &policy
+		}
+		inventory[i] = item
+	}
+	inventoryJSON, err := json.Marshal(inventory)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to serialize product inventory"))
+		return
+	}
+
+	price := pricing.FromPriceAt(now)
 	product := models.StorefrontProduct{
-		ID:          result.ID,
-		Name:        result.Name,
-		Description: result.Description,
-		Price:       result.Price,
-		Inventory:   result.Inventory,
-		Variants:    result.Variants,
-		Media:       mediaJSON, // ✅ now RawMessage
+		ID:              result.ID,
+		Name:            result.Name,
+		Description:     result.Description,
+		Price:           price,
+		OriginalPrice:   originalPrice,
+		DiscountPercent: models.DiscountPercent(originalPrice, price),
+		OnSale:          price < originalPrice,
+		ProductType:     result.ProductType,
+		RatingAverage:   result.RatingAverage,
+		RatingCount:     result.RatingCount,
+		SaleEndsAt:      pricing.SaleEndAt(nil, now),
+		Inventory:       inventoryJSON,
+		Variants:        result.Variants,
+		Media:           mediaJSON, // ✅ now RawMessage
+	}
+	if product.OnSale {
+		product.SalePrice = &price
+	}
+	if result.ProductType == models.ProductTypeBundle {
+		bundle, err := services.GetBundleService().Details(ctx, result.Components)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to load bundle components"))
+			return
+		}
+		product.Bundle = bundle
 	}
+	if !result.QuantityRules.IsZero() {
+		product.QuantityRules = &result.QuantityRules
+		if userID, ok := customerFromContext(c); ok {
+			remaining, err := services.GetQuantityRuleService().Remaining(ctx, userID, productID, result.QuantityRules)
+			if err != nil {
+				log.Printf("[store.products] failed to look up purchases of %s by %s: %v", productID, userID, err)
+			}
+			product.RemainingLimit = remaining
+		}
+	}
+	// Tiers come lowest threshold first; skip any that a lower threshold already beats
+	bestDiscount := 0.0
+	for _, tier := range tiers {
+		if tier.DiscountPercent > bestDiscount {
+			bestDiscount = tier.DiscountPercent
+			product.QuantityTiers = append(product.QuantityTiers, models.StorefrontQuantityTier{
+				MinQuantity:     tier.MinQuantity,
+				DiscountPercent: tier.DiscountPercent,
+				UnitPrice:       models.ApplyDiscount(price, tier.DiscountPercent),
+			})
+		}
+	}
+	if price < pricing.Price { // A sale applies, not just a compare-at price
+		lowest, err := services.GetPriceHistoryService().LowestPriceBeforeSale(ctx, productID, now)
+		if err != nil {
+			log.Printf("[store.products] failed to look up lowest price of %s: %v", productID, err)
+		}
+		product.LowestPrice30d = lowest
+	}
+	questions, questionCount, err := services.GetQuestionService().Answered(ctx, productID, productPageQuestions, 0)
+	if err != nil {
+		log.Printf("[store.products] failed to fetch questions of %s: %v", productID, err)
+		questions = make([]models.StorefrontQuestion, 0)
+	}
+	product.Questions = questions
+	product.QuestionCount = questionCount
+
 	// Optional: Increment view count
 	go incrementProductViews(productID)
 
@@ -0,0 +1,419 @@
+package product_controller
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// priceBucketEdges are the bounds of the price facet's buckets; the last has no upper bound
+var priceBucketEdges = []float64{0, 25, 50, 100, 200, 500}
+
+const facetTagLimit = 30 // Most used tags in the tag facet (selected ones come first)
+
+// facetQueryWorkers bounds the facet queries of a request that run at once, leaving
+// connections of the CMS pool (5) to the rest of the storefront
+const facetQueryWorkers = 3
+
+// GetStorefrontProductFacets godoc
+// @Summary Get storefront product facets
+// @Description Takes the same filters as GET /store/products and returns every facet of the listing (category, subcategory, each variant type, availability, tags and price buckets) with its options counted under the other active filters, so options that would find nothing can be hidden. Options in a facet are counted as if that facet had nothing selected; counts of 0 are dead ends. Variant facets say which listing parameter their values go in (size, color, or option as Type:Value).
+// @Tags Storefront - Products
+// @Produce json
+// @Param q query string false "Search query"
+// @Param category query []string false "Parent category names (repeatable)"
+// @Param subcategory query []string false "Subcategory IDs (repeatable)"
+// @Param style query string false "Style filter (subcategory name)"
+// @Param size query []string false "Sizes (repeatable)"
+// @Param color query []string false "Colours (repeatable)"
+// @Param option query []string false "Other variant options as Type:Value, e.g. Material:Cotton (repeatable; up to 4 variant types, size and color included)"
+// @Param tag query []string false "Tags (repeatable)"
+// @Param availability query string false "Availability filter (in_stock | out_of_stock)"
+// @Param minPrice query number false "Minimum price (sale prices included)"
+// @Param maxPrice query number false "Maximum price (sale prices included)"
+// @Param on_sale query bool false "Only products with an active sale or compare-at discount"
+// @Param min_rating query number false "Minimum average rating (1-5)"
+// @Success 200 {object} models.ApiResponse{data=models.ProductFacets}
+// @Failure 500 {object} models.ApiResponse
+// @Router /store/products/facets [get]
+func GetStorefrontProductFacets(c *gin.Context) {
+	// Logged-in customers in a group see (and filter on) their group's prices
+	group := customerGroupFromContext(c)
+	filters := buildStorefrontFilters(c, group)
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+	db := config.CmsGorm.WithContext(ctx)
+
+	// Run the facet queries concurrently, a few at a time; each writes its own result
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	workers := make(chan struct{}, facetQueryWorkers)
+	run := func(name string, count func(db *gorm.DB) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			if err := models.RunSearch(db, filters.search != nil, count); err != nil {
+				log.Printf("[store.facets] failed to count %s: %v", name, err)
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	result := models.ProductFacets{}
+	var categories, subcategories, availability, tags models.ProductFacet
+	variants := make(map[string]models.ProductFacet)
+
+	// Step 1: Products matching every filter
+	run("total", func(db *gorm.DB) error {
+		matching, args := filters.matchingSQL("", group)
+		var total int64
+		if err := db.Raw(matching+` SELECT COUNT(*) FROM matching`, args...).Scan(&total).Error; err != nil {
+			return err
+		}
+		result.Total = int(total)
+		return nil
+	})
+
+	// Step 2: Each facet under the filters outside it
+	run("categories", func(db *gorm.DB) (err error) {
+		categories, err = countCategoryFacet(db, filters, group)
+		return err
+	})
+	run("subcategories", func(db *gorm.DB) (err error) {
+		subcategories, err = countSubcategoryFacet(db, filters, group)
+		return err
+	})
+	run("availability", func(db *gorm.DB) (err error) {
+		availability, err = countAvailabilityFacet(db, filters, group)
+		return err
+	})
+	run("tags", func(db *gorm.DB) (err error) {
+		tags, err = countTagFacet(db, filters, group)
+		return err
+	})
+	run("price", func(db *gorm.DB) (err error) {
+		result.Price, err = countPriceFacet(db, filters, group)
+		return err
+	})
+
+	// Variant types without a selection share the listing's filters; each selected type
+	// is counted without its own
+	addVariants := func(facets []models.ProductFacet) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, facet := range facets {
+			variants[facet.Name] = facet
+		}
+	}
+	run("variants", func(db *gorm.DB) error {
+		facets, err := countVariantFacets(db, filters, group, "")
+		addVariants(facets)
+		return err
+	})
+	for _, variantType := range filters.variantTypes {
+		variantType := variantType
+		run(variantType, func(db *gorm.DB) error {
+			facets, err := countVariantFacets(db, filters, group, variantType)
+			addVariants(facets)
+			return err
+		})
+	}
+
+	wg.Wait()
+	if len(errs) > 0 {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch product facets"))
+		return
+	}
+
+	// Step 3: Facets in display order (sizes and colours before other variant types)
+	result.Facets = []models.ProductFacet{categories, subcategories}
+	variantNames := make([]string, 0, len(variants))
+	for name := range variants {
+		variantNames = append(variantNames, name)
+	}
+	sort.Slice(variantNames, func(i, j int) bool {
+		rank := func(name string) int {
+			switch name {
+			case "Size":
+				return 0
+			case "Color":
+				return 1
+			}
+			return 2
+		}
+		if rank(variantNames[i]) != rank(variantNames[j]) {
+			return rank(variantNames[i]) < rank(variantNames[j])
+		}
+		return variantNames[i] < variantNames[j]
+	})
+	for _, name := range variantNames {
+		result.Facets = append(result.Facets, variants[name])
+	}
+	result.Facets = append(result.Facets, availability, tags)
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Product facets fetched successfully", result))
+}
+
+// matchingSQL is a WITH clause of the active products matching the filters outside a
+// facet (none: ""), as matching (id, sub_category_id, price)
+func (f *storefrontFilters) matchingSQL(excludeFacet string, group *uuid.UUID) (string, []interface{}) {
+	where, args := f.where(excludeFacet)
+	searchJoin := ""
+	if f.search != nil {
+		searchJoin = models.SearchJoinSQL
+		args = append([]interface{}{f.search.Expanded, f.search.Query}, args...)
+	}
+	return fmt.Sprintf(`
+		WITH matching AS (
+			SELECT DISTINCT p.id, p.sub_category_id, %s AS price
+			FROM products p %s
+			WHERE p.deleted_at IS NULL AND (%s)
+		)`, models.EffectivePriceSQLFor(group), searchJoin, where), args
+}
+
+// isSelected reports whether a facet's value is selected (case-insensitively)
+func (f *storefrontFilters) isSelected(facet, value string) bool {
+	for _, selected := range f.selected[facet] {
+		if strings.EqualFold(selected, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// countCategoryFacet counts the products in each active parent category
+func countCategoryFacet(db *gorm.DB, filters *storefrontFilters, group *uuid.UUID) (models.ProductFacet, error) {
+	facet := models.ProductFacet{Name: facetCategory, Param: "category", Options: []models.ProductFacetOption{}}
+	matching, args := filters.matchingSQL(facetCategory, group)
+	if err := db.Raw(matching+`
+		SELECT parent.name AS label, parent.name AS value, COUNT(DISTINCT m.id) AS count
+		FROM categories parent
+		LEFT JOIN categories sub ON sub.parent_id = parent.id
+		LEFT JOIN matching m ON m.sub_category_id = sub.id
+		WHERE parent.parent_id IS NULL AND parent.status = 'Active'
+		GROUP BY parent.id, parent.name
+		ORDER BY parent.name
+	`, args...).Scan(&facet.Options).Error; err != nil {
+		return facet, err
+	}
+	for i := range facet.Options {
+		facet.Options[i].Selected = filters.isSelected(facetCategory, facet.Options[i].Value)
+	}
+	return facet, nil
+}
+
+// countSubcategoryFacet counts the products in each active subcategory
+func countSubcategoryFacet(db *gorm.DB, filters *storefrontFilters, group *uuid.UUID) (models.ProductFacet, error) {
+	facet := models.ProductFacet{Name: facetSubcategory, Param: "subcategory", Options: []models.ProductFacetOption{}}
+	matching, args := filters.matchingSQL(facetSubcategory, group)
+	if err := db.Raw(matching+`
+		SELECT sub.name AS label, sub.id::text AS value, parent.name AS "group", COUNT(DISTINCT m.id) AS count
+		FROM categories sub
+		JOIN categories parent ON parent.id = sub.parent_id AND parent.status = 'Active'
+		LEFT JOIN matching m ON m.sub_category_id = sub.id
+		WHERE sub.status = 'Active'
+		GROUP BY sub.id, sub.name, parent.name
+		ORDER BY parent.name, sub.name
+	`, args...).Scan(&facet.Options).Error; err != nil {
+		return facet, err
+	}
+	for i := range facet.Options {
+		facet.Options[i].Selected = filters.isSelected(facetSubcategory, facet.Options[i].Value)
+	}
+	return facet, nil
+}
+
+// countVariantFacets counts the products with each option of the variant types: the one
+// given (counted without its own filter), or all those without a selection ("")
+func countVariantFacets(db *gorm.DB, filters *storefrontFilters, group *uuid.UUID, variantType string) ([]models.ProductFacet, error) {
+	exclude := ""
+	typeCondition := "TRUE"
+	var typeArgs []interface{}
+	if variantType != "" {
+		exclude = variantFacet(variantType)
+		typeCondition = "variant->>'type' = ?"
+		typeArgs = append(typeArgs, variantType)
+	} else if len(filters.variantTypes) > 0 {
+		typeCondition = fmt.Sprintf("variant->>'type' NOT IN (%s)", placeholders(len(filters.variantTypes), "?"))
+		for _, selected := range filters.variantTypes {
+			typeArgs = append(typeArgs, selected)
+		}
+	}
+	matching, args := filters.matchingSQL(exclude, group)
+
+	var rows []struct {
+		Type  string
+		Value string
+		Count int
+	}
+	// Options keep the order products list them in (e.g. S, M, L)
+	if err := db.Raw(matching+fmt.Sprintf(`
+		SELECT variant->>'type' AS type, o.value, COUNT(DISTINCT m.id) AS count
+		FROM products p
+		CROSS JOIN jsonb_array_elements(p.variants) AS variant
+		CROSS JOIN jsonb_array_elements_text(variant->'options') WITH ORDINALITY AS o(value, position)
+		LEFT JOIN matching m ON m.id = p.id
+		WHERE p.deleted_at IS NULL AND p.status = 'Active' AND %s
+		GROUP BY variant->>'type', o.value
+		ORDER BY variant->>'type', MIN(o.position), o.value
+	`, typeCondition), append(args, typeArgs...)...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	facets := make([]models.ProductFacet, 0)
+	index := make(map[string]int)
+	for _, row := range rows {
+		i, ok := index[row.Type]
+		if !ok {
+			facet := models.ProductFacet{Name: row.Type, Param: "option", Options: []models.ProductFacetOption{}}
+			switch row.Type {
+			case "Size":
+				facet.Param = "size"
+			case "Color":
+				facet.Param = "color"
+			}
+			i = len(facets)
+			index[row.Type] = i
+			facets = append(facets, facet)
+		}
+
+		value := row.Value
+		if facets[i].Param == "option" {
+			value = row.Type + ":" + row.Value
+		}
+		selected := false
+		for _, option := range filters.selected[variantFacet(row.Type)] {
+			if option == row.Value { // Variant filters match exactly
+				selected = true
+			}
+		}
+		facets[i].Options = append(facets[i].Options, models.ProductFacetOption{
+			Label:    row.Value,
+			Value:    value,
+			Count:    row.Count,
+			Selected: selected,
+		})
+	}
+	return facets, nil
+}
+
+// countAvailabilityFacet counts the products in and out of stock
+func countAvailabilityFacet(db *gorm.DB, filters *storefrontFilters, group *uuid.UUID) (models.ProductFacet, error) {
+	facet := models.ProductFacet{Name: facetAvailability, Param: "availability"}
+	matching, args := filters.matchingSQL(facetAvailability, group)
+	var counts struct {
+		InStock    int
+		OutOfStock int
+	}
+	if err := db.Raw(matching+fmt.Sprintf(`
+		SELECT
+			COUNT(*) FILTER (WHERE in_stock) AS in_stock,
+			COUNT(*) FILTER (WHERE NOT in_stock) AS out_of_stock
+		FROM (
+			SELECT %s AS in_stock
+			FROM products p
+			JOIN matching m ON m.id = p.id
+		) s
+	`, models.InStockSQL), args...).Scan(&counts).Error; err != nil {
+		return facet, err
+	}
+	facet.Options = []models.ProductFacetOption{
+		{Label: "In Stock", Value: "in_stock", Count: counts.InStock, Selected: filters.isSelected(facetAvailability, "in_stock")},
+		{Label: "Out of Stock", Value: "out_of_stock", Count: counts.OutOfStock, Selected: filters.isSelected(facetAvailability, "out_of_stock")},
+	}
+	return facet, nil
+}
+
+// countTagFacet counts the products with the most used tags, selected tags first
+func countTagFacet(db *gorm.DB, filters *storefrontFilters, group *uuid.UUID) (models.ProductFacet, error) {
+	facet := models.ProductFacet{Name: facetTag, Param: "tag", Options: []models.ProductFacetOption{}}
+	matching, args := filters.matchingSQL(facetTag, group)
+
+	selectedFirst := ""
+	if selected := filters.selected[facetTag]; len(selected) > 0 {
+		selectedFirst = fmt.Sprintf("LOWER(tag) IN (%s) DESC,", placeholders(len(selected), "LOWER(?)"))
+		for _, tag := range selected {
+			args = append(args, tag)
+		}
+	}
+	if err := db.Raw(matching+fmt.Sprintf(`
+		SELECT MIN(tag) AS label, LOWER(tag) AS value, COUNT(DISTINCT m.id) AS count
+		FROM products p
+		CROSS JOIN jsonb_array_elements_text(p.tags) AS tag
+		LEFT JOIN matching m ON m.id = p.id
+		WHERE p.deleted_at IS NULL AND p.status = 'Active'
+		GROUP BY LOWER(tag)
+		ORDER BY %s COUNT(DISTINCT m.id) DESC, LOWER(tag)
+		LIMIT ?
+	`, selectedFirst), append(args, facetTagLimit)...).Scan(&facet.Options).Error; err != nil {
+		return facet, err
+	}
+	for i := range facet.Options {
+		facet.Options[i].Selected = filters.isSelected(facetTag, facet.Options[i].Value)
+	}
+	return facet, nil
+}
+
+// countPriceFacet finds the price range and counts the products in each price bucket
+func countPriceFacet(db *gorm.DB, filters *storefrontFilters, group *uuid.UUID) (models.PriceFacet, error) {
+	facet := models.PriceFacet{Buckets: make([]models.PriceBucket, len(priceBucketEdges))}
+	matching, args := filters.matchingSQL(facetPrice, group)
+
+	// Buckets are inclusive at both ends, as minPrice and maxPrice filter
+	columns := make([]string, len(priceBucketEdges))
+	for i, lower := range priceBucketEdges {
+		bucket := &facet.Buckets[i]
+		bucket.Min = lower
+		bucket.Label = strconv.FormatFloat(lower, 'f', -1, 64) + "+"
+		columns[i] = fmt.Sprintf("COUNT(*) FILTER (WHERE price >= %g)", lower)
+		if i+1 < len(priceBucketEdges) {
+			upper := priceBucketEdges[i+1]
+			bucket.Max = &upper
+			bucket.Label = fmt.Sprintf("%s - %s", strconv.FormatFloat(lower, 'f', -1, 64), strconv.FormatFloat(upper, 'f', -1, 64))
+			columns[i] = fmt.Sprintf("COUNT(*) FILTER (WHERE price >= %g AND price <= %g)", lower, upper)
+		}
+	}
+
+	dest := []interface{}{&facet.Range.Min, &facet.Range.Max}
+	counts := make([]int, len(priceBucketEdges))
+	for i := range counts {
+		dest = append(dest, &counts[i])
+	}
+	if err := db.Raw(matching+fmt.Sprintf(`
+		SELECT COALESCE(MIN(price), 0)::float8, COALESCE(MAX(price), 0)::float8, %s
+		FROM matching
+	`, strings.Join(columns, ", ")), args...).Row().Scan(dest...); err != nil {
+		return facet, err
+	}
+
+	minPrice := 0.0
+	if filters.minPrice != nil {
+		minPrice = *filters.minPrice
+	}
+	for i := range facet.Buckets {
+		bucket := &facet.Buckets[i]
+		bucket.Count = counts[i]
+		if bucket.Max == nil {
+			bucket.Selected = filters.minPrice != nil && minPrice == bucket.Min && filters.maxPrice == nil
+		} else {
+			bucket.Selected = minPrice == bucket.Min && filters.maxPrice != nil && *filters.maxPrice == *bucket.Max
+		}
+	}
+	return facet, nil
+}
@@ -0,0 +1,116 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetStorefrontProductRecommendations godoc
+// @Summary Get recommendations for a product
+// @Description Products to show alongside a product: its curated related products first, in the order set in the CMS, then products frequently bought together with it. Inactive and out-of-stock products are left out. source says where each one came from. Logged-in customers in a customer group get their group's prices.
+// @Tags store
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param limit query int false "How many to return (default: 8, max: 20)"
+// @Success 200 {object} models.ApiResponse{data=[]models.StorefrontRecommendation}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 500 {object} models.ApiResponse
+// @Router /store/products/{id}/recommendations [get]
+func GetStorefrontProductRecommendations(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	limit := 8
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			if parsed > 20 {
+				parsed = 20
+			}
+			limit = parsed
+		}
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	var count int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ? AND status = ?", productID, models.ProductStatusActive).
+		Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if count == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	candidates, err := services.GetRecommendationService().Candidates(ctx, productID)
+	if err != nil {
+		log.Printf("[store.products] failed to fetch recommendation candidates for %s: %v", productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch recommendations"))
+		return
+	}
+
+	recommendations := make([]models.StorefrontRecommendation, 0, limit)
+	if len(candidates) == 0 {
+		c.JSON(http.StatusOK, models.SuccessResponse(c, "Recommendations fetched successfully", recommendations))
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.ProductID)
+	}
+
+	// Only what can be bought right now, priced for the customer
+	products, _, err := fetchStorefrontProductsFromDB(
+		c,
+		"p.id IN ? AND p.status = 'Active' AND "+models.InStockSQL,
+		"p.created_at DESC",
+		[]interface{}{ids},
+		nil,
+		1,
+		len(ids),
+		customerGroupFromContext(c),
+	)
+	if err != nil {
+		log.Printf("[store.products] failed to fetch recommendations for %s: %v", productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch recommendations"))
+		return
+	}
+
+	byID := make(map[string]models.StorefrontProductResponse, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	// Keep the candidates' order: curated first, then bought together
+	for _, candidate := range candidates {
+		product, ok := byID[candidate.ProductID.String()]
+		if !ok {
+			continue
+		}
+		recommendations = append(recommendations, models.StorefrontRecommendation{
+			StorefrontProductResponse: product,
+			Source:                    candidate.Source,
+		})
+		if len(recommendations) == limit {
+			break
+		}
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Recommendations fetched successfully", recommendations))
+}
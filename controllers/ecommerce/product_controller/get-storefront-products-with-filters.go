@@ -1,35 +1,43 @@
 package product_controller
 
 import (
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // GetStorefrontProducts godoc
 // @Summary Get storefront products with filters
-// @Description Retrieve active storefront products with optional search, category, subcategory, size, colour, availability, price range, and sorting filters.
+// @Description Retrieve active storefront products with optional search, category, subcategory, size, colour, variant option, tag, availability, price range, and sorting filters.
 // @Tags Storefront - Products
 // @Produce json
-// @Param q query string false "Search query (name or description)"
+// @Param q query string false "Search query: full text over name, tags, category and description, typo-tolerant on names, with the CMS search rules applied (synonyms, pinned and buried products); results come with highlight. A search with a redirect rule sets the X-Search-Redirect header. The first page of a search sets X-Search-Id, for POST /store/search/{id}/click."
 // @Param category query []string false "Parent category names (repeatable)"
 // @Param subcategory query []string false "Subcategory IDs (repeatable)"
 // @Param style query string false "Style filter (subcategory name)"
 // @Param size query []string false "Sizes (repeatable)"
 // @Param color query []string false "Colours (repeatable)"
+// @Param option query []string false "Other variant options as Type:Value, e.g. Material:Cotton (repeatable; up to 4 variant types, size and color included)"
+// @Param tag query []string false "Tags (repeatable)"
 // @Param availability query string false "Availability filter (in_stock | out_of_stock)"
-// @Param minPrice query number false "Minimum price"
-// @Param maxPrice query number false "Maximum price"
-// @Param sortBy query string false "Sort by field (newest, price, etc.)" default(newest)
+// @Param minPrice query number false "Minimum price (sale prices included)"
+// @Param maxPrice query number false "Maximum price (sale prices included)"
+// @Param on_sale query bool false "Only products with an active sale or compare-at discount"
+// @Param min_rating query number false "Minimum average rating (1-5)"
+// @Param sortBy query string false "Sort by field (relevance, newest, price, name, discount, rating); relevance is the default when searching" default(newest)
 // @Param sortOrder query string false "Sort order (asc | desc)" default(desc)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(12)
+// @Param cursor query string false "Cursor pagination instead of page: empty for the first page, then meta.next_cursor (not with relevance, price or discount sort)"
+// @Param total query string false "With cursor: exact for a counted total instead of an estimate" Enums(exact)
 // @Success 200 {object} models.ApiResponse "Products with filters fetched successfully"
+// @Failure 400 {object} models.ApiResponse "Invalid cursor, or a sort without cursor pagination"
 // @Failure 500 {object} models.ApiResponse "Internal server error"
 // @Router /store/products [get]
 func getStorefrontProductsWithFilters(c *gin.Context) {
@@ -38,186 +46,102 @@ func getStorefrontProductsWithFilters(c *gin.Context) {
 	log.Printf("=== FILTER DEBUG START ===")
 	log.Printf("Page: %d, Limit: %d", page, limit)
 
-	// Parse filters
-	searchQuery := c.Query("q")
-	categoryNames := c.QueryArray("category")
-	subcategoryIDs := c.QueryArray("subcategory")
-	style := c.Query("style")
-	sizes := c.QueryArray("size")
-	colors := c.QueryArray("color")
-	availability := c.Query("availability")
-	minPriceStr := c.Query("minPrice")
-	maxPriceStr := c.Query("maxPrice")
 	sortBy := c.DefaultQuery("sortBy", "newest")
 	sortOrder := c.DefaultQuery("sortOrder", "desc")
+	searchQuery := strings.TrimSpace(c.Query("q"))
+	if searchQuery != "" && c.Query("sortBy") == "" {
+		sortBy = "relevance" // Best matches first
+	}
 
-	conditions := []string{"p.status = 'Active'"}
-	args := []interface{}{}
+	// Logged-in customers in a group see (and filter on) their group's prices
+	group := customerGroupFromContext(c)
 
-	// Search query (name or description)
-	if searchQuery != "" {
-		conditions = append(conditions, "(p.name ILIKE ? OR p.description ILIKE ?)")
-		args = append(args, "%"+searchQuery+"%", "%"+searchQuery+"%")
-		log.Printf("Added search condition")
-	}
+	// Parse filters (shared with the listing's facets)
+	filters := buildStorefrontFilters(c, group)
+	search := filters.search
 
-	// Style filter (subcategory name match)
-	if style != "" {
-		cond := `p.sub_category_id IN (
-			SELECT id FROM categories 
-			WHERE LOWER(name) = LOWER(?) AND parent_id IS NOT NULL
-		)`
-		conditions = append(conditions, cond)
-		args = append(args, strings.TrimSpace(style))
-		log.Printf("Added style filter: %s", style)
+	// Merchandised searches send the customer elsewhere; the results still come back
+	if search != nil && search.RedirectURL != nil {
+		c.Header("X-Search-Redirect", *search.RedirectURL)
 	}
 
-	// Category filter (parent categories by NAME - multiple)
-	if len(categoryNames) > 0 {
-		// Build LOWER() placeholders for case-insensitive matching
-		lowerPlaceholders := make([]string, len(categoryNames))
-		for i, name := range categoryNames {
-			lowerPlaceholders[i] = "LOWER(?)"
-			args = append(args, strings.TrimSpace(name))
+	whereClause, args := filters.where("")
+
+	// Cursor pagination: no deep OFFSETs, and new products don't shift the pages
+	var products []models.StorefrontProductResponse
+	var meta *models.Pagination
+	request := models.CursorRequestFromQuery(c)
+	if request != nil {
+		keyset, ok := storefrontKeyset(sortBy, sortOrder, search)
+		if !ok {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Cursor pagination isn't available when sorting by relevance, price or discount; use page"))
+			return
 		}
-		// Find parent category IDs by name, then find all subcategories under those parents
-		cond := fmt.Sprintf(
-			`p.sub_category_id IN (
-				SELECT id FROM categories 
-				WHERE parent_id IN (
-					SELECT id FROM categories 
-					WHERE LOWER(name) IN (%s) AND parent_id IS NULL
-				)
-			)`,
-			strings.Join(lowerPlaceholders, ","),
-		)
-		conditions = append(conditions, cond)
-		log.Printf("Added category filter by names: %v", categoryNames)
-	}
-
-	// Subcategory filter (multiple subcategory IDs)
-	if len(subcategoryIDs) > 0 {
-		placeholders := make([]string, len(subcategoryIDs))
-		for i := range subcategoryIDs {
-			placeholders[i] = "?"
-			args = append(args, subcategoryIDs[i])
+		var err error
+		products, meta, err = fetchStorefrontProductsByCursor(c, whereClause, args, search, keyset, request, limit, group)
+		if errors.Is(err, models.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid cursor"))
+			return
 		}
-		cond := fmt.Sprintf(
-			"p.sub_category_id IN (%s)",
-			strings.Join(placeholders, ","),
-		)
-		conditions = append(conditions, cond)
-		log.Printf("Added subcategory filter: %v", subcategoryIDs)
-	}
-
-	// Size filter
-	if len(sizes) > 0 {
-		log.Printf("Building size filter for: %v", sizes)
-		sizePlaceholders := make([]string, len(sizes))
-		for i, size := range sizes {
-			sizePlaceholders[i] = "?"
-			args = append(args, strings.TrimSpace(size))
+		if err != nil {
+			log.Printf("ERROR in fetchStorefrontProductsByCursor: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch products"))
+			return
 		}
-		cond := fmt.Sprintf(
-			`EXISTS (
-				SELECT 1 
-				FROM jsonb_array_elements(p.variants) AS variant,
-				     jsonb_array_elements_text(variant->'options') AS size_option
-				WHERE variant->>'type' = 'Size' 
-				  AND size_option IN (%s)
-			)`,
-			strings.Join(sizePlaceholders, ","),
+	} else {
+		orderClause := buildStorefrontOrderClause(sortBy, sortOrder, group, search)
+		var totalCount int
+		var err error
+		products, totalCount, err = fetchStorefrontProductsFromDB(
+			c,
+			whereClause,
+			orderClause,
+			args,
+			search,
+			page,
+			limit,
+			group,
 		)
-		conditions = append(conditions, cond)
-		log.Printf("Added size filter")
-	}
-
-	// Color filter
-	if len(colors) > 0 {
-		log.Printf("Building color filter for: %v", colors)
-		colorPlaceholders := make([]string, len(colors))
-		for i, color := range colors {
-			colorPlaceholders[i] = "?"
-			args = append(args, strings.TrimSpace(color))
+		if err != nil {
+			log.Printf("ERROR in fetchStorefrontProductsFromDB: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch products"))
+			return
+		}
+		meta = &models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      totalCount,
+			TotalPages: (totalCount + limit - 1) / limit,
 		}
-		cond := fmt.Sprintf(
-			`EXISTS (
-				SELECT 1 
-				FROM jsonb_array_elements(p.variants) AS variant,
-				     jsonb_array_elements_text(variant->'options') AS color_option
-				WHERE variant->>'type' = 'Color' 
-				  AND color_option IN (%s)
-			)`,
-			strings.Join(colorPlaceholders, ","),
-		)
-		conditions = append(conditions, cond)
-		log.Printf("Added color filter")
 	}
 
-	// Availability filter
-	switch availability {
-	case "in_stock", "inStock":
-		cond := `EXISTS (
-			SELECT 1 
-			FROM jsonb_array_elements(p.inventory) AS item
-			WHERE (item->>'quantity')::int > 0
-		)`
-		conditions = append(conditions, cond)
-		log.Printf("Added availability condition: in_stock")
-	case "out_of_stock", "outOfStock":
-		cond := `NOT EXISTS (
-			SELECT 1 
-			FROM jsonb_array_elements(p.inventory) AS item
-			WHERE (item->>'quantity')::int > 0
-		)`
-		conditions = append(conditions, cond)
-		log.Printf("Added availability condition: out_of_stock")
+	// Searches are logged once (on their first page) for the search analytics; the ID lets
+	// the storefront report clicks on the results. Those that find something also feed the
+	// popular search suggestions.
+	firstPage := page == 1
+	if request != nil {
+		firstPage = request.Cursor == ""
 	}
-
-	// Price range filter
-	if minPriceStr != "" {
-		if minPrice, err := strconv.ParseFloat(minPriceStr, 64); err == nil {
-			conditions = append(conditions, "p.price >= ?")
-			args = append(args, minPrice)
-			log.Printf("Added minPrice condition = %.2f", minPrice)
+	if searchQuery != "" && firstPage {
+		searchID := services.GetSearchAnalyticsService().LogSearch(
+			searchQuery,
+			models.SearchSourceSearch,
+			meta.Total,
+			searchFiltersFromQuery(c),
+			customerIDFromContext(c),
+		)
+		if searchID != uuid.Nil {
+			c.Header("X-Search-Id", searchID.String())
 		}
-	}
-	if maxPriceStr != "" {
-		if maxPrice, err := strconv.ParseFloat(maxPriceStr, 64); err == nil {
-			conditions = append(conditions, "p.price <= ?")
-			args = append(args, maxPrice)
-			log.Printf("Added maxPrice condition = %.2f", maxPrice)
+		if meta.Total > 0 {
+			services.GetSearchSuggestService().RecordSearch(searchQuery)
 		}
 	}
 
-	whereClause := strings.Join(conditions, " AND ")
-	orderClause := buildStorefrontOrderClause(sortBy, sortOrder)
-
-	products, totalCount, err := fetchStorefrontProductsFromDB(
-		c,
-		whereClause,
-		orderClause,
-		args,
-		page,
-		limit,
-	)
-	if err != nil {
-		log.Printf("ERROR in fetchStorefrontProductsFromDB: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch products"))
-		return
-	}
-
-	totalPages := (totalCount + limit - 1) / limit
-
 	c.JSON(http.StatusOK, models.PaginatedResponse(
 		c,
 		"Products with filters fetched successfully",
 		products,
-		&models.Pagination{
-			Page:       page,
-			Limit:      limit,
-			Total:      totalCount,
-			TotalPages: totalPages,
-		},
+		meta,
 	))
 }
@@ -1,6 +1,7 @@
 package product_controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
@@ -12,11 +13,14 @@ import (
 // @Description Retrieve active storefront products using only pagination and sorting (no category, size, colour, or price filters).
 // @Tags store
 // @Produce json
-// @Param sortBy query string false "Sort by field (newest, price, etc.)" default(newest)
+// @Param sortBy query string false "Sort by field (newest, price, name, discount, rating)" default(newest)
 // @Param sortOrder query string false "Sort order (asc | desc)" default(desc)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(12)
+// @Param cursor query string false "Cursor pagination instead of page: empty for the first page, then meta.next_cursor (not with price or discount sort)"
+// @Param total query string false "With cursor: exact for a counted total instead of an estimate" Enums(exact)
 // @Success 200 {object} models.ApiResponse "Products fetched successfully"
+// @Failure 400 {object} models.ApiResponse "Invalid cursor, or a sort without cursor pagination"
 // @Failure 500 {object} models.ApiResponse "Internal server error"
 // @Router /store/products/basic [get]
 func getStorefrontProductsWithoutFilters(c *gin.Context) {
@@ -24,16 +28,41 @@ func getStorefrontProductsWithoutFilters(c *gin.Context) {
 	sortBy := c.DefaultQuery("sortBy", "newest")
 	sortOrder := c.DefaultQuery("sortOrder", "desc")
 
+	group := customerGroupFromContext(c)
+
 	whereClause := "p.status = 'Active'"
-	orderClause := buildStorefrontOrderClause(sortBy, sortOrder)
+
+	// Cursor pagination: no deep OFFSETs, and new products don't shift the pages
+	if request := models.CursorRequestFromQuery(c); request != nil {
+		keyset, ok := storefrontKeyset(sortBy, sortOrder, nil)
+		if !ok {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Cursor pagination isn't available when sorting by price or discount; use page"))
+			return
+		}
+		products, meta, err := fetchStorefrontProductsByCursor(c, whereClause, nil, nil, keyset, request, limit, group)
+		if errors.Is(err, models.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid cursor"))
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch products"))
+			return
+		}
+		c.JSON(http.StatusOK, models.PaginatedResponse(c, "Products fetched successfully", products, meta))
+		return
+	}
+
+	orderClause := buildStorefrontOrderClause(sortBy, sortOrder, group, nil)
 
 	products, totalCount, err := fetchStorefrontProductsFromDB(
 		c,
 		whereClause,
 		orderClause,
 		nil, // no filter args
+		nil, // no search
 		page,
 		limit,
+		group,
 	)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to fetch products"))
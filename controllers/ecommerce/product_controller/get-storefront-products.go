@@ -9,18 +9,23 @@ import (
 // @Description Get paginated products for storefront with optional search and filtering
 // @Tags store
 // @Produce json
-// @Param q query string false "Search query"
+// @Param q query string false "Search query (full text, typo-tolerant; results are relevance-ranked and highlighted)"
 // @Param category query []string false "Category IDs (repeatable ?category=ID&category=ID)"
 // @Param subcategory query []string false "Subcategory IDs (repeatable ?subcategory=ID&subcategory=ID)"
 // @Param size query []string false "Sizes (repeatable ?size=XS&size=S)"
 // @Param availability query string false "Availability filter" Enums(in_stock, out_of_stock, inStock, outOfStock)
 // @Param minPrice query number false "Minimum price"
 // @Param maxPrice query number false "Maximum price"
-// @Param sortBy query string false "Sort by field" Enums(price, name, newest, popular) default(newest)
+// @Param on_sale query bool false "Only products currently on sale"
+// @Param min_rating query number false "Minimum average rating (1-5)"
+// @Param sortBy query string false "Sort by field" Enums(relevance, price, name, newest, popular, discount, rating) default(newest)
 // @Param sortOrder query string false "Sort order" Enums(asc, desc) default(desc)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
+// @Param cursor query string false "Cursor (keyset) pagination instead of page: empty for the first page, then meta.next_cursor; not with relevance sort"
+// @Param total query string false "With cursor: exact for a counted total instead of the planner's estimate" Enums(exact)
 // @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
 // @Failure 500 {object} models.ApiResponse
 // @Router /store/products [get]
 func GetStorefrontProducts(c *gin.Context) {
@@ -39,9 +44,13 @@ func hasStorefrontFilters(c *gin.Context) bool {
 		len(c.QueryArray("subcategory")) > 0 ||
 		len(c.QueryArray("size")) > 0 ||
 		len(c.QueryArray("color")) > 0 ||
+		len(c.QueryArray("option")) > 0 ||
+		len(c.QueryArray("tag")) > 0 ||
 		c.Query("availability") != "" ||
 		c.Query("minPrice") != "" ||
 		c.Query("maxPrice") != "" ||
+		c.Query("on_sale") != "" ||
+		c.Query("min_rating") != "" ||
 		c.Query("style") != "" { // Add this line
 		return true
 	}
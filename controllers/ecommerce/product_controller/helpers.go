@@ -1,38 +1,152 @@
 package product_controller
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/middleware"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // ─────────────────────────────────────────────────────────────
 // Helpers
 // ─────────────────────────────────────────────────────────────
 
-// buildStorefrontOrderClause builds the ORDER BY clause shared by handlers.
-func buildStorefrontOrderClause(sortBy, sortOrder string) string {
+// customerGroupFromContext returns the logged-in customer's group, whose prices they see
+// (nil for guests and customers without one)
+func customerGroupFromContext(c *gin.Context) *uuid.UUID {
+	userID, ok := customerFromContext(c)
+	if !ok {
+		return nil
+	}
+	groupID, err := services.GetCustomerPricingService().GroupOf(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("[store.products] failed to look up customer group of %s: %v", userID, err)
+		return nil
+	}
+	return groupID
+}
+
+// customerFromContext returns the logged-in customer's ID (false for guests)
+func customerFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// customerIDFromContext returns the logged-in customer's ID, or nil for guests
+func customerIDFromContext(c *gin.Context) *uuid.UUID {
+	userID, ok := customerFromContext(c)
+	if !ok {
+		return nil
+	}
+	return &userID
+}
+
+// searchFilterParams are the listing parameters logged with a search
+var searchFilterParams = []string{
+	"category", "subcategory", "style", "size", "color", "option", "tag", "availability",
+	"minPrice", "maxPrice", "on_sale", "min_rating", "sortBy", "sortOrder",
+}
+
+// searchFiltersFromQuery returns the filters and sort a listing was asked for
+func searchFiltersFromQuery(c *gin.Context) models.SearchFilters {
+	filters := make(models.SearchFilters)
+	for _, name := range searchFilterParams {
+		if values := c.QueryArray(name); len(values) > 0 {
+			filters[name] = values
+		}
+	}
+	return filters
+}
+
+// buildStorefrontOrderClause builds the ORDER BY clause shared by handlers. Relevance
+// only applies to searches (search: the query has the search joined), with the search's
+// pinned products first and buried ones last.
+func buildStorefrontOrderClause(sortBy, sortOrder string, group *uuid.UUID, search *models.SearchPlan) string {
 	order := "DESC"
 	if strings.ToUpper(sortOrder) == "ASC" {
 		order = "ASC"
 	}
 
 	switch sortBy {
+	case "relevance":
+		if search != nil {
+			return fmt.Sprintf("%s, p.created_at DESC", search.RelevanceOrderSQL())
+		}
+		return "p.created_at DESC"
 	case "price":
-		return fmt.Sprintf("p.price %s", order)
+		// The price customers pay right now, sales and group prices included
+		return fmt.Sprintf("%s %s", models.EffectivePriceSQLFor(group), order)
+	case "discount":
+		// Biggest discount first by default (smallest price-to-original ratio)
+		ratioOrder := "ASC"
+		if order == "ASC" {
+			ratioOrder = "DESC"
+		}
+		return fmt.Sprintf("%s / NULLIF(%s, 0) %s, p.created_at DESC", models.EffectivePriceSQLFor(group), models.OriginalPriceSQLFor(group), ratioOrder)
 	case "name":
 		return fmt.Sprintf("p.name %s", order)
 	case "newest":
 		return fmt.Sprintf("p.created_at %s", order)
+	case "rating":
+		// Best rated first by default; more reviews break ties
+		return fmt.Sprintf("p.rating_average %s, p.rating_count DESC, p.created_at DESC", order)
 	default:
 		return "p.created_at DESC"
 	}
 }
 
+// storefrontKeyset is the cursor pagination order of a listing sort; ties go by product
+// ID (UUIDv7, so newest first when descending). Relevance, price and discount have none:
+// pins and ranks don't make a stable key, and a sale starting or ending between pages
+// moves a product's price (without a search relevance is newest first, as in
+// buildStorefrontOrderClause).
+func storefrontKeyset(sortBy, sortOrder string, search *models.SearchPlan) (models.Keyset, bool) {
+	desc := strings.ToUpper(sortOrder) != "ASC"
+	keyset := models.Keyset{IDSQL: "p.id", KeyType: "numeric", Desc: desc}
+
+	switch sortBy {
+	case "relevance":
+		if search != nil {
+			return keyset, false
+		}
+		sortBy = "newest"
+		keyset.KeySQL, keyset.KeyType, keyset.Desc = "p.created_at", "timestamptz", true
+	case "price", "discount":
+		return keyset, false
+	case "name":
+		keyset.KeySQL, keyset.KeyType = "p.name", "text"
+	case "rating":
+		keyset.KeySQL = "p.rating_average"
+	case "newest":
+		keyset.KeySQL, keyset.KeyType = "p.created_at", "timestamptz"
+	default:
+		sortBy = "newest"
+		keyset.KeySQL, keyset.KeyType, keyset.Desc = "p.created_at", "timestamptz", true
+	}
+
+	keyset.Sort = sortBy + ":asc"
+	if keyset.Desc {
+		keyset.Sort = sortBy + ":desc"
+	}
+	return keyset, true
+}
+
 func parsePagination(c *gin.Context) (page, limit int) {
 	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ = strconv.Atoi(c.DefaultQuery("limit", "12"))
@@ -51,57 +165,195 @@ func parsePagination(c *gin.Context) (page, limit int) {
 // Database fetcher (THIN RESPONSE)
 // ─────────────────────────────────────────────────────────────
 
+// fetchStorefrontProductsFromDB runs a product listing. With a search (nil for none), the
+// search is joined as sq (see models.SearchJoinSQL) for the where and order clauses to
+// use, and each product comes with where it matched.
 func fetchStorefrontProductsFromDB(
 	c *gin.Context,
 	whereClause string,
 	orderClause string,
 	args []interface{},
+	search *models.SearchPlan,
 	page int,
 	limit int,
+	group *uuid.UUID,
 ) ([]models.StorefrontProductResponse, int, error) {
 	ctx, cancel := config.WithTimeout()
 	defer cancel()
 
 	offset := (page - 1) * limit
 
-	// Count query
-	countQuery := fmt.Sprintf(`
-		SELECT COUNT(DISTINCT p.id)
-		FROM products p
-		WHERE %s
-	`, whereClause)
-
-	var totalCount int64
-	if err := config.CmsGorm.
-		WithContext(ctx).
-		Raw(countQuery, args...).
-		Scan(&totalCount).Error; err != nil {
+	totalCount, err := countStorefrontProducts(ctx, whereClause, args, search, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := selectStorefrontProducts(ctx, whereClause, orderClause, args, search, group, "", "LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
 		return nil, 0, err
 	}
 
+	return toStorefrontProducts(rows), totalCount, nil
+}
+
+// fetchStorefrontProductsByCursor runs a product listing a cursor page at a time, in the
+// keyset's order (see fetchStorefrontProductsFromDB). Returns models.ErrInvalidCursor for
+// a cursor it can't use.
+func fetchStorefrontProductsByCursor(
+	c *gin.Context,
+	whereClause string,
+	args []interface{},
+	search *models.SearchPlan,
+	keyset models.Keyset,
+	request *models.CursorRequest,
+	limit int,
+	group *uuid.UUID,
+) ([]models.StorefrontProductResponse, *models.Pagination, error) {
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	pageWhere, pageArgs := whereClause, args
+	if request.Cursor != "" {
+		cursor, err := models.DecodeCursor(request.Cursor, keyset.Sort)
+		if err != nil {
+			return nil, nil, err
+		}
+		pageWhere = fmt.Sprintf("(%s) AND %s", whereClause, keyset.AfterSQL())
+		pageArgs = append(append([]interface{}{}, args...), keyset.AfterArgs(cursor)...)
+	}
+
+	// One more than the page tells whether there's a next one
+	rows, err := selectStorefrontProducts(ctx, pageWhere, keyset.OrderSQL(), pageArgs, search, group, keyset.CursorKeySQL(), "LIMIT ?", limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nextCursor := ""
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[limit-1]
+		nextCursor = keyset.NextCursor(last.CursorKey, uuid.MustParse(last.ID))
+	}
+
+	// A first page that is also the last has the exact total already
+	total, exact := len(rows), request.Cursor == "" && nextCursor == ""
+	if !exact {
+		if total, err = countStorefrontProducts(ctx, whereClause, args, search, request.ExactTotal); err != nil {
+			return nil, nil, err
+		}
+		exact = request.ExactTotal
+	}
+
+	return toStorefrontProducts(rows), models.CursorPagination(limit, total, !exact, nextCursor), nil
+}
+
+// storefrontProductRow is a listing row, with where a search matched and its cursor key
+type storefrontProductRow struct {
+	models.StorefrontProductResponse
+	HighlightName    *string
+	HighlightSnippet *string
+	CursorKey        string
+}
+
+// countStorefrontProducts counts a listing's products, or has the planner estimate them
+func countStorefrontProducts(ctx context.Context, whereClause string, args []interface{}, search *models.SearchPlan, exact bool) (int, error) {
+	searchJoin := ""
+	if search != nil {
+		searchJoin = models.SearchJoinSQL
+		args = append([]interface{}{search.Expanded, search.Query}, args...)
+	}
+
+	var totalCount int
+	err := models.RunSearch(config.CmsGorm.WithContext(ctx), search != nil, func(db *gorm.DB) (err error) {
+		if !exact {
+			totalCount, err = config.EstimateCount(db.Raw(fmt.Sprintf(`
+				SELECT p.id
+				FROM products p %s
+				WHERE p.deleted_at IS NULL AND (%s)
+			`, searchJoin, whereClause), args...))
+			return err
+		}
+
+		// Count query
+		countQuery := fmt.Sprintf(`
+			SELECT COUNT(DISTINCT p.id)
+			FROM products p %s
+			WHERE p.deleted_at IS NULL AND (%s)
+		`, searchJoin, whereClause)
+
+		return db.Raw(countQuery, args...).Scan(&totalCount).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return totalCount, nil
+}
+
+// selectStorefrontProducts runs a listing's data query, with the page clause (and its
+// arguments) after the order, and the cursor key column if any
+func selectStorefrontProducts(
+	ctx context.Context,
+	whereClause string,
+	orderClause string,
+	args []interface{},
+	search *models.SearchPlan,
+	group *uuid.UUID,
+	cursorKeyColumn string,
+	pageClause string,
+	pageArgs ...interface{},
+) ([]storefrontProductRow, error) {
+	searchJoin := ""
+	searchColumns := "NULL AS highlight_name, NULL AS highlight_snippet"
+	if search != nil {
+		searchJoin = models.SearchJoinSQL
+		searchColumns = fmt.Sprintf("%s AS highlight_name, %s AS highlight_snippet", models.SearchHeadlineNameSQL, models.SearchSnippetSQL)
+		args = append([]interface{}{search.Expanded, search.Query}, args...)
+	}
+	if cursorKeyColumn != "" {
+		searchColumns += ",\n\t\t" + cursorKeyColumn
+	}
+
 	// Data query (ONLY required fields)
 	dataQuery := fmt.Sprintf(`
 	SELECT 
 		p.id::text AS id,
 		p.name,
-		p.price,
-		COALESCE(p.media->'primary'->>'url', '') AS image
-	FROM products p
-	WHERE %s
+		%s AS price,
+		%s AS original_price,
+		COALESCE(p.media->'primary'->>'url', '') AS image,
+		p.rating_average,
+		p.rating_count,
+		%s
+	FROM products p %s
+	WHERE p.deleted_at IS NULL AND (%s)
 	ORDER BY %s
-	LIMIT ? OFFSET ?
-`, whereClause, orderClause)
+	%s
+`, models.EffectivePriceSQLFor(group), models.OriginalPriceSQLFor(group), searchColumns, searchJoin, whereClause, orderClause, pageClause)
 
-	dataArgs := append(args, limit, offset)
+	dataArgs := append(append([]interface{}{}, args...), pageArgs...)
 
-	products := make([]models.StorefrontProductResponse, 0)
-
-	if err := config.CmsGorm.
-		WithContext(ctx).
-		Raw(dataQuery, dataArgs...).
-		Scan(&products).Error; err != nil {
-		return nil, 0, err
+	var rows []storefrontProductRow
+	if err := models.RunSearch(config.CmsGorm.WithContext(ctx), search != nil, func(db *gorm.DB) error {
+		return db.Raw(dataQuery, dataArgs...).Scan(&rows).Error
+	}); err != nil {
+		return nil, err
 	}
+	return rows, nil
+}
 
-	return products, int(totalCount), nil
+// toStorefrontProducts turns listing rows into the listing's products
+func toStorefrontProducts(rows []storefrontProductRow) []models.StorefrontProductResponse {
+	products := make([]models.StorefrontProductResponse, 0, len(rows))
+	for _, row := range rows {
+		product := row.StorefrontProductResponse
+		product.ApplySale()
+		if row.HighlightName != nil {
+			product.Highlight = &models.SearchHighlight{Name: *row.HighlightName}
+			if row.HighlightSnippet != nil {
+				product.Highlight.Snippet = *row.HighlightSnippet
+			}
+		}
+		products = append(products, product)
+	}
+	return products
 }
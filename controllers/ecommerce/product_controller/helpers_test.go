@@ -0,0 +1,44 @@
+package product_controller
+
+import (
+	"testing"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+)
+
+func TestStorefrontKeyset(t *testing.T) {
+	search := &models.SearchPlan{Query: "dress", Expanded: "dress"}
+	tests := []struct {
+		name      string
+		sortBy    string
+		sortOrder string
+		search    *models.SearchPlan
+		ok        bool
+		sort      string
+		keySQL    string
+	}{
+		{"newest", "newest", "desc", nil, true, "newest:desc", "p.created_at"},
+		{"oldest", "newest", "asc", nil, true, "newest:asc", "p.created_at"},
+		{"name", "name", "asc", nil, true, "name:asc", "p.name"},
+		{"rating", "rating", "desc", nil, true, "rating:desc", "p.rating_average"},
+		{"unknown sort is newest first", "popularity", "asc", nil, true, "newest:desc", "p.created_at"},
+		{"relevance without a search is newest first", "relevance", "asc", nil, true, "newest:desc", "p.created_at"},
+		{"relevance with a search", "relevance", "desc", search, false, "", ""},
+		{"price moves with sales", "price", "asc", nil, false, "", ""},
+		{"discount moves with sales", "discount", "desc", nil, false, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyset, ok := storefrontKeyset(tt.sortBy, tt.sortOrder, tt.search)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if keyset.Sort != tt.sort || keyset.KeySQL != tt.keySQL {
+				t.Errorf("keyset = %+v, want sort %q on %q", keyset, tt.sort, tt.keySQL)
+			}
+		})
+	}
+}
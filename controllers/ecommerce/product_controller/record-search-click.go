@@ -0,0 +1,55 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RecordSearchClick godoc
+// @Summary Record a click on a search result
+// @Description Count a click on a product found by a search or suggested in the search box, for the search analytics. The search ID is the X-Search-Id header of the search's first page (or of the suggestions).
+// @Tags Storefront - Products
+// @Accept json
+// @Produce json
+// @Param id path string true "Search ID (X-Search-Id)"
+// @Param click body models.SearchClickRequest true "Product clicked"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 500 {object} models.ApiResponse
+// @Router /store/search/{id}/click [post]
+func RecordSearchClick(c *gin.Context) {
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid search ID"))
+		return
+	}
+
+	var req models.SearchClickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	found, err := services.GetSearchAnalyticsService().RecordClick(ctx, searchID, req.ProductID)
+	if err != nil {
+		log.Printf("[store.search] failed to record click on search %s: %v", searchID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to record click"))
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Search not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "Click recorded successfully", nil))
+}
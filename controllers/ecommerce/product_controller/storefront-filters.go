@@ -0,0 +1,259 @@
+package product_controller
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Facets the storefront filters belong to; a facet's counts are taken under every filter
+// but its own
+const (
+	facetCategory     = "category"
+	facetSubcategory  = "subcategory"
+	facetAvailability = "availability"
+	facetPrice        = "price"
+	facetTag          = "tag"
+	facetVariant      = "variant:" // + variant type, e.g. "variant:Size"
+)
+
+// storefrontFilter is one condition of a storefront listing
+type storefrontFilter struct {
+	facet string // "" for conditions every facet applies (status, search, sale, rating)
+	sql   string
+	args  []interface{}
+}
+
+// storefrontFilters are the conditions of a storefront listing, with its search (nil for none)
+// and what the shopper selected in each facet
+type storefrontFilters struct {
+	search       *models.SearchPlan
+	conditions   []storefrontFilter
+	selected     map[string][]string // Facet → selected values
+	variantTypes []string            // Variant types with selected options, in order
+	minPrice     *float64
+	maxPrice     *float64
+}
+
+// where joins the conditions outside the excluded facet (none: "") into a where clause
+func (f *storefrontFilters) where(excludeFacet string) (string, []interface{}) {
+	clauses := make([]string, 0, len(f.conditions))
+	args := make([]interface{}, 0)
+	for _, condition := range f.conditions {
+		if excludeFacet != "" && condition.facet == excludeFacet {
+			continue
+		}
+		clauses = append(clauses, condition.sql)
+		args = append(args, condition.args...)
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+// selectedValues returns the values of a listing parameter, trimmed
+func selectedValues(c *gin.Context, param string) []string {
+	values := make([]string, 0)
+	for _, value := range c.QueryArray(param) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// maxVariantFilterTypes caps the variant types a listing filters on (each one is a facet
+// query of its own); options of further types are ignored
+const maxVariantFilterTypes = 4
+
+// variantFacet is the facet of a variant type; size and color have their own parameters,
+// other types share option=Type:Value
+func variantFacet(variantType string) string {
+	return facetVariant + variantType
+}
+
+// parseVariantOptions groups the listing's variant filters by type: size, color and
+// option=Type:Value (e.g. option=Material:Cotton), up to maxVariantFilterTypes types
+func parseVariantOptions(c *gin.Context) (map[string][]string, []string) {
+	byType := make(map[string][]string)
+	types := make([]string, 0)
+	add := func(variantType, value string) {
+		if _, ok := byType[variantType]; !ok {
+			if len(types) == maxVariantFilterTypes {
+				log.Printf("Ignoring %s filter: more than %d variant types", variantType, maxVariantFilterTypes)
+				return
+			}
+			types = append(types, variantType)
+		}
+		byType[variantType] = append(byType[variantType], value)
+	}
+
+	for _, size := range selectedValues(c, "size") {
+		add("Size", size)
+	}
+	for _, color := range selectedValues(c, "color") {
+		add("Color", color)
+	}
+	for _, option := range selectedValues(c, "option") {
+		variantType, value, ok := strings.Cut(option, ":")
+		variantType, value = strings.TrimSpace(variantType), strings.TrimSpace(value)
+		if ok && variantType != "" && value != "" {
+			add(variantType, value)
+		}
+	}
+	return byType, types
+}
+
+// placeholders returns n comma-separated placeholders, each wrapped by format (e.g. "LOWER(?)")
+func placeholders(n int, format string) string {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = format
+	}
+	return strings.Join(list, ",")
+}
+
+// buildStorefrontFilters parses the listing parameters shared by the product listing and
+// its facets into conditions on products p. Within a filter, values are alternatives;
+// different filters all apply.
+func buildStorefrontFilters(c *gin.Context, group *uuid.UUID) *storefrontFilters {
+	filters := &storefrontFilters{
+		conditions: []storefrontFilter{{sql: "p.status = 'Active'"}},
+		selected:   make(map[string][]string),
+	}
+	add := func(facet, sql string, args ...interface{}) {
+		filters.conditions = append(filters.conditions, storefrontFilter{facet: facet, sql: sql, args: args})
+	}
+	effectivePrice := models.EffectivePriceSQLFor(group)
+
+	// Search query (full text with synonyms, or a near match on the name; plus pinned products)
+	if searchQuery := strings.TrimSpace(c.Query("q")); searchQuery != "" {
+		filters.search = services.GetSearchRuleService().Plan(c.Request.Context(), searchQuery)
+		add("", filters.search.MatchSQL())
+		log.Printf("Added search condition (expanded: %q)", filters.search.Expanded)
+	}
+
+	// Style filter (subcategory name match)
+	if style := strings.TrimSpace(c.Query("style")); style != "" {
+		add(facetSubcategory, `p.sub_category_id IN (
+			SELECT id FROM categories
+			WHERE LOWER(name) = LOWER(?) AND parent_id IS NOT NULL
+		)`, style)
+		log.Printf("Added style filter: %s", style)
+	}
+
+	// Category filter (parent categories by NAME - multiple)
+	if categoryNames := selectedValues(c, "category"); len(categoryNames) > 0 {
+		// Find parent category IDs by name, then find all subcategories under those parents
+		args := make([]interface{}, len(categoryNames))
+		for i, name := range categoryNames {
+			args[i] = name
+		}
+		add(facetCategory, fmt.Sprintf(
+			`p.sub_category_id IN (
+				SELECT id FROM categories
+				WHERE parent_id IN (
+					SELECT id FROM categories
+					WHERE LOWER(name) IN (%s) AND parent_id IS NULL
+				)
+			)`,
+			placeholders(len(categoryNames), "LOWER(?)"),
+		), args...)
+		filters.selected[facetCategory] = categoryNames
+		log.Printf("Added category filter by names: %v", categoryNames)
+	}
+
+	// Subcategory filter (multiple subcategory IDs)
+	if subcategoryIDs := selectedValues(c, "subcategory"); len(subcategoryIDs) > 0 {
+		args := make([]interface{}, len(subcategoryIDs))
+		for i, id := range subcategoryIDs {
+			args[i] = id
+		}
+		add(facetSubcategory, fmt.Sprintf("p.sub_category_id IN (%s)", placeholders(len(subcategoryIDs), "?")), args...)
+		filters.selected[facetSubcategory] = subcategoryIDs
+		log.Printf("Added subcategory filter: %v", subcategoryIDs)
+	}
+
+	// Variant filters (size, color and other variant types)
+	optionsByType, variantTypes := parseVariantOptions(c)
+	filters.variantTypes = variantTypes
+	for _, variantType := range variantTypes {
+		options := optionsByType[variantType]
+		args := []interface{}{variantType}
+		for _, option := range options {
+			args = append(args, option)
+		}
+		add(variantFacet(variantType), fmt.Sprintf(
+			`EXISTS (
+				SELECT 1
+				FROM jsonb_array_elements(p.variants) AS variant,
+				     jsonb_array_elements_text(variant->'options') AS variant_option
+				WHERE variant->>'type' = ?
+				  AND variant_option IN (%s)
+			)`,
+			placeholders(len(options), "?"),
+		), args...)
+		filters.selected[variantFacet(variantType)] = options
+		log.Printf("Added %s filter: %v", variantType, options)
+	}
+
+	// Tag filter
+	if tags := selectedValues(c, "tag"); len(tags) > 0 {
+		args := make([]interface{}, len(tags))
+		for i, tag := range tags {
+			args[i] = tag
+		}
+		add(facetTag, fmt.Sprintf(
+			`EXISTS (
+				SELECT 1 FROM jsonb_array_elements_text(p.tags) AS tag
+				WHERE LOWER(tag) IN (%s)
+			)`,
+			placeholders(len(tags), "LOWER(?)"),
+		), args...)
+		filters.selected[facetTag] = tags
+		log.Printf("Added tag filter: %v", tags)
+	}
+
+	// Availability filter
+	switch c.Query("availability") {
+	case "in_stock", "inStock":
+		// Bundles are in stock when their components are
+		add(facetAvailability, models.InStockSQL)
+		filters.selected[facetAvailability] = []string{"in_stock"}
+		log.Printf("Added availability condition: in_stock")
+	case "out_of_stock", "outOfStock":
+		add(facetAvailability, "NOT "+models.InStockSQL)
+		filters.selected[facetAvailability] = []string{"out_of_stock"}
+		log.Printf("Added availability condition: out_of_stock")
+	}
+
+	// On-sale filter (something is cheaper than the original price right now)
+	if onSale, err := strconv.ParseBool(c.Query("on_sale")); err == nil && onSale {
+		add("", fmt.Sprintf("%s < %s", effectivePrice, models.OriginalPriceSQLFor(group)))
+		log.Printf("Added on_sale condition")
+	}
+
+	// Rating filter (average of approved reviews; unreviewed products have 0)
+	if minRating, err := strconv.ParseFloat(c.Query("min_rating"), 64); err == nil {
+		add("", "p.rating_average >= ?", minRating)
+		log.Printf("Added min_rating condition = %.2f", minRating)
+	}
+
+	// Price range filter (on the price customers pay right now)
+	if minPrice, err := strconv.ParseFloat(c.Query("minPrice"), 64); err == nil {
+		add(facetPrice, effectivePrice+" >= ?", minPrice)
+		filters.minPrice = &minPrice
+		log.Printf("Added minPrice condition = %.2f", minPrice)
+	}
+	if maxPrice, err := strconv.ParseFloat(c.Query("maxPrice"), 64); err == nil {
+		add(facetPrice, effectivePrice+" <= ?", maxPrice)
+		filters.maxPrice = &maxPrice
+		log.Printf("Added maxPrice condition = %.2f", maxPrice)
+	}
+
+	return filters
+}
@@ -0,0 +1,120 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SubmitProductReview godoc
+// @Summary Review a product
+// @Description Leave a rating, title, body and up to 5 photo URLs for a product from one of your completed orders. One review per product; it's shown once approved by the store.
+// @Tags store
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param review body models.ProductReviewRequest true "Review"
+// @Success 201 {object} models.ApiResponse{data=models.ProductReview}
+// @Failure 400 {object} models.ApiResponse
+// @Failure 401 {object} models.ApiResponse
+// @Failure 403 {object} models.ApiResponse "No completed order with this product"
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Already reviewed"
+// @Router /store/products/{id}/reviews [post]
+func SubmitProductReview(c *gin.Context) {
+	// Step 1: Validate product ID, customer and payload
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	userID, ok := customerFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse(c, "Unauthorized"))
+		return
+	}
+
+	var req models.ProductReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid request: "+err.Error()))
+		return
+	}
+	req.Title = strings.TrimSpace(req.Title)
+	req.Body = strings.TrimSpace(req.Body)
+	if req.Title == "" || req.Body == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "title and body are required"))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: The product is on sale and the customer has received it
+	var count int64
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ? AND status = ?", productID, models.ProductStatusActive).
+		Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if count == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		return
+	}
+
+	orderID, err := services.GetReviewService().VerifiedOrder(ctx, userID, productID)
+	if err != nil {
+		log.Printf("[store.reviews] failed to check orders of %s for %s: %v", userID, productID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if orderID == nil {
+		c.JSON(http.StatusForbidden, models.ErrorResponse(c, "Only customers with a completed order of this product can review it"))
+		return
+	}
+
+	// Step 3: One review per customer and product
+	var existing models.ProductReview
+	err = config.EcommerceGorm.WithContext(ctx).
+		Select("id").
+		Where("product_id = ? AND user_id = ?", productID, userID).
+		First(&existing).Error
+	if err == nil {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "You have already reviewed this product"))
+		return
+	}
+	if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+
+	// Step 4: Save it for moderation
+	review := models.ProductReview{
+		ProductID: productID,
+		UserID:    userID,
+		OrderID:   orderID,
+		Rating:    req.Rating,
+		Title:     req.Title,
+		Body:      req.Body,
+		Photos:    req.Photos,
+		Status:    models.ReviewPending,
+	}
+	if err := config.EcommerceGorm.WithContext(ctx).Create(&review).Error; err != nil {
+		log.Printf("[store.reviews] failed to create review of %s by %s: %v", productID, userID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to submit review"))
+		return
+	}
+
+	log.Printf("[store.reviews] review %s of %s submitted for moderation", review.ID, productID)
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "Review submitted; it will appear once approved", review))
+}
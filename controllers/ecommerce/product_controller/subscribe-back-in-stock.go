@@ -0,0 +1,165 @@
+package product_controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SubscribeBackInStock godoc
+// @Summary Get notified when a sold-out variant is back in stock
+// @Description Subscribe an email (or the logged-in user) to a sold-out variant, identified by SKU, option values (combo) or variant name. One email is sent when it is restocked. The logged-in user's own email is subscribed right away; any other email is sent a confirmation link first (POST /store/notifications/confirm) and isn't notified until it's followed. Rate limited per IP.
+// @Tags store
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param subscription body models.BackInStockRequest true "Variant and email"
+// @Success 201 {object} models.ApiResponse{data=models.BackInStockSubscription} "Subscribed, or confirmation sent (status unconfirmed)"
+// @Success 200 {object} models.ApiResponse "Already subscribed"
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Failure 409 {object} models.ApiResponse "Variant is in stock"
+// @Failure 429 {object} models.ApiResponse "Too many requests"
+// @Router /store/products/{id}/notify [post]
+func SubscribeBackInStock(c *gin.Context) {
+	// Step 1: Validate product ID and payload
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid product ID"))
+		return
+	}
+
+	var req models.BackInStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid request: "+err.Error()))
+		return
+	}
+
+	// Logged-in shoppers default to their account email; any other email has to be
+	// confirmed by its owner, so the endpoint can't be used to mail strangers
+	var userID *uuid.UUID
+	accountEmail := ""
+	if raw, exists := c.Get("userID"); exists {
+		if parsed, err := uuid.Parse(raw.(string)); err == nil {
+			if v, exists := c.Get("userEmail"); exists {
+				userID = &parsed
+				accountEmail = strings.ToLower(v.(string))
+			}
+		}
+	}
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == "" {
+		email = accountEmail
+	}
+	if email == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "email is required"))
+		return
+	}
+	confirmed := accountEmail != "" && email == accountEmail
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	// Step 2: Find the variant on the published product
+	var product models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Select("id, name, inventory").
+		Where("id = ? AND status = ?", productID, "Active").
+		First(&product).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Product not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		}
+		return
+	}
+
+	idx := -1
+	switch {
+	case strings.TrimSpace(req.SKU) != "":
+		idx = product.Inventory.IndexOfSKU(strings.TrimSpace(req.SKU))
+	case len(req.Combo) > 0:
+		idx = product.Inventory.IndexOfOptions(req.Combo...)
+	case strings.TrimSpace(req.VariantName) != "":
+		for i, item := range product.Inventory {
+			if strings.EqualFold(item.VariantName, strings.TrimSpace(req.VariantName)) {
+				idx = i
+				break
+			}
+		}
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "sku, combo or variant_name is required"))
+		return
+	}
+	if idx < 0 || product.Inventory[idx].SKU == "" {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Variant not found for this product"))
+		return
+	}
+
+	variant := product.Inventory[idx]
+	if variant.Quantity > 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse(c, "This variant is in stock"))
+		return
+	}
+
+	// Step 3: Create the subscription (one open subscription per email and variant)
+	token, err := services.GenerateUnsubscribeToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create subscription"))
+		return
+	}
+
+	subscription := models.BackInStockSubscription{
+		ProductID:        productID,
+		SKU:              variant.SKU,
+		VariantName:      variant.VariantName,
+		Email:            email,
+		UnsubscribeToken: token,
+		Status:           models.BackInStockUnconfirmed,
+	}
+	if confirmed {
+		now := time.Now()
+		subscription.UserID = userID
+		subscription.Status = models.BackInStockPending
+		subscription.ConfirmedAt = &now
+	}
+
+	result := config.EcommerceGorm.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&subscription)
+	if result.Error != nil {
+		log.Printf("[store.back-in-stock] failed to subscribe %s to %s: %v", email, variant.SKU, result.Error)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to create subscription"))
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusOK, models.SuccessResponse(c, "You're already subscribed to this variant", nil))
+		return
+	}
+
+	// Step 4: Ask the owner of any other email to confirm it (the link carries the token)
+	if !confirmed {
+		if err := services.GetBackInStockService().SendConfirmation(&subscription, product.Name); err != nil {
+			log.Printf("[store.back-in-stock] failed to send confirmation to %s for %s: %v", email, variant.SKU, err)
+			// Drop the subscription so the shopper can try again
+			if err := config.EcommerceGorm.WithContext(ctx).Delete(&subscription).Error; err != nil {
+				log.Printf("[store.back-in-stock] failed to drop unconfirmed subscription %s: %v", subscription.ID, err)
+			}
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to send confirmation email"))
+			return
+		}
+		c.JSON(http.StatusCreated, models.SuccessResponse(c, "Check your inbox to confirm your email", subscription))
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(c, "We'll email you when this variant is back in stock", subscription))
+}
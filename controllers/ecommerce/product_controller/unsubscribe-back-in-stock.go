@@ -0,0 +1,61 @@
+package product_controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UnsubscribeBackInStock godoc
+// @Summary Unsubscribe from a back-in-stock notification
+// @Description Cancel a back-in-stock subscription using the token from the email's unsubscribe link
+// @Tags store
+// @Accept json
+// @Produce json
+// @Param request body models.BackInStockUnsubscribeRequest true "Unsubscribe token"
+// @Success 200 {object} models.ApiResponse
+// @Failure 400 {object} models.ApiResponse
+// @Failure 404 {object} models.ApiResponse
+// @Router /store/notifications/unsubscribe [post]
+func UnsubscribeBackInStock(c *gin.Context) {
+	var req models.BackInStockUnsubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(c, "Invalid request: "+err.Error()))
+		return
+	}
+
+	ctx, cancel := config.WithTimeout()
+	defer cancel()
+
+	var subscription models.BackInStockSubscription
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Where("unsubscribe_token = ?", req.Token).
+		Limit(1).
+		Find(&subscription).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Database error"))
+		return
+	}
+	if subscription.ID == uuid.Nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse(c, "Subscription not found"))
+		return
+	}
+
+	// Already unsubscribed links keep working
+	if subscription.Status != models.BackInStockUnsubscribed {
+		if err := config.EcommerceGorm.WithContext(ctx).
+			Model(&subscription).
+			Updates(map[string]interface{}{
+				"status":          models.BackInStockUnsubscribed,
+				"unsubscribed_at": time.Now(),
+			}).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, "Failed to unsubscribe"))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(c, "You've been unsubscribed", nil))
+}
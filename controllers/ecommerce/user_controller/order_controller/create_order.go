@@ -2,13 +2,16 @@ package order_controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
 	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/services"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -36,16 +39,17 @@ func detectDevice(userAgent string) string {
 
 // CreateOrder godoc
 // @Summary Create new order (checkout)
-// @Description Create a new order from cart items with payment and address
+// @Description Create a new order from cart items with payment and address. Items are charged the price that applies at checkout: active sales, the customer's group price list and quantity tiers included. Bundles take their stock from each of their components. Products' quantity rules (min/max per order, max per customer, multiples of a step) apply to the units of each product across combos. Units beyond stock are accepted where the product or combo allows backorders or pre-orders (up to its cap) and recorded on the line as backordered; they're taken from stock when the order ships.
 // @Tags User - Orders
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param order body models.CreateOrderRequest true "Order details"
 // @Success 201 {object} models.ApiResponse{data=object{order_id=string,order_number=string}} "Order created successfully"
-// @Failure 400 {object} models.ApiResponse "Invalid request"
+// @Failure 400 {object} models.ApiResponse "Invalid request, unknown or incomplete variant, or quantity rule broken"
 // @Failure 401 {object} models.ApiResponse "Unauthorized"
 // @Failure 404 {object} models.ApiResponse "Payment method or address not found"
+// @Failure 409 {object} models.ApiResponse "Insufficient stock"
 // @Failure 500 {object} models.ApiResponse "Internal server error"
 // @Router /user/orders [post]
 func CreateOrder(c *gin.Context) {
@@ -123,6 +127,7 @@ func CreateOrder(c *gin.Context) {
 	var orderID uuid.UUID
 	var orderNumber string
 	var totalAmount float64
+	stockDeducted := false
 
 	err = config.EcommerceGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Create address snapshot
@@ -152,15 +157,23 @@ func CreateOrder(c *gin.Context) {
 		log.Printf("🔍 Querying CMS DB for products: %v", productIDs)
 
 		var products []struct {
-			ID    uuid.UUID `gorm:"column:id"`
-			Name  string    `gorm:"column:name"`
-			Price float64   `gorm:"column:price"`
+			ID             uuid.UUID                  `gorm:"column:id"`
+			Name           string                     `gorm:"column:name"`
+			Price          float64                    `gorm:"column:price"`
+			CompareAtPrice *float64                   `gorm:"column:compare_at_price"`
+			SalePrice      *float64                   `gorm:"column:sale_price"`
+			SaleStartsAt   *time.Time                 `gorm:"column:sale_starts_at"`
+			SaleEndsAt     *time.Time                 `gorm:"column:sale_ends_at"`
+			Inventory      models.InventoryList       `gorm:"column:inventory"`
+			ProductType    string                     `gorm:"column:product_type"`
+			Components     models.BundleComponentList `gorm:"column:bundle_components"`
+			Backorder      models.BackorderPolicy     `gorm:"column:backorder"`
 		}
 
 		if err := config.CmsGorm.WithContext(ctx).
 			Table("products").
-			Select("id, name, price").
-			Where("id IN ? AND status = ?", productIDs, "Active").
+			Select("id, name, price, compare_at_price, sale_price, sale_starts_at, sale_ends_at, inventory, product_type, bundle_components, backorder").
+			Where("id IN ? AND status = ? AND deleted_at IS NULL", productIDs, "Active").
 			Find(&products).Error; err != nil {
 			log.Printf("❌ Failed to fetch product prices: %v", err)
 			return fmt.Errorf("failed to validate products")
@@ -169,21 +182,158 @@ func CreateOrder(c *gin.Context) {
 		// Build product map
 		productPrices := make(map[string]ProductInfo)
 		for _, p := range products {
-			productPrices[p.ID.String()] = ProductInfo{Name: p.Name, Price: p.Price}
+			productPrices[p.ID.String()] = ProductInfo{Name: p.Name, Pricing: models.Product{
+				Price:            p.Price,
+				CompareAtPrice:   p.CompareAtPrice,
+				SalePrice:        p.SalePrice,
+				SaleStartsAt:     p.SaleStartsAt,
+				SaleEndsAt:       p.SaleEndsAt,
+				Inventory:        p.Inventory,
+				ProductType:      p.ProductType,
+				BundleComponents: p.Components,
+				Backorder:        p.Backorder,
+			}}
 		}
 
-		// Validate all products exist
-		for _, item := range req.Items {
-			if _, exists := productPrices[item.ProductID]; !exists {
+		// Customer group prices and quantity tiers (tiers count every unit of a product
+		// in the order, across combos)
+		pricingService := services.GetCustomerPricingService()
+		group, err := pricingService.GroupOf(ctx, userID)
+		if err != nil {
+			log.Printf("❌ Failed to fetch customer group: %v", err)
+			return fmt.Errorf("failed to validate products")
+		}
+		listPrices, err := pricingService.ListPrices(ctx, group, productIDs)
+		if err != nil {
+			log.Printf("❌ Failed to fetch group prices: %v", err)
+			return fmt.Errorf("failed to validate products")
+		}
+		tiers, err := pricingService.Tiers(ctx, group, productIDs)
+		if err != nil {
+			log.Printf("❌ Failed to fetch quantity tiers: %v", err)
+			return fmt.Errorf("failed to validate products")
+		}
+		productQuantities := make(map[uuid.UUID]int, len(productIDs))
+		for i, item := range req.Items {
+			productQuantities[productIDs[i]] += item.Quantity
+		}
+
+		// Stock that has arrived for earlier backorders is held for them
+		outstanding, err := services.GetBackorderService().Outstanding(ctx, productIDs)
+		if err != nil {
+			log.Printf("❌ Failed to fetch outstanding backorders: %v", err)
+			return fmt.Errorf("failed to validate products")
+		}
+		taken := make(map[string]int)       // Units of a combo this order takes from stock
+		backordered := make(map[string]int) // Units of a combo this order backorders
+
+		// Validate all products exist, resolve the SKU of each line and price it as of
+		// checkout (sales that apply right now included)
+		checkoutAt := time.Now()
+		itemSKUs := make([]*string, len(req.Items))
+		itemPrices := make([]float64, len(req.Items))
+		itemBundles := make([]models.OrderBundleComponentList, len(req.Items))
+		itemBackorders := make([]backorderLine, len(req.Items))
+		for i, item := range req.Items {
+			productInfo, exists := productPrices[item.ProductID]
+			if !exists {
 				return fmt.Errorf("product %s not found or inactive", item.ProductID)
 			}
+
+			combo, err := resolveItemCombo(productInfo.Pricing.Inventory, item)
+			if err != nil {
+				return err
+			}
+			if combo != nil && combo.SKU != "" {
+				sku := combo.SKU
+				itemSKUs[i] = &sku
+			}
+			pricing := &productInfo.Pricing
+			if listPrice, ok := listPrices[productIDs[i]]; ok {
+				pricing = pricing.WithListPrice(&listPrice)
+			}
+			discount := models.TierDiscount(tiers, productIDs[i], productQuantities[productIDs[i]])
+			itemPrices[i] = models.ApplyDiscount(pricing.PriceAt(combo, checkoutAt), discount)
+
+			// Units beyond the free stock are backordered, where the combo allows it
+			if combo != nil && combo.SKU != "" {
+				comboKey := item.ProductID + "/" + strings.ToLower(combo.SKU)
+				held := outstanding[productIDs[i]][strings.ToLower(combo.SKU)]
+				free := max(combo.Quantity-held-taken[comboKey], 0)
+				fromStock := min(item.Quantity, free)
+				if short := item.Quantity - fromStock; short > 0 {
+					policy := productInfo.Pricing.BackorderFor(combo)
+					allowed := 0
+					if policy.Allowed() {
+						allowed = short
+						if policy.Limit > 0 {
+							allowed = max(policy.Limit-held-backordered[comboKey], 0)
+						}
+					}
+					if short > allowed {
+						return &services.InsufficientStockError{
+							ProductName: productInfo.Name,
+							VariantName: combo.VariantName,
+							Available:   free + allowed,
+							Requested:   item.Quantity,
+						}
+					}
+					mode := policy.Mode
+					itemBackorders[i] = backorderLine{Quantity: short, Type: &mode, ExpectedShipDate: policy.ExpectedShipDate}
+					backordered[comboKey] += short
+				}
+				taken[comboKey] += fromStock
+			}
+
+			// Bundles: snapshot the components, which is where the stock comes from
+			if productInfo.Pricing.IsBundle() {
+				bundle, err := services.GetBundleService().Details(ctx, productInfo.Pricing.BundleComponents)
+				if err != nil {
+					log.Printf("❌ Failed to fetch bundle components: %v", err)
+					return fmt.Errorf("failed to validate products")
+				}
+				if bundle.Available < item.Quantity {
+					return &services.InsufficientStockError{
+						ProductName: productInfo.Name,
+						VariantName: "bundle",
+						Available:   bundle.Available,
+						Requested:   item.Quantity,
+					}
+				}
+				for _, component := range bundle.Components {
+					itemBundles[i] = append(itemBundles[i], models.OrderBundleComponent{
+						ProductID:   component.ProductID.String(),
+						ProductName: component.ProductName,
+						SKU:         component.SKU,
+						VariantName: component.VariantName,
+						Quantity:    component.Quantity,
+					})
+				}
+			}
+		}
+
+		// Quantity rules count the same units, and what the customer has ordered before.
+		// Bundle components (line quantity × component quantity) count towards the limits.
+		bundledQuantities := make(map[uuid.UUID]int)
+		for i, item := range req.Items {
+			for _, component := range itemBundles[i] {
+				componentID, _ := uuid.Parse(component.ProductID)
+				bundledQuantities[componentID] += item.Quantity * component.Quantity
+			}
+		}
+		if err := services.GetQuantityRuleService().CheckOrder(ctx, tx, userID, productQuantities, bundledQuantities); err != nil {
+			var ruleErr *services.QuantityRuleError
+			if errors.As(err, &ruleErr) {
+				return err
+			}
+			log.Printf("❌ Failed to check quantity rules: %v", err)
+			return fmt.Errorf("failed to validate products")
 		}
 
 		// Calculate order totals
 		var subtotal float64 = 0
-		for _, item := range req.Items {
-			productInfo := productPrices[item.ProductID]
-			itemSubtotal := productInfo.Price * float64(item.Quantity)
+		for i, item := range req.Items {
+			itemSubtotal := itemPrices[i] * float64(item.Quantity)
 			subtotal += itemSubtotal
 		}
 
@@ -225,9 +375,9 @@ func CreateOrder(c *gin.Context) {
 		}
 
 		// Create order items
-		for _, item := range req.Items {
+		for i, item := range req.Items {
 			productInfo := productPrices[item.ProductID]
-			itemSubtotal := productInfo.Price * float64(item.Quantity)
+			itemSubtotal := itemPrices[i] * float64(item.Quantity)
 			itemProductID, _ := uuid.Parse(item.ProductID)
 
 			orderItem := struct {
@@ -238,10 +388,16 @@ func CreateOrder(c *gin.Context) {
 				ProductName  string
 				VariantSize  *string
 				VariantColor *string
+				SKU          *string
 				Price        float64
 				Quantity     int
 				Subtotal     float64
 				Status       string
+
+				BundleComponents    models.OrderBundleComponentList
+				BackorderedQuantity int
+				BackorderType       *string
+				ExpectedShipDate    *time.Time
 			}{
 				ID:           uuid.Must(uuid.NewV7()),
 				OrderID:      orderID,
@@ -250,10 +406,16 @@ func CreateOrder(c *gin.Context) {
 				ProductName:  productInfo.Name,
 				VariantSize:  item.VariantSize,
 				VariantColor: item.VariantColor,
-				Price:        productInfo.Price,
+				SKU:          itemSKUs[i],
+				Price:        itemPrices[i],
 				Quantity:     item.Quantity,
 				Subtotal:     itemSubtotal,
 				Status:       "pending",
+
+				BundleComponents:    itemBundles[i],
+				BackorderedQuantity: itemBackorders[i].Quantity,
+				BackorderType:       itemBackorders[i].Type,
+				ExpectedShipDate:    itemBackorders[i].ExpectedShipDate,
 			}
 
 			if err := tx.Table("order_items").Create(&orderItem).Error; err != nil {
@@ -268,9 +430,66 @@ func CreateOrder(c *gin.Context) {
 			return fmt.Errorf("failed to create order")
 		}
 
+		// Deduct stock in the CMS DB last, so a stock failure rolls the order back (and the
+		// stock is released below if the order fails to commit after it).
+		// Backordered units are taken when the order ships.
+		stockLines := make([]services.OrderStockLine, 0, len(req.Items))
+		for i, item := range req.Items {
+			for _, component := range itemBundles[i] {
+				componentID, _ := uuid.Parse(component.ProductID)
+				stockLines = append(stockLines, services.OrderStockLine{
+					ProductID: componentID,
+					SKU:       component.SKU,
+					Quantity:  item.Quantity * component.Quantity,
+				})
+			}
+			if itemSKUs[i] == nil {
+				continue
+			}
+			itemProductID, _ := uuid.Parse(item.ProductID)
+			stockLines = append(stockLines, services.OrderStockLine{
+				ProductID: itemProductID,
+				SKU:       *itemSKUs[i],
+				Quantity:  item.Quantity - itemBackorders[i].Quantity,
+			})
+		}
+		if err := services.GetStockService().DeductOrderStock(ctx, orderID, stockLines); err != nil {
+			log.Printf("❌ Failed to deduct stock: %v", err)
+			var stockErr *services.InsufficientStockError
+			if errors.As(err, &stockErr) {
+				return err
+			}
+			return fmt.Errorf("failed to reserve stock")
+		}
+		stockDeducted = true
+
 		return nil
 	})
 	if err != nil {
+		// The CMS stock transaction committed on its own; give the stock back
+		if stockDeducted {
+			releaseCtx, releaseCancel := config.WithTimeout()
+			if releaseErr := services.GetStockService().ReleaseOrderStock(releaseCtx, orderID); releaseErr != nil {
+				log.Printf("❌ Failed to release stock for uncommitted order %s: %v", orderID, releaseErr)
+			}
+			releaseCancel()
+		}
+
+		var stockErr *services.InsufficientStockError
+		if errors.As(err, &stockErr) {
+			c.JSON(http.StatusConflict, models.ErrorResponse(c, stockErr.Error()))
+			return
+		}
+		var comboErr *itemComboError
+		if errors.As(err, &comboErr) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, comboErr.Error()))
+			return
+		}
+		var ruleErr *services.QuantityRuleError
+		if errors.As(err, &ruleErr) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(c, ruleErr.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse(c, err.Error()))
 		return
 	}
@@ -291,6 +510,54 @@ func CreateOrder(c *gin.Context) {
 
 // Helper struct for product info
 type ProductInfo struct {
-	Name  string
-	Price float64
+	Name    string
+	Pricing models.Product // Price, sale fields, inventory, bundle components and backorder policy only
+}
+
+// backorderLine is the part of an order line ordered beyond stock
+type backorderLine struct {
+	Quantity         int
+	Type             *string // backorder or preorder
+	ExpectedShipDate *time.Time
+}
+
+// itemComboError is a cart item that doesn't pick out one of its product's combos
+type itemComboError struct {
+	message string
+}
+
+func (e *itemComboError) Error() string {
+	return e.message
+}
+
+// resolveItemCombo returns the inventory combo a cart item refers to.
+// An explicit SKU must exist on the product; otherwise the combo is matched by size/color,
+// which must match exactly one. Returns nil for products without inventory combos, and an
+// *itemComboError for items that don't pick one.
+func resolveItemCombo(inventory models.InventoryList, item models.OrderItemInput) (*models.InventoryField, error) {
+	if item.SKU != nil && strings.TrimSpace(*item.SKU) != "" {
+		idx := inventory.IndexOfSKU(strings.TrimSpace(*item.SKU))
+		if idx < 0 {
+			return nil, &itemComboError{fmt.Sprintf("SKU %s not found for product %s", *item.SKU, item.ProductID)}
+		}
+		return &inventory[idx], nil
+	}
+
+	var size, color string
+	if item.VariantSize != nil {
+		size = *item.VariantSize
+	}
+	if item.VariantColor != nil {
+		color = *item.VariantColor
+	}
+
+	if len(inventory) == 0 {
+		return nil, nil
+	}
+
+	idx := inventory.IndexOfOptions(size, color)
+	if idx < 0 {
+		return nil, &itemComboError{fmt.Sprintf("selected variant is not available, or is missing a size or colour, for product %s", item.ProductID)}
+	}
+	return &inventory[idx], nil
 }
@@ -111,12 +111,17 @@ func GetOrderDetails(c *gin.Context) {
 			product_name,
 			variant_size, 
 			variant_color, 
+			sku,
 			price, 
 			quantity, 
 			subtotal,
 			status, 
 			created_at, 
-			updated_at
+			updated_at,
+			bundle_components,
+			backordered_quantity,
+			backorder_type,
+			expected_ship_date
 		FROM order_items
 		WHERE order_id = ?
 		ORDER BY created_at ASC
@@ -187,6 +187,21 @@ func main() {
 	}
 	log.Println("✅ JWT Service initialized")
 
+	services.GetStockAlertService().StartScheduler()
+	log.Println("✅ Low-stock alert scheduler started")
+
+	services.GetProductScheduleService().StartScheduler()
+	log.Println("✅ Product publish scheduler started")
+
+	services.GetProductTrashService().StartScheduler()
+	log.Println("✅ Product trash purge scheduler started")
+
+	services.GetRecommendationService().StartScheduler()
+	log.Println("✅ Recommendation refresh scheduler started")
+
+	services.GetSearchSuggestService().StartScheduler()
+	log.Println("✅ Search suggestion refresh scheduler started")
+
 	// ✅ Configure CORS properly for all content types including PDFs
 	corsCfg := cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:3001", "https://admin.modeva.shop", "https://modeva.shop", "http://admin.modeva.shop"},
@@ -194,7 +209,7 @@ func main() {
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-CSRF-Token", "X-Requested-With"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
-		ExposeHeaders:    []string{"Content-Disposition", "Content-Length"}, // Expose these headers for downloads
+		ExposeHeaders:    []string{"Content-Disposition", "Content-Length", "X-Search-Redirect", "X-Search-Id"}, // Downloads, and search redirects and analytics
 	}
 
 	// ✅ Initialize Google OAuth
@@ -217,8 +232,13 @@ func main() {
 	adminGroup.Use(middleware.RateLimiter(100, time.Minute))
 	cms_routes.SetupCategoryRoutes(adminGroup)
 	cms_routes.SetupProductRoutes(adminGroup)
+	cms_routes.SetupInventoryRoutes(adminGroup)
 	cms_routes.SetupOrderRoutes(adminGroup)
 	cms_routes.SetupCustomerRoutes(adminGroup)
+	cms_routes.SetupPricingRoutes(adminGroup)
+	cms_routes.SetupReviewRoutes(adminGroup)
+	cms_routes.SetupQuestionRoutes(adminGroup)
+	cms_routes.SetupSearchRoutes(adminGroup)
 	cms_routes.SetupAnalyticsRoutes(adminGroup)
 
 	// Public storefront (no rate limiter)
@@ -20,20 +20,42 @@ import (
 
 // pathToResourceType maps URL paths to resource types
 var pathToResourceType = map[string]string{
-	"categories": models.ResourceTypeCategory,
-	"products":   models.ResourceTypeProduct,
-	"orders":     models.ResourceTypeOrder,
-	"customers":  models.ResourceTypeCustomer,
-	"admins":     models.ResourceTypeAdmin,
+	"categories":        models.ResourceTypeCategory,
+	"products":          models.ResourceTypeProduct,
+	"orders":            models.ResourceTypeOrder,
+	"customers":         models.ResourceTypeCustomer,
+	"admins":            models.ResourceTypeAdmin,
+	"stock-adjustments": models.ResourceTypeStockAdjustment,
+	"locations":         models.ResourceTypeStockLocation,
+	"transfers":         models.ResourceTypeStockTransfer,
+	"alerts":            models.ResourceTypeStockAlert,
+	"import":            models.ResourceTypeProductImport,
+	"customer-groups":   models.ResourceTypeCustomerGroup,
+	"quantity-tiers":    models.ResourceTypeQuantityTier,
+	"reviews":           models.ResourceTypeReview,
+	"questions":         models.ResourceTypeQuestion,
+	"answers":           models.ResourceTypeAnswer,
+	"synonyms":          models.ResourceTypeSearchSynonym,
+	"redirects":         models.ResourceTypeSearchRedirect,
+	"pins":              models.ResourceTypeSearchPin,
 }
 
 // resourceTypeToNameField maps resource types to their name field
 var resourceTypeToNameField = map[string]string{
-	models.ResourceTypeCategory: "name",
-	models.ResourceTypeProduct:  "name",
-	models.ResourceTypeCustomer: "email",
-	models.ResourceTypeOrder:    "id",
-	models.ResourceTypeAdmin:    "email",
+	models.ResourceTypeCategory:        "name",
+	models.ResourceTypeProduct:         "name",
+	models.ResourceTypeCustomer:        "email",
+	models.ResourceTypeOrder:           "id",
+	models.ResourceTypeAdmin:           "email",
+	models.ResourceTypeStockAdjustment: "name",
+	models.ResourceTypeStockLocation:   "name",
+	models.ResourceTypeStockAlert:      "sku",
+	models.ResourceTypeCustomerGroup:   "name",
+	models.ResourceTypeReview:          "title",
+	models.ResourceTypeQuestion:        "question",
+	models.ResourceTypeAnswer:          "answer",
+	models.ResourceTypeSearchRedirect:  "query",
+	models.ResourceTypeSearchPin:       "query",
 }
 
 // methodToActionVerb maps HTTP methods to action verbs
@@ -44,6 +66,16 @@ var methodToActionVerb = map[string]string{
 	"DELETE": "deleted",
 }
 
+// pathToActionVerb overrides the HTTP method's verb for action endpoints, keyed by
+// the last path segment (e.g. POST .../revisions/3/restore → "restored_product")
+var pathToActionVerb = map[string]string{
+	"restore": "restored",
+	"approve": "approved",
+	"reject":  "rejected",
+	"reply":   "replied_to",
+	"answer":  "answered",
+}
+
 // ════════════════════════════════════════════════════════════
 // Activity Logging Middleware
 // ════════════════════════════════════════════════════════════
@@ -106,12 +138,17 @@ func ActivityLoggingMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		verbOverridden := false
+		if verb, exists := pathToActionVerb[lastPathSegment(c.Request.URL.Path)]; exists {
+			actionVerb, verbOverridden = verb, true
+		}
+
 		// Build full action name (e.g., "created_product", "updated_category")
 		action := actionVerb + "_" + resourceType
 
-		// Fetch "before" object from DB (only for updates and deletes)
+		// Fetch "before" object from DB (only for updates, deletes and actions on an existing resource)
 		var beforeObject interface{}
-		if c.Request.Method != "POST" && resourceID != "" {
+		if (c.Request.Method != "POST" || verbOverridden) && resourceID != "" {
 			beforeObject = fetchResourceFromDB(resourceType, resourceID)
 		}
 
@@ -207,6 +244,12 @@ func extractResourceType(path string) string {
 	return ""
 }
 
+// lastPathSegment returns the final non-empty segment of a URL path
+func lastPathSegment(path string) string {
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	return parts[len(parts)-1]
+}
+
 // isIDParam checks if a path segment is an ID parameter
 func isIDParam(segment string) bool {
 	// Check if it looks like a UUID or numeric ID
@@ -227,13 +270,110 @@ func fetchResourceFromDB(resourceType, resourceID string) interface{} {
 
 	switch resourceType {
 	case models.ResourceTypeProduct:
+		// Unscoped: trashing and restoring log the product on both sides of the trash
 		var product models.Product
-		if err := config.CmsGorm.WithContext(ctx).First(&product, "id = ?", resourceID).Error; err != nil {
+		if err := config.CmsGorm.WithContext(ctx).Unscoped().First(&product, "id = ?", resourceID).Error; err != nil {
 			log.Printf("[activity-logging] failed to fetch product %s: %v", resourceID, err)
 			return nil
 		}
 		return product
 
+	case models.ResourceTypeStockAdjustment:
+		// Stock adjustments are logged against the product's inventory
+		var product models.Product
+		if err := config.CmsGorm.WithContext(ctx).
+			Select("id, name, inventory").
+			First(&product, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch product stock %s: %v", resourceID, err)
+			return nil
+		}
+		return map[string]interface{}{
+			"id":        product.ID,
+			"name":      product.Name,
+			"inventory": product.Inventory,
+		}
+
+	case models.ResourceTypeStockLocation:
+		var location models.StockLocation
+		if err := config.CmsGorm.WithContext(ctx).First(&location, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch stock location %s: %v", resourceID, err)
+			return nil
+		}
+		return location
+
+	case models.ResourceTypeStockAlert:
+		var alert models.StockAlert
+		if err := config.CmsGorm.WithContext(ctx).First(&alert, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch stock alert %s: %v", resourceID, err)
+			return nil
+		}
+		return alert
+
+	case models.ResourceTypeCustomerGroup:
+		var group models.CustomerGroup
+		if err := config.CmsGorm.WithContext(ctx).First(&group, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch customer group %s: %v", resourceID, err)
+			return nil
+		}
+		return group
+
+	case models.ResourceTypeQuantityTier:
+		var tier models.QuantityTier
+		if err := config.CmsGorm.WithContext(ctx).First(&tier, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch quantity tier %s: %v", resourceID, err)
+			return nil
+		}
+		return tier
+
+	case models.ResourceTypeReview:
+		// Reviews live in the ecommerce database
+		var review models.ProductReview
+		if err := config.EcommerceGorm.WithContext(ctx).First(&review, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch review %s: %v", resourceID, err)
+			return nil
+		}
+		return review
+
+	case models.ResourceTypeQuestion:
+		var question models.ProductQuestion
+		if err := config.EcommerceGorm.WithContext(ctx).First(&question, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch question %s: %v", resourceID, err)
+			return nil
+		}
+		return question
+
+	case models.ResourceTypeAnswer:
+		var answer models.ProductAnswer
+		if err := config.EcommerceGorm.WithContext(ctx).First(&answer, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch answer %s: %v", resourceID, err)
+			return nil
+		}
+		return answer
+
+	case models.ResourceTypeSearchSynonym:
+		var group models.SearchSynonymGroup
+		if err := config.CmsGorm.WithContext(ctx).First(&group, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch synonym group %s: %v", resourceID, err)
+			return nil
+		}
+		return group
+
+	case models.ResourceTypeSearchRedirect:
+		var redirect models.SearchRedirect
+		if err := config.CmsGorm.WithContext(ctx).First(&redirect, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch search redirect %s: %v", resourceID, err)
+			return nil
+		}
+		return redirect
+
+	case models.ResourceTypeSearchPin:
+		var pin models.SearchPin
+		if err := config.CmsGorm.WithContext(ctx).First(&pin, "id = ?", resourceID).Error; err != nil {
+			log.Printf("[activity-logging] failed to fetch search pin %s: %v", resourceID, err)
+			return nil
+		}
+		return pin
+
 	case models.ResourceTypeCategory:
 		var category models.Category
 		if err := config.CmsGorm.WithContext(ctx).First(&category, "id = ?", resourceID).Error; err != nil {
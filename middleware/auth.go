@@ -54,6 +54,30 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware sets the user info like AuthMiddleware when a valid token is
+// present, but lets anonymous requests through (for guest-friendly routes)
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := c.Cookie("auth_token")
+		if err != nil || token == "" {
+			parts := strings.Split(c.GetHeader("Authorization"), " ")
+			if len(parts) == 2 && parts[0] == "Bearer" {
+				token = parts[1]
+			}
+		}
+
+		if token != "" {
+			if claims, err := utils.ValidateJWT(token); err == nil {
+				c.Set("userID", claims.UserID)
+				c.Set("userEmail", claims.Email)
+				c.Set("userName", claims.Name)
+			}
+		}
+
+		c.Next()
+	}
+}
+
 // Helper functions remain the same
 func GetUserIDFromContext(c *gin.Context) (string, bool) {
 	userID, exists := c.Get("userID")
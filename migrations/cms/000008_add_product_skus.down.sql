@@ -0,0 +1,11 @@
+-- Migration Down: Remove product SKUs
+
+-- Drop trigger and function
+DROP TRIGGER IF EXISTS trigger_sync_product_skus ON products;
+DROP FUNCTION IF EXISTS sync_product_skus();
+
+-- Drop lookup table
+DROP TABLE IF EXISTS product_skus;
+
+-- Remove sku_pattern column
+ALTER TABLE products DROP COLUMN IF EXISTS sku_pattern;
@@ -0,0 +1,64 @@
+-- Migration: Add SKUs and barcodes to product inventory
+-- Up: Add products.sku_pattern, backfill SKUs on existing inventory combos
+--     and keep a product_skus lookup table in sync with products.inventory
+-- Down: Drop the lookup table, trigger and column
+
+-- Per-product SKU pattern (empty = use the default pattern)
+ALTER TABLE products ADD COLUMN sku_pattern TEXT NOT NULL DEFAULT '';
+
+-- Lookup table: one row per inventory combo, SKUs and barcodes are unique catalogue-wide
+CREATE TABLE product_skus (
+    sku VARCHAR(100) PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_name TEXT NOT NULL DEFAULT '',
+    combo JSONB NOT NULL DEFAULT '[]'::jsonb,
+    barcode VARCHAR(14),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_product_skus_sku_lower ON product_skus (LOWER(sku));
+CREATE UNIQUE INDEX idx_product_skus_barcode ON product_skus (barcode) WHERE barcode IS NOT NULL;
+CREATE INDEX idx_product_skus_product_id ON product_skus (product_id);
+
+-- Rebuild a product's rows in product_skus from its inventory JSONB
+CREATE OR REPLACE FUNCTION sync_product_skus()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM product_skus WHERE product_id = NEW.id;
+
+    INSERT INTO product_skus (sku, product_id, variant_name, combo, barcode)
+    SELECT
+        item->>'sku',
+        NEW.id,
+        COALESCE(item->>'variant_name', ''),
+        COALESCE(item->'combo', '[]'::jsonb),
+        NULLIF(item->>'barcode', '')
+    FROM jsonb_array_elements(COALESCE(NEW.inventory, '[]'::jsonb)) AS item
+    WHERE COALESCE(item->>'sku', '') <> '';
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_sync_product_skus
+    AFTER INSERT OR UPDATE OF inventory ON products
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_product_skus();
+
+-- Backfill: give every existing combo a stable SKU (MOD-<id suffix>-<position>)
+UPDATE products p
+SET inventory = (
+    SELECT jsonb_agg(
+        CASE
+            WHEN COALESCE(item->>'sku', '') <> '' THEN item
+            ELSE item || jsonb_build_object(
+                'sku',
+                'MOD-' || UPPER(RIGHT(REPLACE(p.id::text, '-', ''), 10)) || '-' || LPAD(pos::text, 2, '0')
+            )
+        END
+        ORDER BY pos
+    )
+    FROM jsonb_array_elements(p.inventory) WITH ORDINALITY AS t(item, pos)
+)
+WHERE jsonb_typeof(p.inventory) = 'array'
+  AND jsonb_array_length(p.inventory) > 0;
@@ -0,0 +1,8 @@
+-- Migration Down: Drop stock_movements ledger
+
+-- Drop trigger and function
+DROP TRIGGER IF EXISTS trigger_prevent_stock_movement_update ON stock_movements;
+DROP FUNCTION IF EXISTS prevent_stock_movement_update();
+
+-- Drop table
+DROP TABLE IF EXISTS stock_movements;
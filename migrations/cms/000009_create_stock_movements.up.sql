@@ -0,0 +1,58 @@
+-- Migration: Create stock_movements ledger
+-- Up: Append-only table recording every change to an inventory combo's quantity,
+--     seeded with an "initial" movement for the stock currently on hand
+-- Down: Drop the table
+
+CREATE TABLE stock_movements (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(100),
+    variant_name TEXT NOT NULL,
+    combo JSONB NOT NULL DEFAULT '[]'::jsonb,
+    delta INT NOT NULL CHECK (delta <> 0),
+    quantity_after INT NOT NULL CHECK (quantity_after >= 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN (
+        'initial', 'sale', 'cancellation', 'return', 'damage', 'adjustment', 'correction', 'restock'
+    )),
+    reference_type VARCHAR(30),
+    reference_id TEXT,
+    note TEXT,
+    admin_id UUID,
+    admin_email TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_movements_product_date ON stock_movements (product_id, created_at DESC);
+CREATE INDEX idx_stock_movements_sku ON stock_movements (sku);
+CREATE INDEX idx_stock_movements_reason ON stock_movements (reason);
+CREATE INDEX idx_stock_movements_reference ON stock_movements (reference_type, reference_id);
+
+-- Ledger entries are never edited
+CREATE OR REPLACE FUNCTION prevent_stock_movement_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_prevent_stock_movement_update
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_stock_movement_update();
+
+-- Seed opening balances from the current inventory
+INSERT INTO stock_movements (id, product_id, sku, variant_name, combo, delta, quantity_after, reason, note)
+SELECT
+    gen_random_uuid(),
+    p.id,
+    NULLIF(item->>'sku', ''),
+    COALESCE(item->>'variant_name', ''),
+    COALESCE(item->'combo', '[]'::jsonb),
+    (item->>'quantity')::int,
+    (item->>'quantity')::int,
+    'initial',
+    'Opening balance'
+FROM products p
+CROSS JOIN LATERAL jsonb_array_elements(p.inventory) AS item
+WHERE jsonb_typeof(p.inventory) = 'array'
+  AND COALESCE((item->>'quantity')::int, 0) > 0;
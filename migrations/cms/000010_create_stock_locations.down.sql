@@ -0,0 +1,19 @@
+-- Migration Down: Remove multi-location inventory
+
+-- Restore reason check (transfer movements have to go first)
+DELETE FROM stock_movements WHERE reason = 'transfer';
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_reason_check CHECK (reason IN (
+    'initial', 'sale', 'cancellation', 'return', 'damage', 'adjustment', 'correction', 'restock'
+));
+
+-- Remove location columns from movements
+DROP INDEX IF EXISTS idx_stock_movements_location;
+ALTER TABLE stock_movements
+    DROP COLUMN IF EXISTS location_quantity_after,
+    DROP COLUMN IF EXISTS location_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS location_stock;
+DROP TRIGGER IF EXISTS trigger_stock_locations_updated_at ON stock_locations;
+DROP TABLE IF EXISTS stock_locations;
@@ -0,0 +1,73 @@
+-- Migration: Multi-location inventory
+-- Up: Create stock_locations and per-location quantities (location_stock), move all
+--     current stock into a default location and tag stock movements with a location.
+--     products.inventory keeps the total across all locations.
+-- Down: Drop the tables and the stock_movements location columns
+
+CREATE TABLE stock_locations (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    code VARCHAR(30) NOT NULL UNIQUE,
+    type VARCHAR(20) NOT NULL DEFAULT 'warehouse',
+    address TEXT NOT NULL DEFAULT '',
+    priority INT NOT NULL DEFAULT 100, -- Lower = allocated first at checkout
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (type IN ('warehouse', 'store', 'popup'))
+);
+
+-- Only one default location
+CREATE UNIQUE INDEX idx_stock_locations_default ON stock_locations (is_default) WHERE is_default;
+CREATE INDEX idx_stock_locations_priority ON stock_locations (priority);
+
+CREATE TRIGGER trigger_stock_locations_updated_at
+    BEFORE UPDATE ON stock_locations
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE location_stock (
+    location_id UUID NOT NULL REFERENCES stock_locations(id) ON DELETE RESTRICT,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(100) NOT NULL,
+    quantity INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (location_id, product_id, sku)
+);
+
+CREATE INDEX idx_location_stock_product_sku ON location_stock (product_id, sku);
+
+-- Default location holds all existing stock
+INSERT INTO stock_locations (id, name, code, type, priority, is_default)
+VALUES (gen_random_uuid(), 'Main Warehouse', 'MAIN', 'warehouse', 0, TRUE);
+
+INSERT INTO location_stock (location_id, product_id, sku, quantity)
+SELECT
+    (SELECT id FROM stock_locations WHERE is_default),
+    p.id,
+    item->>'sku',
+    (item->>'quantity')::int
+FROM products p
+CROSS JOIN LATERAL jsonb_array_elements(p.inventory) AS item
+WHERE jsonb_typeof(p.inventory) = 'array'
+  AND COALESCE(item->>'sku', '') <> ''
+  AND COALESCE((item->>'quantity')::int, 0) > 0;
+
+-- Tag movements with the location they happened at
+ALTER TABLE stock_movements
+    ADD COLUMN location_id UUID REFERENCES stock_locations(id) ON DELETE SET NULL,
+    ADD COLUMN location_quantity_after INT;
+
+CREATE INDEX idx_stock_movements_location ON stock_movements (location_id);
+
+ALTER TABLE stock_movements DISABLE TRIGGER trigger_prevent_stock_movement_update;
+UPDATE stock_movements
+SET location_id = (SELECT id FROM stock_locations WHERE is_default);
+ALTER TABLE stock_movements ENABLE TRIGGER trigger_prevent_stock_movement_update;
+
+-- Transfers between locations
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_reason_check CHECK (reason IN (
+    'initial', 'sale', 'cancellation', 'return', 'damage', 'adjustment', 'correction', 'restock', 'transfer'
+));
@@ -0,0 +1,7 @@
+-- Migration Down: Remove low-stock thresholds and alerts
+
+DROP TRIGGER IF EXISTS trigger_stock_alerts_updated_at ON stock_alerts;
+DROP TABLE IF EXISTS stock_alerts;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS low_stock_threshold;
+ALTER TABLE products DROP COLUMN IF EXISTS low_stock_threshold;
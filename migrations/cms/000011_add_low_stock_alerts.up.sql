@@ -0,0 +1,34 @@
+-- Migration: Low-stock thresholds and restock alerts
+-- Up: Add an optional low_stock_threshold to products and categories and create
+--     stock_alerts. A combo is low when its quantity is below the effective threshold:
+--     product → subcategory → parent category → default (5).
+-- Down: Drop stock_alerts and the threshold columns
+
+ALTER TABLE products ADD COLUMN low_stock_threshold INT CHECK (low_stock_threshold >= 0);
+ALTER TABLE categories ADD COLUMN low_stock_threshold INT CHECK (low_stock_threshold >= 0);
+
+CREATE TABLE stock_alerts (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(100) NOT NULL,
+    variant_name TEXT NOT NULL,
+    quantity INT NOT NULL,  -- Combo quantity when last checked
+    threshold INT NOT NULL, -- Effective threshold when last checked
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    dismissed_at TIMESTAMPTZ,
+    dismissed_by UUID,
+    resolved_at TIMESTAMPTZ,
+    emailed_at TIMESTAMPTZ,  -- Set once the alert has gone out in a digest
+    CHECK (status IN ('open', 'dismissed', 'resolved'))
+);
+
+-- One live alert per combo; a dismissed alert stays live until the combo is restocked
+CREATE UNIQUE INDEX idx_stock_alerts_live ON stock_alerts (product_id, sku) WHERE status IN ('open', 'dismissed');
+CREATE INDEX idx_stock_alerts_status_created ON stock_alerts (status, created_at DESC);
+
+CREATE TRIGGER trigger_stock_alerts_updated_at
+    BEFORE UPDATE ON stock_alerts
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
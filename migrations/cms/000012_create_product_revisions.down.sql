@@ -0,0 +1,3 @@
+-- Migration Down: Drop product_revisions
+
+DROP TABLE IF EXISTS product_revisions;
@@ -0,0 +1,47 @@
+-- Migration: Create product_revisions
+-- Up: Append-only snapshots of a product's editable fields, one per save
+--     (create, update, import, restore), numbered per product. Stock quantities are
+--     included for reference but owned by stock_movements. Every existing product is
+--     seeded with revision 1 so the first edit has something to diff against.
+-- Down: Drop the table
+
+CREATE TABLE product_revisions (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    revision INT NOT NULL CHECK (revision > 0),
+    snapshot JSONB NOT NULL,
+    changed_fields JSONB NOT NULL DEFAULT '[]'::jsonb, -- Top-level fields changed since the previous revision
+    source VARCHAR(20) NOT NULL CHECK (source IN ('initial', 'create', 'update', 'import', 'restore')),
+    restored_from INT, -- Revision number a restore copied
+    admin_id UUID,
+    admin_email TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (product_id, revision)
+);
+
+CREATE INDEX idx_product_revisions_product ON product_revisions (product_id, revision DESC);
+
+-- Seed the current state of every product
+INSERT INTO product_revisions (id, product_id, revision, snapshot, source, created_at)
+SELECT
+    gen_random_uuid(),
+    p.id,
+    1,
+    jsonb_build_object(
+        'name', p.name,
+        'description', p.description,
+        'price', p.price,
+        'status', p.status,
+        'sub_category_id', p.sub_category_id,
+        'tags', p.tags,
+        'composition', p.composition,
+        'media', p.media,
+        'variants', p.variants,
+        'inventory', p.inventory,
+        'sku_pattern', p.sku_pattern,
+        'low_stock_threshold', p.low_stock_threshold,
+        'seo', p.seo
+    ),
+    'initial',
+    p.updated_at
+FROM products p;
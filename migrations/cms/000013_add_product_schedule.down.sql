@@ -0,0 +1,12 @@
+-- Migration Down: Remove scheduled publishing
+
+UPDATE product_revisions SET source = 'update' WHERE source = 'schedule';
+ALTER TABLE product_revisions DROP CONSTRAINT product_revisions_source_check;
+ALTER TABLE product_revisions ADD CONSTRAINT product_revisions_source_check
+    CHECK (source IN ('initial', 'create', 'update', 'import', 'restore'));
+
+DROP INDEX IF EXISTS idx_products_unpublish_at;
+DROP INDEX IF EXISTS idx_products_publish_at;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_schedule_order_check;
+ALTER TABLE products DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE products DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,18 @@
+-- Migration: Scheduled publishing
+-- Up: Add publish_at/unpublish_at to products. The product scheduler sets a Draft product
+--     Active once publish_at passes, and an Active product back to Draft once unpublish_at
+--     passes, clearing the column it acted on. Adds the 'schedule' revision source.
+-- Down: Drop the schedule columns and the 'schedule' revision source
+
+ALTER TABLE products ADD COLUMN publish_at TIMESTAMPTZ;
+ALTER TABLE products ADD COLUMN unpublish_at TIMESTAMPTZ;
+ALTER TABLE products ADD CONSTRAINT products_schedule_order_check
+    CHECK (publish_at IS NULL OR unpublish_at IS NULL OR unpublish_at > publish_at);
+
+-- The scheduler only looks at products with something pending
+CREATE INDEX idx_products_publish_at ON products (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX idx_products_unpublish_at ON products (unpublish_at) WHERE unpublish_at IS NOT NULL;
+
+ALTER TABLE product_revisions DROP CONSTRAINT product_revisions_source_check;
+ALTER TABLE product_revisions ADD CONSTRAINT product_revisions_source_check
+    CHECK (source IN ('initial', 'create', 'update', 'import', 'restore', 'schedule'));
@@ -0,0 +1,7 @@
+-- Migration Down: Remove product trash
+
+DELETE FROM products WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_products_deleted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE products DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,11 @@
+-- Migration: Product trash
+-- Up: Soft-delete products. Deleting a product sets deleted_at (and deleted_by); trashed
+--     products are hidden from the CMS and storefront but keep their SKUs, revisions and
+--     stock history so they can be restored. The trash purge job removes them for good
+--     (and their media) once the retention period has passed.
+-- Down: Permanently delete trashed products and drop the columns
+
+ALTER TABLE products ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE products ADD COLUMN deleted_by UUID; -- Admin who trashed it
+
+CREATE INDEX idx_products_deleted_at ON products (deleted_at) WHERE deleted_at IS NOT NULL;
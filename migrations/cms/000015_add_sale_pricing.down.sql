@@ -0,0 +1,15 @@
+-- Migration Down: Remove sale pricing
+
+UPDATE products
+SET inventory = (
+    SELECT COALESCE(jsonb_agg(item - 'sale_price' - 'sale_starts_at' - 'sale_ends_at' ORDER BY position), '[]'::jsonb)
+    FROM jsonb_array_elements(inventory) WITH ORDINALITY AS items(item, position)
+)
+WHERE inventory @? '$[*].sale_price';
+
+DROP INDEX IF EXISTS idx_products_sale_price;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_sale_window_check;
+ALTER TABLE products DROP COLUMN IF EXISTS sale_ends_at;
+ALTER TABLE products DROP COLUMN IF EXISTS sale_starts_at;
+ALTER TABLE products DROP COLUMN IF EXISTS sale_price;
+ALTER TABLE products DROP COLUMN IF EXISTS compare_at_price;
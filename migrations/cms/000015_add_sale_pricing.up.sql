@@ -0,0 +1,15 @@
+-- Migration: Sale pricing
+-- Up: Add a compare-at price and a scheduled sale (price + optional start/end) to
+--     products. Inventory combos can carry their own sale in the inventory JSONB
+--     (sale_price, sale_starts_at, sale_ends_at). The price charged is the lowest of the
+--     regular price and the sales that apply at the time; nothing rewrites price.
+-- Down: Drop the sale columns and strip combo sales from inventory
+
+ALTER TABLE products ADD COLUMN compare_at_price NUMERIC(12,2) CHECK (compare_at_price >= 0);
+ALTER TABLE products ADD COLUMN sale_price NUMERIC(12,2) CHECK (sale_price >= 0);
+ALTER TABLE products ADD COLUMN sale_starts_at TIMESTAMPTZ;
+ALTER TABLE products ADD COLUMN sale_ends_at TIMESTAMPTZ;
+ALTER TABLE products ADD CONSTRAINT products_sale_window_check
+    CHECK (sale_starts_at IS NULL OR sale_ends_at IS NULL OR sale_ends_at > sale_starts_at);
+
+CREATE INDEX idx_products_sale_price ON products (sale_ends_at) WHERE sale_price IS NOT NULL;
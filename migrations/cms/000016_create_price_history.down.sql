@@ -0,0 +1,3 @@
+-- Migration Down: Drop price_history
+
+DROP TABLE IF EXISTS price_history;
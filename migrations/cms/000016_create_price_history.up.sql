@@ -0,0 +1,43 @@
+-- Migration: Create price_history
+-- Up: One row each time the pricing of a product (price, compare-at price, sale and
+--     sale window) or of an inventory combo (its own sale, keyed by SKU) changes, so the
+--     price charged at any past moment can be worked out (e.g. the lowest price of the
+--     30 days before a sale, for EU price disclosure). Every existing product, and every
+--     combo with a sale, is seeded with its current pricing.
+-- Down: Drop the table
+
+CREATE TABLE price_history (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku TEXT, -- NULL for the product's own pricing, else the combo
+    price NUMERIC(12,2) NOT NULL, -- Product price at the time (for combos too)
+    compare_at_price NUMERIC(12,2),
+    sale_price NUMERIC(12,2),
+    sale_starts_at TIMESTAMPTZ,
+    sale_ends_at TIMESTAMPTZ,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('initial', 'create', 'update', 'import', 'restore')),
+    admin_id UUID,
+    admin_email TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_price_history_product ON price_history (product_id, created_at DESC);
+
+-- Seed the current pricing of every product...
+INSERT INTO price_history (id, product_id, sku, price, compare_at_price, sale_price, sale_starts_at, sale_ends_at, source)
+SELECT gen_random_uuid(), p.id, NULL, p.price, p.compare_at_price, p.sale_price, p.sale_starts_at, p.sale_ends_at, 'initial'
+FROM products p;
+
+-- ...and of every combo with its own sale
+INSERT INTO price_history (id, product_id, sku, price, sale_price, sale_starts_at, sale_ends_at, source)
+SELECT
+    gen_random_uuid(),
+    p.id,
+    inv->>'sku',
+    p.price,
+    (inv->>'sale_price')::numeric,
+    (inv->>'sale_starts_at')::timestamptz,
+    (inv->>'sale_ends_at')::timestamptz,
+    'initial'
+FROM products p, jsonb_array_elements(p.inventory) AS inv
+WHERE inv->>'sale_price' IS NOT NULL AND COALESCE(inv->>'sku', '') <> '';
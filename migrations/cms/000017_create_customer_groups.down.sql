@@ -0,0 +1,5 @@
+-- Migration Down: Drop customer groups, group price lists and quantity tiers
+
+DROP TABLE IF EXISTS quantity_tiers;
+DROP TABLE IF EXISTS customer_group_prices;
+DROP TABLE IF EXISTS customer_groups;
@@ -0,0 +1,41 @@
+-- Migration: Customer groups, group price lists and quantity tiers
+-- Up: Customer groups (e.g. wholesale stockists) are assigned to customers in the
+--     ecommerce database (users.customer_group_id). A group's price list overrides
+--     products.price for its members; quantity tiers take a percentage off a line once
+--     enough units of a product are ordered, for everyone or for one group, on one
+--     product or on all of them.
+-- Down: Drop the tables
+
+CREATE TABLE customer_groups (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE customer_group_prices (
+    customer_group_id UUID NOT NULL REFERENCES customer_groups(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price NUMERIC(12,2) NOT NULL CHECK (price >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (customer_group_id, product_id)
+);
+
+CREATE INDEX idx_customer_group_prices_product ON customer_group_prices (product_id);
+
+CREATE TABLE quantity_tiers (
+    id UUID PRIMARY KEY,
+    customer_group_id UUID REFERENCES customer_groups(id) ON DELETE CASCADE, -- NULL: every customer
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,               -- NULL: every product
+    min_quantity INT NOT NULL CHECK (min_quantity > 1),
+    discount_percent NUMERIC(5,2) NOT NULL CHECK (discount_percent > 0 AND discount_percent < 100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One tier per threshold per scope (NULLs count as a scope of their own)
+CREATE UNIQUE INDEX idx_quantity_tiers_scope ON quantity_tiers (
+    COALESCE(customer_group_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    COALESCE(product_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    min_quantity
+);
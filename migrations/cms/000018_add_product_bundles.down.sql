@@ -0,0 +1,6 @@
+-- Migration Down: Remove product bundles
+
+DROP INDEX IF EXISTS idx_products_bundle_components;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_bundle_components_check;
+ALTER TABLE products DROP COLUMN IF EXISTS bundle_components;
+ALTER TABLE products DROP COLUMN IF EXISTS product_type;
@@ -0,0 +1,15 @@
+-- Migration: Product bundles
+-- Up: Add a product type and, for bundles, their components (other products' inventory
+--     combos, by SKU, with a quantity each) as JSONB. Bundles hold no stock of their
+--     own: availability comes from the components and orders deduct from them.
+-- Down: Drop the columns (bundles become empty standard products)
+
+ALTER TABLE products ADD COLUMN product_type VARCHAR(20) NOT NULL DEFAULT 'standard'
+    CHECK (product_type IN ('standard', 'bundle'));
+ALTER TABLE products ADD COLUMN bundle_components JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE products ADD CONSTRAINT products_bundle_components_check
+    CHECK (product_type = 'bundle' OR bundle_components = '[]'::jsonb);
+
+-- Finding the bundles a product is part of
+CREATE INDEX idx_products_bundle_components ON products USING GIN (bundle_components jsonb_path_ops)
+    WHERE product_type = 'bundle';
@@ -0,0 +1,3 @@
+-- Migration Down: Remove product quantity rules
+
+ALTER TABLE products DROP COLUMN IF EXISTS quantity_rules;
@@ -0,0 +1,7 @@
+-- Migration: Product quantity rules
+-- Up: Add per-product purchase quantity rules as JSONB: min/max units per order, a max
+--     per customer (optionally over a rolling window of days) and a step (sold in
+--     multiples of it, e.g. packs of 3). '{}' means no rules.
+-- Down: Drop the column
+
+ALTER TABLE products ADD COLUMN quantity_rules JSONB NOT NULL DEFAULT '{}';
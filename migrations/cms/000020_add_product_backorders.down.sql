@@ -0,0 +1,3 @@
+-- Migration Down: Remove backorder settings
+
+ALTER TABLE products DROP COLUMN IF EXISTS backorder;
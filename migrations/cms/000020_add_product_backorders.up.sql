@@ -0,0 +1,8 @@
+-- Migration: Backorders and pre-orders
+-- Up: Add per-product backorder settings as JSONB: a mode (none, backorder or preorder),
+--     an expected ship date (required for pre-orders) and an optional cap on the units
+--     that can be on backorder per combo. Combos can override them in their inventory
+--     entry ("backorder"). '{}' means no backorders.
+-- Down: Drop the column (combo overrides stay in the inventory JSONB, unused)
+
+ALTER TABLE products ADD COLUMN backorder JSONB NOT NULL DEFAULT '{}';
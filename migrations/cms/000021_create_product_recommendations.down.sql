@@ -0,0 +1,4 @@
+-- Migration Down: Remove product recommendations
+
+DROP TABLE IF EXISTS product_co_purchases;
+DROP TABLE IF EXISTS related_products;
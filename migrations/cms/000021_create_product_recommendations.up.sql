@@ -0,0 +1,26 @@
+-- Migration: Product recommendations
+-- Up: Related products curated in the CMS, in display order, and "frequently bought
+--     together" pairs mined from completed orders in the ecommerce database. The pairs
+--     are rebuilt by a periodic job; both directions of a pair are stored.
+-- Down: Drop the tables
+
+CREATE TABLE related_products (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    related_product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, related_product_id),
+    CHECK (product_id <> related_product_id)
+);
+
+CREATE TABLE product_co_purchases (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    related_product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    orders INT NOT NULL CHECK (orders > 0),                                    -- Completed orders with both
+    confidence NUMERIC(5,4) NOT NULL CHECK (confidence > 0 AND confidence <= 1), -- Share of product_id's orders
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, related_product_id),
+    CHECK (product_id <> related_product_id)
+);
+
+CREATE INDEX idx_product_co_purchases_rank ON product_co_purchases (product_id, orders DESC, confidence DESC);
@@ -0,0 +1,5 @@
+-- Migration Down: Remove product ratings
+
+DROP INDEX IF EXISTS idx_products_rating;
+ALTER TABLE products DROP COLUMN IF EXISTS rating_count;
+ALTER TABLE products DROP COLUMN IF EXISTS rating_average;
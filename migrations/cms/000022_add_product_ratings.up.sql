@@ -0,0 +1,14 @@
+-- Migration: Product ratings
+-- Up: Keep each product's average rating and number of approved reviews on the product,
+--     so storefront listings can show, sort and filter by rating. Reviews live in the
+--     ecommerce database; these are recalculated whenever a review is approved, rejected
+--     or an approved review changes.
+-- Down: Drop the columns
+
+ALTER TABLE products ADD COLUMN rating_average NUMERIC(3,2) NOT NULL DEFAULT 0
+    CHECK (rating_average >= 0 AND rating_average <= 5);
+ALTER TABLE products ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0
+    CHECK (rating_count >= 0);
+
+CREATE INDEX idx_products_rating ON products (rating_average DESC, rating_count DESC)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+-- Migration Down: Remove full-text product search
+
+DROP TRIGGER IF EXISTS categories_search_vector_update ON categories;
+DROP TRIGGER IF EXISTS products_search_vector_update ON products;
+DROP FUNCTION IF EXISTS categories_search_vector_trigger();
+DROP FUNCTION IF EXISTS products_search_vector_trigger();
+DROP FUNCTION IF EXISTS product_search_vector(text, jsonb, uuid, text);
+DROP INDEX IF EXISTS idx_products_search_vector;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,66 @@
+-- Migration: Full-text product search
+-- Up: Keep a weighted tsvector on each product (name A, tags B, category path C,
+--     description D; English stemming so "dresses" matches "dress") with a GIN index.
+--     Triggers keep it current when a product's fields change or its category is renamed.
+--     pg_trgm provides word_similarity for the typo-tolerant fallback on product names.
+-- Down: Drop the triggers, functions, column, index and extension
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE products ADD COLUMN search_vector tsvector NOT NULL DEFAULT ''::tsvector;
+
+-- The weighted document of one product; the category path is "Parent Subcategory"
+CREATE OR REPLACE FUNCTION product_search_vector(
+    p_name text,
+    p_tags jsonb,
+    p_sub_category_id uuid,
+    p_description text
+) RETURNS tsvector AS $$
+    SELECT
+        setweight(to_tsvector('english', COALESCE(p_name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(
+            (SELECT string_agg(tag, ' ') FROM jsonb_array_elements_text(COALESCE(p_tags, '[]'::jsonb)) AS tag),
+            ''
+        )), 'B') ||
+        setweight(to_tsvector('english', COALESCE(
+            (SELECT concat_ws(' ', c.parent_name, c.name) FROM categories c WHERE c.id = p_sub_category_id),
+            ''
+        )), 'C') ||
+        setweight(to_tsvector('english', COALESCE(p_description, '')), 'D')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION products_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := product_search_vector(NEW.name, NEW.tags, NEW.sub_category_id, NEW.description);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_search_vector_update
+    BEFORE INSERT OR UPDATE OF name, tags, sub_category_id, description ON products
+    FOR EACH ROW EXECUTE FUNCTION products_search_vector_trigger();
+
+-- Renaming a subcategory changes the category path of its products (renaming a parent
+-- updates its children's parent_name, which lands here too)
+CREATE OR REPLACE FUNCTION categories_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE products p
+    SET search_vector = product_search_vector(p.name, p.tags, p.sub_category_id, p.description)
+    WHERE p.sub_category_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories_search_vector_update
+    AFTER UPDATE OF name, parent_name ON categories
+    FOR EACH ROW
+    WHEN (OLD.name IS DISTINCT FROM NEW.name OR OLD.parent_name IS DISTINCT FROM NEW.parent_name)
+    EXECUTE FUNCTION categories_search_vector_trigger();
+
+-- Backfill
+UPDATE products
+SET search_vector = product_search_vector(name, tags, sub_category_id, description);
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
@@ -0,0 +1,4 @@
+-- Migration Down: Remove search suggestion indexes
+
+DROP INDEX IF EXISTS idx_categories_name_trgm;
+DROP INDEX IF EXISTS idx_products_name_trgm;
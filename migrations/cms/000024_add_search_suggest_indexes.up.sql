@@ -0,0 +1,9 @@
+-- Migration: Search suggestion indexes
+-- Up: Trigram indexes on lower(name) of products and categories, so the name prefix
+--     lookups behind search suggestions ("lower(name) LIKE 'dre%' OR LIKE '% dre%'") stay
+--     fast when the Redis suggestion index isn't available.
+-- Down: Drop the indexes
+
+CREATE INDEX idx_products_name_trgm ON products USING GIN (lower(name) gin_trgm_ops)
+    WHERE deleted_at IS NULL;
+CREATE INDEX idx_categories_name_trgm ON categories USING GIN (lower(name) gin_trgm_ops);
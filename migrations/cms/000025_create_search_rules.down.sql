@@ -0,0 +1,5 @@
+-- Migration Down: Remove search rules
+
+DROP TABLE IF EXISTS search_pins;
+DROP TABLE IF EXISTS search_redirects;
+DROP TABLE IF EXISTS search_synonyms;
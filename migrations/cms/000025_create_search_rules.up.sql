@@ -0,0 +1,37 @@
+-- Migration: Search rules
+-- Up: Merchandising rules the storefront search applies, managed in the CMS:
+--     - search_synonyms: groups of interchangeable terms ("sneakers", "trainers"); a search
+--       for any of them matches all of them
+--     - search_redirects: searches that send the customer to a page instead ("sale" → /sale)
+--     - search_pins: products pinned to the top of (or buried at the bottom of) the results
+--       of a search, in position order
+--     Queries and terms are stored lowercased with single spaces, as searches are matched.
+-- Down: Drop the tables
+
+CREATE TABLE search_synonyms (
+    id         UUID PRIMARY KEY,
+    terms      JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE search_redirects (
+    id         UUID PRIMARY KEY,
+    query      VARCHAR(100) NOT NULL UNIQUE,
+    url        VARCHAR(500) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE search_pins (
+    id         UUID PRIMARY KEY,
+    query      VARCHAR(100) NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    action     VARCHAR(10) NOT NULL CHECK (action IN ('pin', 'bury')),
+    position   INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (query, product_id)
+);
+
+CREATE INDEX idx_search_pins_query ON search_pins (query, action, position);
@@ -0,0 +1,3 @@
+-- Migration Down: Remove search logs
+
+DROP TABLE IF EXISTS search_logs;
@@ -0,0 +1,24 @@
+-- Migration: Search logs
+-- Up: Log each storefront search (the first page of results) and search box suggestion
+--     lookup: the normalized query, how many results it found, the filters used, and the
+--     clicks on its results (counted through POST /store/search/{id}/click). The CMS search
+--     analytics (top, zero-result and low click-through queries) read from here.
+--     user_id is the logged-in customer (ecommerce DB), clicked_product_id the first
+--     product clicked; neither is a foreign key so logs outlive both.
+-- Down: Drop the table
+
+CREATE TABLE search_logs (
+    id                 UUID PRIMARY KEY,
+    query              VARCHAR(100) NOT NULL,
+    source             VARCHAR(20) NOT NULL CHECK (source IN ('search', 'suggest')),
+    result_count       INTEGER NOT NULL DEFAULT 0,
+    filters            JSONB NOT NULL DEFAULT '{}',
+    user_id            UUID,
+    clicks             INTEGER NOT NULL DEFAULT 0,
+    clicked_product_id UUID,
+    first_clicked_at   TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_search_logs_created ON search_logs (source, created_at);
+CREATE INDEX idx_search_logs_query ON search_logs (query, created_at);
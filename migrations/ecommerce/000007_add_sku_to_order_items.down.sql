@@ -0,0 +1,7 @@
+-- Migration Down: Remove sku column from order_items
+
+-- Drop index first
+DROP INDEX IF EXISTS idx_order_items_sku;
+
+-- Remove sku column
+ALTER TABLE order_items DROP COLUMN sku;
@@ -0,0 +1,9 @@
+-- Migration: Add sku column to order_items
+-- Up: Store the SKU of the purchased inventory combo on each order line
+-- Down: Remove the column
+
+-- Add sku column (NULL for orders placed before SKUs existed)
+ALTER TABLE order_items ADD COLUMN sku VARCHAR(100);
+
+-- Create index for SKU lookups
+CREATE INDEX idx_order_items_sku ON order_items(sku);
@@ -0,0 +1,2 @@
+-- Drop back-in-stock subscriptions
+DROP TABLE IF EXISTS back_in_stock_subscriptions;
@@ -0,0 +1,29 @@
+-- Migration: Back-in-stock notification subscriptions
+-- Up: Shoppers (guest email or logged-in user) subscribe to a sold-out inventory combo
+--     and are emailed once when it is back in stock
+-- Down: Drop the table
+
+CREATE TABLE back_in_stock_subscriptions (
+    id                uuid PRIMARY KEY,
+    product_id        uuid NOT NULL,          -- CMS product (no FK across databases)
+    sku               varchar(100) NOT NULL,
+    variant_name      varchar(255) NOT NULL,
+    email             varchar(255) NOT NULL,
+    user_id           uuid,                   -- Set when subscribed while logged in
+    unsubscribe_token varchar(64) NOT NULL,
+    status            varchar(20) NOT NULL DEFAULT 'pending',
+    created_at        timestamp without time zone NOT NULL DEFAULT now(),
+    notified_at       timestamp without time zone,
+    unsubscribed_at   timestamp without time zone,
+
+    CONSTRAINT back_in_stock_subscriptions_user_id_fkey FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT back_in_stock_subscriptions_status_check CHECK (status IN ('pending', 'notified', 'unsubscribed'))
+);
+
+-- One pending subscription per email and combo
+CREATE UNIQUE INDEX idx_back_in_stock_pending
+    ON back_in_stock_subscriptions (product_id, sku, LOWER(email))
+    WHERE status = 'pending';
+CREATE UNIQUE INDEX idx_back_in_stock_unsubscribe_token ON back_in_stock_subscriptions (unsubscribe_token);
+CREATE INDEX idx_back_in_stock_product_status ON back_in_stock_subscriptions (product_id, status);
@@ -0,0 +1,7 @@
+-- Migration Down: Remove customer_group_id column from users
+
+-- Drop index first
+DROP INDEX IF EXISTS idx_users_customer_group;
+
+-- Remove customer_group_id column
+ALTER TABLE users DROP COLUMN customer_group_id;
@@ -0,0 +1,9 @@
+-- Migration: Add customer_group_id column to users
+-- Up: Assign a customer to a CMS customer group (wholesale pricing)
+-- Down: Remove the column
+
+-- Add customer_group_id column (CMS customer group; no FK across databases)
+ALTER TABLE users ADD COLUMN customer_group_id UUID;
+
+-- Create index for group member lookups
+CREATE INDEX idx_users_customer_group ON users(customer_group_id) WHERE customer_group_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Migration Down: Remove bundle_components column from order_items
+
+ALTER TABLE order_items DROP COLUMN IF EXISTS bundle_components;
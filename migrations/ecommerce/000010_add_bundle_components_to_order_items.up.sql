@@ -0,0 +1,7 @@
+-- Migration: Add bundle components to order_items
+-- Up: Snapshot the components of a bundle on its order line (product, SKU, variant and
+--     quantity per bundle), so orders, invoices and packing slips can list them
+-- Down: Remove the column
+
+-- NULL for lines that aren't bundles
+ALTER TABLE order_items ADD COLUMN bundle_components JSONB;
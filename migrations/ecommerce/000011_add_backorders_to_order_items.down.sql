@@ -0,0 +1,6 @@
+-- Migration Down: Remove backorders from order_items
+
+DROP INDEX IF EXISTS idx_order_items_backordered;
+ALTER TABLE order_items DROP COLUMN IF EXISTS expected_ship_date;
+ALTER TABLE order_items DROP COLUMN IF EXISTS backorder_type;
+ALTER TABLE order_items DROP COLUMN IF EXISTS backordered_quantity;
@@ -0,0 +1,15 @@
+-- Migration: Add backorders to order_items
+-- Up: Record how many units of a line were ordered beyond stock, whether as a backorder
+--     or a pre-order, and when they were expected to ship. Those units aren't taken out
+--     of stock at checkout but when the order ships, which sets the count back to 0.
+-- Down: Remove the columns
+
+ALTER TABLE order_items ADD COLUMN backordered_quantity INTEGER NOT NULL DEFAULT 0
+    CHECK (backordered_quantity >= 0 AND backordered_quantity <= quantity);
+ALTER TABLE order_items ADD COLUMN backorder_type VARCHAR(20)
+    CHECK (backorder_type IN ('backorder', 'preorder'));
+ALTER TABLE order_items ADD COLUMN expected_ship_date TIMESTAMPTZ;
+
+-- Outstanding backorders per combo (checkout availability and the backorder report)
+CREATE INDEX idx_order_items_backordered ON order_items (product_id, sku)
+    WHERE backordered_quantity > 0;
@@ -0,0 +1,3 @@
+-- Migration Down: Drop product reviews
+
+DROP TABLE IF EXISTS product_reviews;
@@ -0,0 +1,36 @@
+-- Migration: Product reviews
+-- Up: Verified-purchase reviews: a customer with a completed order containing the
+--     product can leave one review of it (rating, title, body and photo URLs). Reviews
+--     are held for moderation in the CMS and only shown once approved; admins can reply.
+-- Down: Drop the table
+
+CREATE TABLE product_reviews (
+    id               uuid PRIMARY KEY,
+    product_id       uuid NOT NULL,          -- CMS product (no FK across databases)
+    user_id          uuid NOT NULL,
+    order_id         uuid,                   -- The completed order that verified the purchase
+    rating           smallint NOT NULL,
+    title            varchar(150) NOT NULL,
+    body             text NOT NULL,
+    photos           jsonb NOT NULL DEFAULT '[]',
+    status           varchar(20) NOT NULL DEFAULT 'pending',
+    rejection_reason text,
+    admin_reply      text,
+    replied_at       timestamptz,
+    moderated_by     uuid,                   -- CMS admin (no FK across databases)
+    moderated_at     timestamptz,
+    created_at       timestamptz NOT NULL DEFAULT now(),
+    updated_at       timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT product_reviews_user_id_fkey FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT product_reviews_order_id_fkey FOREIGN KEY (order_id)
+        REFERENCES orders(id) ON DELETE SET NULL,
+    CONSTRAINT product_reviews_rating_check CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT product_reviews_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+-- One review per customer and product
+CREATE UNIQUE INDEX idx_product_reviews_product_user ON product_reviews (product_id, user_id);
+CREATE INDEX idx_product_reviews_product_status ON product_reviews (product_id, status, created_at DESC);
+CREATE INDEX idx_product_reviews_status_created ON product_reviews (status, created_at);
@@ -0,0 +1,4 @@
+-- Migration Down: Drop product questions and answers
+
+DROP TABLE IF EXISTS product_answers;
+DROP TABLE IF EXISTS product_questions;
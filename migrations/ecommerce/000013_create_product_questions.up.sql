@@ -0,0 +1,54 @@
+-- Migration: Product questions and answers
+-- Up: Customers ask questions about a product; the store or customers who have bought
+--     the product answer them. Questions and customers' answers are held for moderation
+--     in the CMS; the store's own answers are published straight away. answered_at is set
+--     when a question's first answer is published, and the asker is emailed once
+--     (asker_notified_at).
+-- Down: Drop the tables
+
+CREATE TABLE product_questions (
+    id                uuid PRIMARY KEY,
+    product_id        uuid NOT NULL,          -- CMS product (no FK across databases)
+    user_id           uuid NOT NULL,
+    question          text NOT NULL,
+    status            varchar(20) NOT NULL DEFAULT 'pending',
+    rejection_reason  text,
+    moderated_by      uuid,                   -- CMS admin (no FK across databases)
+    moderated_at      timestamptz,
+    answered_at       timestamptz,
+    asker_notified_at timestamptz,
+    created_at        timestamptz NOT NULL DEFAULT now(),
+    updated_at        timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT product_questions_user_id_fkey FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT product_questions_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX idx_product_questions_product_answered ON product_questions (product_id, answered_at DESC)
+    WHERE status = 'approved' AND answered_at IS NOT NULL;
+CREATE INDEX idx_product_questions_unanswered ON product_questions (created_at)
+    WHERE status <> 'rejected' AND answered_at IS NULL;
+
+CREATE TABLE product_answers (
+    id           uuid PRIMARY KEY,
+    question_id  uuid NOT NULL,
+    user_id      uuid,                        -- Set for answers from customers who bought the product
+    admin_id     uuid,                        -- Set for the store's answers (CMS admin, no FK)
+    answer       text NOT NULL,
+    status       varchar(20) NOT NULL DEFAULT 'pending',
+    moderated_by uuid,
+    moderated_at timestamptz,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    updated_at   timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT product_answers_question_id_fkey FOREIGN KEY (question_id)
+        REFERENCES product_questions(id) ON DELETE CASCADE,
+    CONSTRAINT product_answers_user_id_fkey FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT product_answers_author_check CHECK ((user_id IS NULL) <> (admin_id IS NULL)),
+    CONSTRAINT product_answers_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX idx_product_answers_question ON product_answers (question_id, status, created_at);
+CREATE INDEX idx_product_answers_pending ON product_answers (created_at) WHERE status = 'pending';
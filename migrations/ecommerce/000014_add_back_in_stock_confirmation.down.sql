@@ -0,0 +1,14 @@
+-- Migration Down: Remove back-in-stock confirmation
+
+DELETE FROM back_in_stock_subscriptions WHERE status = 'unconfirmed';
+
+DROP INDEX IF EXISTS idx_back_in_stock_pending;
+CREATE UNIQUE INDEX idx_back_in_stock_pending
+    ON back_in_stock_subscriptions (product_id, sku, LOWER(email))
+    WHERE status = 'pending';
+
+ALTER TABLE back_in_stock_subscriptions DROP CONSTRAINT back_in_stock_subscriptions_status_check;
+ALTER TABLE back_in_stock_subscriptions ADD CONSTRAINT back_in_stock_subscriptions_status_check
+    CHECK (status IN ('pending', 'notified', 'unsubscribed'));
+
+ALTER TABLE back_in_stock_subscriptions DROP COLUMN IF EXISTS confirmed_at;
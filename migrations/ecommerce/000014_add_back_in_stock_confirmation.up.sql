@@ -0,0 +1,18 @@
+-- Migration: Confirm guest back-in-stock subscriptions
+-- Up: Subscriptions for an email that isn't the logged-in user's own start unconfirmed and
+--     only wait for a restock (pending) once the emailed confirmation link is followed.
+--     Unconfirmed subscriptions count towards one open subscription per email and combo,
+--     so a combo's confirmation is sent to an address once.
+-- Down: Drop unconfirmed subscriptions and the column
+
+ALTER TABLE back_in_stock_subscriptions ADD COLUMN confirmed_at timestamp without time zone;
+UPDATE back_in_stock_subscriptions SET confirmed_at = created_at;
+
+ALTER TABLE back_in_stock_subscriptions DROP CONSTRAINT back_in_stock_subscriptions_status_check;
+ALTER TABLE back_in_stock_subscriptions ADD CONSTRAINT back_in_stock_subscriptions_status_check
+    CHECK (status IN ('unconfirmed', 'pending', 'notified', 'unsubscribed'));
+
+DROP INDEX IF EXISTS idx_back_in_stock_pending;
+CREATE UNIQUE INDEX idx_back_in_stock_pending
+    ON back_in_stock_subscriptions (product_id, sku, LOWER(email))
+    WHERE status IN ('unconfirmed', 'pending');
@@ -124,9 +124,22 @@ func (al *ActivityLog) ToResponse() ActivityLogResponse {
 
 const (
 	// Product Actions
-	ActionCreateProduct = "created_product"
-	ActionUpdateProduct = "updated_product"
-	ActionDeleteProduct = "deleted_product"
+	ActionCreateProduct    = "created_product"
+	ActionUpdateProduct    = "updated_product"
+	ActionDeleteProduct    = "deleted_product"
+	ActionRestoreProduct   = "restored_product"
+	ActionPublishProduct   = "published_product"   // By the product scheduler
+	ActionUnpublishProduct = "unpublished_product" // By the product scheduler
+	ActionPurgeProduct     = "purged_product"      // Deleted for good after its time in the trash
+
+	// Stock Actions
+	ActionCreateStockAdjustment = "created_stock_adjustment"
+	ActionCreateStockLocation   = "created_stock_location"
+	ActionUpdateStockLocation   = "updated_stock_location"
+	ActionDeleteStockLocation   = "deleted_stock_location"
+	ActionCreateStockTransfer   = "created_stock_transfer"
+	ActionUpdateStockAlert      = "updated_stock_alert"
+	ActionCreateProductImport   = "created_product_import"
 
 	// Category Actions
 	ActionCreateCategory = "created_category"
@@ -136,6 +149,18 @@ const (
 	// Order Actions
 	ActionUpdateOrder = "updated_order"
 
+	// Review Actions
+	ActionApproveReview = "approved_review"
+	ActionRejectReview  = "rejected_review"
+	ActionReplyToReview = "replied_to_review"
+
+	// Question Actions
+	ActionApproveQuestion = "approved_question"
+	ActionRejectQuestion  = "rejected_question"
+	ActionAnswerQuestion  = "answered_question"
+	ActionApproveAnswer   = "approved_answer"
+	ActionRejectAnswer    = "rejected_answer"
+
 	// Customer Actions
 	ActionUpdateCustomer    = "updated_customer"
 	ActionBanCustomer       = "banned_customer"
@@ -153,14 +178,33 @@ const (
 	ActionUpdateAdminProfile = "updated_admin_profile"
 
 	// Resource Types
-	ResourceTypeProduct     = "product"
-	ResourceTypeCategory    = "category"
-	ResourceTypeOrder       = "order"
-	ResourceTypeCustomer    = "customer"
-	ResourceTypeAdmin       = "admin"
-	ResourceTypeAdminInvite = "admin_invite" // ← Add this line
+	ResourceTypeProduct         = "product"
+	ResourceTypeCategory        = "category"
+	ResourceTypeOrder           = "order"
+	ResourceTypeCustomer        = "customer"
+	ResourceTypeAdmin           = "admin"
+	ResourceTypeAdminInvite     = "admin_invite" // ← Add this line
+	ResourceTypeStockAdjustment = "stock_adjustment"
+	ResourceTypeStockLocation   = "stock_location"
+	ResourceTypeStockTransfer   = "stock_transfer"
+	ResourceTypeStockAlert      = "stock_alert"
+	ResourceTypeProductImport   = "product_import"
+	ResourceTypeCustomerGroup   = "customer_group"
+	ResourceTypeQuantityTier    = "quantity_tier"
+	ResourceTypeReview          = "review"
+	ResourceTypeQuestion        = "question"
+	ResourceTypeAnswer          = "answer"
+	ResourceTypeSearchSynonym   = "search_synonym"
+	ResourceTypeSearchRedirect  = "search_redirect"
+	ResourceTypeSearchPin       = "search_pin"
 
 	// Status
 	StatusSuccess = "success"
 	StatusFailed  = "failed"
 )
+
+// SystemActorID and SystemActorEmail identify actions taken by the server itself
+// (e.g. scheduled publishing) rather than by an admin
+var SystemActorID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+const SystemActorEmail = "system@modeva"
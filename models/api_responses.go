@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,10 +17,28 @@ type ApiResponse struct {
 }
 
 type Pagination struct {
-	Page       int `json:"page" example:"1"`
+	Page       int `json:"page" example:"1"` // Page/offset pagination only
 	Limit      int `json:"limit" example:"10"`
 	Total      int `json:"total" example:"42"`
 	TotalPages int `json:"total_pages" example:"5"`
+
+	// Cursor pagination (see CursorRequest)
+	NextCursor     string `json:"next_cursor,omitempty"`     // Absent on the last page
+	TotalEstimated bool   `json:"total_estimated,omitempty"` // Total is the planner's estimate
+
+	cursor bool // Set by CursorPagination; leaves page out
+}
+
+// MarshalJSON leaves page out of cursor pages, which have none
+func (p Pagination) MarshalJSON() ([]byte, error) {
+	type pagination Pagination
+	if !p.cursor {
+		return json.Marshal(pagination(p))
+	}
+	return json.Marshal(struct {
+		pagination
+		Page int `json:"page,omitempty"`
+	}{pagination: pagination(p)})
 }
 
 type RateLimiter struct {
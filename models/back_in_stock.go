@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BackInStockSubscription is a shopper waiting for a sold-out inventory combo (ecommerce DB).
+// Subscribers are emailed once, when the combo's quantity goes from 0 back to positive.
+// Emails other than the logged-in user's own are unconfirmed until their owner follows the
+// emailed confirmation link.
+type BackInStockSubscription struct {
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID        uuid.UUID  `json:"product_id" gorm:"type:uuid;not null"`
+	SKU              string     `json:"sku" gorm:"type:varchar(100);not null"`
+	VariantName      string     `json:"variant_name" gorm:"type:varchar(255);not null"`
+	Email            string     `json:"email" gorm:"type:varchar(255);not null"`
+	UserID           *uuid.UUID `json:"user_id,omitempty" gorm:"type:uuid"`
+	UnsubscribeToken string     `json:"-" gorm:"type:varchar(64);not null"`
+	Status           string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	NotifiedAt       *time.Time `json:"notified_at,omitempty"`
+	UnsubscribedAt   *time.Time `json:"unsubscribed_at,omitempty"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (s *BackInStockSubscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (BackInStockSubscription) TableName() string {
+	return "back_in_stock_subscriptions"
+}
+
+const (
+	BackInStockUnconfirmed  = "unconfirmed"
+	BackInStockPending      = "pending"
+	BackInStockNotified     = "notified"
+	BackInStockUnsubscribed = "unsubscribed"
+)
+
+// ════════════════════════════════════════════════════════════
+// Request/Response Models
+// ════════════════════════════════════════════════════════════
+
+// BackInStockRequest subscribes to a sold-out combo, identified by SKU, option values
+// (combo) or variant name. Email is required unless the shopper is logged in; any email
+// but the logged-in user's own has to be confirmed.
+type BackInStockRequest struct {
+	SKU         string   `json:"sku" example:"linen-shirt-small-black"`
+	Combo       []string `json:"combo" example:"Small,Black"`
+	VariantName string   `json:"variant_name" example:"Small / Black"`
+	Email       string   `json:"email" binding:"omitempty,email" example:"jane@example.com"`
+}
+
+// BackInStockUnsubscribeRequest cancels a subscription using the token from the email link
+type BackInStockUnsubscribeRequest struct {
+	Token string `json:"token" binding:"required,len=64"`
+}
+
+// BackInStockConfirmRequest confirms a subscription using the token from the confirmation email
+type BackInStockConfirmRequest struct {
+	Token string `json:"token" binding:"required,len=64"`
+}
+
+// BackInStockVariantStats summarises subscriptions for one combo of a product
+type BackInStockVariantStats struct {
+	SKU            string     `json:"sku"`
+	VariantName    string     `json:"variant_name"`
+	Quantity       int        `json:"quantity"` // Current stock
+	Unconfirmed    int        `json:"unconfirmed"`
+	Pending        int        `json:"pending"`
+	Notified       int        `json:"notified"`
+	Unsubscribed   int        `json:"unsubscribed"`
+	LastNotifiedAt *time.Time `json:"last_notified_at,omitempty"`
+}
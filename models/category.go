@@ -9,14 +9,15 @@ import (
 
 // Category represents a CMS category
 type Category struct {
-	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey" db:"id"`
-	Name        string     `json:"name" gorm:"not null" db:"name"`
-	Description string     `json:"description" gorm:"not null" db:"description"`
-	Status      string     `json:"status" gorm:"type:varchar(20);default:'Inactive';check:status IN ('Active', 'Inactive')" db:"status"`
-	ParentID    *uuid.UUID `json:"parent_id" gorm:"type:uuid;index" db:"parent_id"`
-	ParentName  *string    `json:"parent_name" gorm:"type:text" db:"parent_name"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
+	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey" db:"id"`
+	Name              string     `json:"name" gorm:"not null" db:"name"`
+	Description       string     `json:"description" gorm:"not null" db:"description"`
+	Status            string     `json:"status" gorm:"type:varchar(20);default:'Inactive';check:status IN ('Active', 'Inactive')" db:"status"`
+	ParentID          *uuid.UUID `json:"parent_id" gorm:"type:uuid;index" db:"parent_id"`
+	ParentName        *string    `json:"parent_name" gorm:"type:text" db:"parent_name"`
+	LowStockThreshold *int       `json:"low_stock_threshold" gorm:"column:low_stock_threshold" db:"low_stock_threshold"` // Default for products in this category
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
 
 	// Relationships (GORM will handle these automatically)
 	Parent   *Category   `json:"parent,omitempty" gorm:"foreignKey:ParentID;references:ID"`
@@ -64,16 +65,18 @@ func (Category) TableName() string {
 
 // CategoryRequest is used when creating a category or subcategory
 type CategoryRequest struct {
-	Name        string     `json:"name" binding:"required" example:"Electronics"`
-	Description string     `json:"description" binding:"required" example:"Devices and gadgets"`
-	ParentID    *uuid.UUID `json:"parent_id,omitempty" example:"null"`
+	Name              string     `json:"name" binding:"required" example:"Electronics"`
+	Description       string     `json:"description" binding:"required" example:"Devices and gadgets"`
+	ParentID          *uuid.UUID `json:"parent_id,omitempty" example:"null"`
+	LowStockThreshold *int       `json:"low_stock_threshold,omitempty" binding:"omitempty,min=0" example:"5"`
 }
 
 // UpdateCategoryRequest is used when updating a category
 type UpdateCategoryRequest struct {
-	Name        *string    `json:"name"`
-	Description *string    `json:"description"`
-	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	Name              *string    `json:"name"`
+	Description       *string    `json:"description"`
+	ParentID          *uuid.UUID `json:"parent_id,omitempty"`
+	LowStockThreshold *int       `json:"low_stock_threshold" binding:"omitempty,min=-1"` // -1 clears it (inherit from parent)
 }
 
 // UpdateCategoryStatusRequest is used when updating a category's status
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned for a cursor that can't be read, or was taken under
+// another sort
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is a position in a keyset-paginated list: the sort key and ID (UUIDv7) of the
+// last item seen, and the sort it was taken under. Clients get it encoded and pass it back
+// as is.
+type Cursor struct {
+	Sort string    `json:"s"`
+	Key  string    `json:"k"` // Sort key as Postgres text
+	ID   uuid.UUID `json:"id"`
+}
+
+// Encode returns the cursor as an opaque, URL-safe string
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor reads an encoded cursor taken under sort
+func DecodeCursor(encoded, sort string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == uuid.Nil || cursor.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// CursorRequest is a keyset pagination request. Lists switch to it when the cursor
+// parameter is present: ?cursor= (empty) for the first page, then the next_cursor of
+// each page. Totals are the planner's estimate unless ?total=exact.
+type CursorRequest struct {
+	Cursor     string // Encoded; "" for the first page
+	ExactTotal bool
+}
+
+// CursorRequestFromQuery returns the list's cursor request, or nil for page/offset
+// pagination
+func CursorRequestFromQuery(c *gin.Context) *CursorRequest {
+	cursor, ok := c.GetQuery("cursor")
+	if !ok {
+		return nil
+	}
+	return &CursorRequest{Cursor: cursor, ExactTotal: c.Query("total") == "exact"}
+}
+
+// Keyset is the order of a keyset-paginated list: a sort key, with the ID breaking ties
+// in the same direction
+type Keyset struct {
+	Sort    string // Name of the order, checked against cursors
+	KeySQL  string // Sort key (never NULL)
+	KeyType string // Postgres type the key compares as, e.g. timestamptz, numeric, text
+	IDSQL   string
+	Desc    bool
+}
+
+// OrderSQL is the keyset's ORDER BY clause
+func (k Keyset) OrderSQL() string {
+	direction := "ASC"
+	if k.Desc {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%s %s, %s %s", k.KeySQL, direction, k.IDSQL, direction)
+}
+
+// AfterSQL is true for the items after a cursor; its arguments are AfterArgs
+func (k Keyset) AfterSQL() string {
+	comparison := ">"
+	if k.Desc {
+		comparison = "<"
+	}
+	return fmt.Sprintf("(%s, %s) %s (CAST(? AS %s), ?)", k.KeySQL, k.IDSQL, comparison, k.KeyType)
+}
+
+// AfterArgs are the arguments of AfterSQL for a cursor
+func (k Keyset) AfterArgs(cursor *Cursor) []interface{} {
+	return []interface{}{cursor.Key, cursor.ID}
+}
+
+// CursorKeySQL selects an item's sort key for its cursor, as cursor_key
+func (k Keyset) CursorKeySQL() string {
+	return fmt.Sprintf("(%s)::text AS cursor_key", k.KeySQL)
+}
+
+// NextCursor is the encoded cursor after the last item of a page (key: its cursor_key)
+func (k Keyset) NextCursor(key string, id uuid.UUID) string {
+	return Cursor{Sort: k.Sort, Key: key, ID: id}.Encode()
+}
+
+// CursorPagination is the meta of a cursor page, without page (nextCursor: "" on the
+// last page)
+func CursorPagination(limit, total int, estimated bool, nextCursor string) *Pagination {
+	return &Pagination{
+		Limit:          limit,
+		Total:          total,
+		TotalPages:     (total + limit - 1) / limit,
+		NextCursor:     nextCursor,
+		TotalEstimated: estimated,
+		cursor:         true,
+	}
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := uuid.Must(uuid.NewV7())
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"timestamp", "2026-10-18 09:30:00.123456+00"},
+		{"numeric", "49.99"},
+		{"text with quotes and unicode", `Größe "XL" / ½`},
+		{"empty key", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := Cursor{Sort: "name:asc", Key: tt.key, ID: id}.Encode()
+			if strings.ContainsAny(encoded, "+/=") {
+				t.Errorf("Encode() = %q, not URL-safe", encoded)
+			}
+			got, err := DecodeCursor(encoded, "name:asc")
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if got.Key != tt.key || got.ID != id || got.Sort != "name:asc" {
+				t.Errorf("DecodeCursor() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(v interface{}) string {
+		raw, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+	valid := Cursor{Sort: "newest:desc", Key: "2026-10-18", ID: uuid.New()}
+
+	tests := []struct {
+		name    string
+		encoded string
+		sort    string
+	}{
+		{"other sort", valid.Encode(), "price:asc"},
+		{"other direction", valid.Encode(), "newest:asc"},
+		{"not base64", "!!!", "newest:desc"},
+		{"not JSON", base64.RawURLEncoding.EncodeToString([]byte("cursor")), "newest:desc"},
+		{"no ID", encode(map[string]string{"s": "newest:desc", "k": "x"}), "newest:desc"},
+		{"bad ID", encode(map[string]string{"s": "newest:desc", "k": "x", "id": "nope"}), "newest:desc"},
+		{"empty", "", "newest:desc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.encoded, tt.sort); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestKeysetSQL(t *testing.T) {
+	tests := []struct {
+		name   string
+		keyset Keyset
+		order  string
+		after  string
+	}{
+		{
+			name:   "descending",
+			keyset: Keyset{KeySQL: "p.created_at", KeyType: "timestamptz", IDSQL: "p.id", Desc: true},
+			order:  "p.created_at DESC, p.id DESC",
+			after:  "(p.created_at, p.id) < (CAST(? AS timestamptz), ?)",
+		},
+		{
+			name:   "ascending",
+			keyset: Keyset{KeySQL: "p.name", KeyType: "text", IDSQL: "p.id"},
+			order:  "p.name ASC, p.id ASC",
+			after:  "(p.name, p.id) > (CAST(? AS text), ?)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.keyset.OrderSQL(); got != tt.order {
+				t.Errorf("OrderSQL() = %q, want %q", got, tt.order)
+			}
+			if got := tt.keyset.AfterSQL(); got != tt.after {
+				t.Errorf("AfterSQL() = %q, want %q", got, tt.after)
+			}
+		})
+	}
+}
+
+func TestKeysetNextCursor(t *testing.T) {
+	keyset := Keyset{Sort: "rating:desc", KeySQL: "p.rating_average", KeyType: "numeric", IDSQL: "p.id", Desc: true}
+	id := uuid.New()
+
+	cursor, err := DecodeCursor(keyset.NextCursor("4.5", id), keyset.Sort)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	args := keyset.AfterArgs(cursor)
+	if len(args) != 2 || args[0] != "4.5" || args[1] != id {
+		t.Errorf("AfterArgs() = %v", args)
+	}
+	if got, want := keyset.CursorKeySQL(), "(p.rating_average)::text AS cursor_key"; got != want {
+		t.Errorf("CursorKeySQL() = %q, want %q", got, want)
+	}
+}
+
+func TestPaginationJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination *Pagination
+		want       string
+	}{
+		{
+			name:       "page pagination keeps page, even on an empty first page",
+			pagination: &Pagination{Page: 0, Limit: 10},
+			want:       `{"page":0,"limit":10,"total":0,"total_pages":0}`,
+		},
+		{
+			name:       "page pagination",
+			pagination: &Pagination{Page: 2, Limit: 10, Total: 42, TotalPages: 5},
+			want:       `{"page":2,"limit":10,"total":42,"total_pages":5}`,
+		},
+		{
+			name:       "cursor pagination leaves page out",
+			pagination: CursorPagination(10, 42, true, "abc"),
+			want:       `{"limit":10,"total":42,"total_pages":5,"next_cursor":"abc","total_estimated":true}`,
+		},
+		{
+			name:       "last cursor page",
+			pagination: CursorPagination(10, 7, false, ""),
+			want:       `{"limit":10,"total":7,"total_pages":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(ApiResponse{Meta: tt.pagination})
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var out struct {
+				Meta json.RawMessage `json:"meta"`
+			}
+			if err := json.Unmarshal(raw, &out); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if string(out.Meta) != tt.want {
+				t.Errorf("meta = %s, want %s", out.Meta, tt.want)
+			}
+		})
+	}
+}
@@ -35,6 +35,8 @@ type CMSCustomerDetail struct {
 	BanReason        *string          `json:"ban_reason" gorm:"column:ban_reason"`
 	SuspendedUntil   *time.Time       `json:"suspended_until" gorm:"column:suspended_until"`
 	SuspendedReason  *string          `json:"suspended_reason" gorm:"column:suspended_reason"`
+	CustomerGroupID  *string          `json:"customer_group_id" gorm:"column:customer_group_id"`
+	CustomerGroup    *string          `json:"customer_group" gorm:"-"` // Group name
 	Address          *CustomerAddress `json:"address" gorm:"-"`
 	RecentOrders     []CustomerOrder  `json:"recent_orders" gorm:"-"`
 }
@@ -68,6 +70,9 @@ type UpdateCustomerRequest struct {
 	BanReason       *string    `json:"ban_reason"`
 	SuspendedUntil  *time.Time `json:"suspended_until"`
 	SuspendedReason *string    `json:"suspended_reason"`
+
+	// Pricing
+	CustomerGroupID *string `json:"customer_group_id"` // Empty string removes the customer from their group
 }
 
 type CMSCustomerOrderRow struct {
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerGroup groups customers who get their own prices (e.g. wholesale stockists).
+// Customers are assigned in the ecommerce database (users.customer_group_id).
+type CustomerGroup struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Name        string    `json:"name" gorm:"not null;uniqueIndex"`
+	Description string    `json:"description" gorm:"not null;default:''"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (cg *CustomerGroup) BeforeCreate(tx *gorm.DB) error {
+	if cg.ID == uuid.Nil {
+		cg.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (CustomerGroup) TableName() string {
+	return "customer_groups"
+}
+
+// CustomerGroupPrice is a group's price for a product, replacing Product.Price for its
+// members (sales still apply when they're lower)
+type CustomerGroupPrice struct {
+	CustomerGroupID uuid.UUID `json:"customer_group_id" gorm:"type:uuid;primaryKey"`
+	ProductID       uuid.UUID `json:"product_id" gorm:"type:uuid;primaryKey"`
+	Price           float64   `json:"price" gorm:"type:numeric(12,2);not null"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name
+func (CustomerGroupPrice) TableName() string {
+	return "customer_group_prices"
+}
+
+// QuantityTier takes DiscountPercent off a line once MinQuantity units of a product are
+// ordered. nil CustomerGroupID applies to every customer; nil ProductID to every product.
+type QuantityTier struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	CustomerGroupID *uuid.UUID `json:"customer_group_id" gorm:"type:uuid"`
+	ProductID       *uuid.UUID `json:"product_id" gorm:"type:uuid"`
+	MinQuantity     int        `json:"min_quantity" gorm:"not null"`
+	DiscountPercent float64    `json:"discount_percent" gorm:"type:numeric(5,2);not null"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (qt *QuantityTier) BeforeCreate(tx *gorm.DB) error {
+	if qt.ID == uuid.Nil {
+		qt.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (QuantityTier) TableName() string {
+	return "quantity_tiers"
+}
+
+// TierDiscount returns the best discount (percent) the tiers give quantity units of a product
+func TierDiscount(tiers []QuantityTier, productID uuid.UUID, quantity int) float64 {
+	best := 0.0
+	for _, tier := range tiers {
+		if tier.ProductID != nil && *tier.ProductID != productID {
+			continue
+		}
+		if quantity >= tier.MinQuantity && tier.DiscountPercent > best {
+			best = tier.DiscountPercent
+		}
+	}
+	return best
+}
+
+// ApplyDiscount takes percent off price, rounded to the cent
+func ApplyDiscount(price, percent float64) float64 {
+	if percent <= 0 {
+		return price
+	}
+	return math.Round(price*(100-percent)) / 100
+}
+
+// ════════════════════════════════════════════════════════════
+// Request/Response Models
+// ════════════════════════════════════════════════════════════
+
+// CustomerGroupRequest creates a customer group
+type CustomerGroupRequest struct {
+	Name        string `json:"name" binding:"required,max=100" example:"Wholesale"`
+	Description string `json:"description" example:"Boutique stockists on negotiated prices"`
+}
+
+// UpdateCustomerGroupRequest updates a customer group (only non-nil fields)
+type UpdateCustomerGroupRequest struct {
+	Name        *string `json:"name" binding:"omitempty,max=100"`
+	Description *string `json:"description"`
+}
+
+// CustomerGroupResponse is a group with its size
+type CustomerGroupResponse struct {
+	CustomerGroup
+	MemberCount int `json:"member_count" gorm:"-"`
+	PriceCount  int `json:"price_count"` // Products with a group price
+}
+
+// SetCustomerGroupPricesRequest sets or removes group prices for products
+type SetCustomerGroupPricesRequest struct {
+	Prices []CustomerGroupPriceInput `json:"prices" binding:"required,min=1,max=500,dive"`
+}
+
+// CustomerGroupPriceInput is one product's group price; -1 removes it
+type CustomerGroupPriceInput struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required"`
+	Price     float64   `json:"price" binding:"min=-1" example:"49.99"`
+}
+
+// CustomerGroupPriceResponse is a group price with the product's regular price
+type CustomerGroupPriceResponse struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	RegularPrice float64   `json:"regular_price"`
+	Price        float64   `json:"price"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// QuantityTierRequest creates a quantity tier
+type QuantityTierRequest struct {
+	CustomerGroupID *uuid.UUID `json:"customer_group_id"` // Omit for every customer
+	ProductID       *uuid.UUID `json:"product_id"`        // Omit for every product
+	MinQuantity     int        `json:"min_quantity" binding:"required,min=2" example:"10"`
+	DiscountPercent float64    `json:"discount_percent" binding:"required,gt=0,lt=100" example:"15"`
+}
@@ -36,12 +36,20 @@ type OrderItem struct {
 	ProductName  string    `json:"product_name"`
 	VariantSize  *string   `json:"variant_size,omitempty"`
 	VariantColor *string   `json:"variant_color,omitempty"`
+	SKU          *string   `json:"sku,omitempty"`
 	Price        float64   `json:"price"`
 	Quantity     int       `json:"quantity"`
 	Subtotal     float64   `json:"subtotal"`
 	Status       string    `json:"status"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	BundleComponents OrderBundleComponentList `json:"bundle_components,omitempty"` // Bundles only, as ordered
+
+	// Units ordered beyond stock, not yet taken from it (0 once the order ships)
+	BackorderedQuantity int        `json:"backordered_quantity,omitempty"`
+	BackorderType       *string    `json:"backorder_type,omitempty"` // backorder or preorder
+	ExpectedShipDate    *time.Time `json:"expected_ship_date,omitempty"`
 }
 
 // OrderWithItems combines order and its items
@@ -79,6 +87,7 @@ type OrderItemInput struct {
 	Quantity     int     `json:"quantity" binding:"required,min=1"`
 	VariantSize  *string `json:"variant_size,omitempty"`
 	VariantColor *string `json:"variant_color,omitempty"`
+	SKU          *string `json:"sku,omitempty"` // Optional; resolved from size/color when omitted
 }
 
 type CMSOrderListRow struct {
@@ -145,6 +154,9 @@ type UpdateOrderStatusResponse struct {
 	OrderNumber string  `json:"order_number"`
 	Status      string  `json:"status"`
 	AdminNotes  *string `json:"admin_notes,omitempty"`
+
+	// Set when a cancelled order's stock couldn't be returned; cancelling it again retries
+	RestockError *string `gorm:"-" json:"restock_error,omitempty"`
 }
 
 type OrderStatsBreakdown struct {
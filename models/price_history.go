@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PriceHistory is an append-only record of a product's pricing, or of one inventory
+// combo's sale, written whenever it changes. Together the rows give the price charged
+// at any past moment (see Product.PriceAt).
+type PriceHistory struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID      uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	SKU            *string    `json:"sku,omitempty"`                                        // nil: the product's own pricing
+	Price          float64    `json:"price" gorm:"type:numeric(12,2);not null"`             // Product price at the time
+	CompareAtPrice *float64   `json:"compare_at_price,omitempty" gorm:"type:numeric(12,2)"` // Product rows only
+	SalePrice      *float64   `json:"sale_price,omitempty" gorm:"type:numeric(12,2)"`
+	SaleStartsAt   *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt     *time.Time `json:"sale_ends_at,omitempty"`
+	Source         string     `json:"source" gorm:"not null"` // RevisionSourceCreate, RevisionSourceUpdate, ...
+	AdminID        *uuid.UUID `json:"admin_id,omitempty" gorm:"type:uuid"`
+	AdminEmail     *string    `json:"admin_email,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (ph *PriceHistory) BeforeCreate(tx *gorm.DB) error {
+	if ph.ID == uuid.Nil {
+		ph.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (PriceHistory) TableName() string {
+	return "price_history"
+}
+
+// SamePricing reports whether two rows for the same product or combo record the same pricing
+func (ph *PriceHistory) SamePricing(other *PriceHistory) bool {
+	return ph.Price == other.Price &&
+		sameFloat(ph.CompareAtPrice, other.CompareAtPrice) &&
+		sameFloat(ph.SalePrice, other.SalePrice) &&
+		sameTime(ph.SaleStartsAt, other.SaleStartsAt) &&
+		sameTime(ph.SaleEndsAt, other.SaleEndsAt)
+}
+
+func sameFloat(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
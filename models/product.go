@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,6 +34,16 @@ type InventoryField struct {
 	Combo       []string `json:"combo" binding:"required" example:"['Small', 'Black']"`
 	VariantName string   `json:"variant_name" binding:"required" example:"Small-Black"`
 	Quantity    int      `json:"quantity" binding:"required,min=0" example:"100"`
+	SKU         string   `json:"sku,omitempty" binding:"omitempty,max=100" example:"linen-shirt-small-black"`
+	Barcode     string   `json:"barcode,omitempty" example:"4006381333931"` // Optional GTIN/EAN
+
+	// Optional sale for this combo only (see product_pricing.go)
+	SalePrice    *float64   `json:"sale_price,omitempty" binding:"omitempty,min=0" example:"59.99"`
+	SaleStartsAt *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at,omitempty"`
+
+	// Optional backorder settings for this combo only, instead of the product's
+	Backorder *BackorderPolicy `json:"backorder,omitempty"`
 }
 
 // Create custom types for slices (so we can add methods)
@@ -46,6 +57,67 @@ type (
 // Use custom types as aliases for convenience
 type Inventory = InventoryList
 
+// IndexOfSKU returns the index of the combo with the given SKU, or -1 if none matches
+func (i InventoryList) IndexOfSKU(sku string) int {
+	if sku == "" {
+		return -1
+	}
+	for idx, item := range i {
+		if strings.EqualFold(item.SKU, sku) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// IndexOfOptions returns the index of the combo containing every given option value
+// (e.g. "Small", "Black"), or -1 if none or several match (a size alone on a size × colour
+// product doesn't pick a combo). Empty values are ignored; with no values at all only a
+// single-combo inventory can match.
+func (i InventoryList) IndexOfOptions(options ...string) int {
+	hasOption := false
+	for _, opt := range options {
+		if opt != "" {
+			hasOption = true
+			break
+		}
+	}
+	if !hasOption {
+		if len(i) == 1 {
+			return 0
+		}
+		return -1
+	}
+
+	found := -1
+	for idx, item := range i {
+		matched := true
+		for _, opt := range options {
+			if opt == "" {
+				continue
+			}
+			found := false
+			for _, value := range item.Combo {
+				if strings.EqualFold(value, opt) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			if found >= 0 {
+				return -1
+			}
+			found = idx
+		}
+	}
+	return found
+}
+
 type Seo struct {
 	SEOTitle       string `json:"seo_title" binding:"required" example:"Best Sample Product"`
 	SEODescription string `json:"seo_description" binding:"required" example:"This is the best sample product."`
@@ -61,23 +133,38 @@ type Composition struct {
 // ═══════════════════════════════════════════════════════════
 
 type Product struct {
-	ID              uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
-	Name            string          `json:"name" gorm:"not null;index"`
-	Description     string          `json:"description" gorm:"not null"`
-	Composition     CompositionList `json:"composition" gorm:"type:jsonb;not null;default:'[]'"`
-	Price           float64         `json:"price" gorm:"type:numeric(12,2);not null;check:price >= 0"`
-	SubCategoryID   uuid.UUID       `json:"sub_category_id" gorm:"type:uuid;not null;index:idx_products_subcategory"`
-	SubCategoryName *string         `json:"sub_category_name,omitempty" gorm:"-"` // Computed field
-	SubCategory     *Category       `json:"sub_category,omitempty" gorm:"foreignKey:SubCategoryID;references:ID"`
-	Status          string          `json:"status" gorm:"not null;check:status IN ('Active', 'Draft');index"`
-	Tags            TagsList        `json:"tags" gorm:"type:jsonb;not null;default:'[]';index:,type:gin"`
-	Media           ProductMedia    `json:"media" gorm:"type:jsonb;not null;default:'{}'"`
-	Variants        VariantsList    `json:"variants" gorm:"type:jsonb;not null;default:'[]'"`
-	Inventory       InventoryList   `json:"inventory" gorm:"type:jsonb;not null;default:'[]'"`
-	SEO             Seo             `json:"seo" gorm:"type:jsonb;not null;default:'{}'"`
-	Views           int             `json:"views" gorm:"default:0;index:idx_products_views,sort:desc"`
-	CreatedAt       time.Time       `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt       time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                uuid.UUID           `json:"id" gorm:"type:uuid;primaryKey"`
+	Name              string              `json:"name" gorm:"not null;index"`
+	Description       string              `json:"description" gorm:"not null"`
+	Composition       CompositionList     `json:"composition" gorm:"type:jsonb;not null;default:'[]'"`
+	Price             float64             `json:"price" gorm:"type:numeric(12,2);not null;check:price >= 0"`
+	CompareAtPrice    *float64            `json:"compare_at_price" gorm:"type:numeric(12,2)"` // "Was" price shown struck through
+	SalePrice         *float64            `json:"sale_price" gorm:"type:numeric(12,2)"`       // Applies between SaleStartsAt and SaleEndsAt
+	SaleStartsAt      *time.Time          `json:"sale_starts_at"`                             // nil: already started
+	SaleEndsAt        *time.Time          `json:"sale_ends_at"`                               // nil: runs until removed
+	SubCategoryID     uuid.UUID           `json:"sub_category_id" gorm:"type:uuid;not null;index:idx_products_subcategory"`
+	SubCategoryName   *string             `json:"sub_category_name,omitempty" gorm:"-"` // Computed field
+	SubCategory       *Category           `json:"sub_category,omitempty" gorm:"foreignKey:SubCategoryID;references:ID"`
+	Status            string              `json:"status" gorm:"not null;check:status IN ('Active', 'Draft');index"`
+	Tags              TagsList            `json:"tags" gorm:"type:jsonb;not null;default:'[]';index:,type:gin"`
+	Media             ProductMedia        `json:"media" gorm:"type:jsonb;not null;default:'{}'"`
+	Variants          VariantsList        `json:"variants" gorm:"type:jsonb;not null;default:'[]'"`
+	Inventory         InventoryList       `json:"inventory" gorm:"type:jsonb;not null;default:'[]'"`
+	ProductType       string              `json:"product_type" gorm:"not null;default:'standard'"`           // standard or bundle
+	BundleComponents  BundleComponentList `json:"bundle_components" gorm:"type:jsonb;not null;default:'[]'"` // Bundles only (see product_bundle.go)
+	QuantityRules     QuantityRules       `json:"quantity_rules" gorm:"type:jsonb;not null;default:'{}'"`    // See product_quantity_rules.go
+	Backorder         BackorderPolicy     `json:"backorder" gorm:"type:jsonb;not null;default:'{}'"`         // See product_backorder.go
+	SKUPattern        string              `json:"sku_pattern,omitempty" gorm:"column:sku_pattern;not null;default:''"`
+	LowStockThreshold *int                `json:"low_stock_threshold" gorm:"column:low_stock_threshold"` // nil = inherit from category
+	SEO               Seo                 `json:"seo" gorm:"type:jsonb;not null;default:'{}'"`
+	PublishAt         *time.Time          `json:"publish_at"`              // Draft → Active at this time (cleared once done)
+	UnpublishAt       *time.Time          `json:"unpublish_at"`            // Active → Draft at this time (cleared once done)
+	DisplayStatus     string              `json:"display_status" gorm:"-"` // Computed: Status, or "Scheduled" for a Draft with publish_at
+	Views             int                 `json:"views" gorm:"default:0;index:idx_products_views,sort:desc"`
+	CreatedAt         time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time           `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt         gorm.DeletedAt      `json:"deleted_at"` // Set while the product is in the trash (GORM queries skip it)
+	DeletedBy         *uuid.UUID          `json:"deleted_by,omitempty" gorm:"type:uuid"`
 }
 
 // BeforeCreate hook - auto-generate UUID v7
@@ -88,14 +175,29 @@ func (p *Product) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-// AfterFind hook - populate SubCategoryName from relationship
+// AfterFind hook - populate SubCategoryName from relationship and DisplayStatus
 func (p *Product) AfterFind(tx *gorm.DB) error {
 	if p.SubCategory != nil {
 		p.SubCategoryName = &p.SubCategory.Name
 	}
+	p.DisplayStatus = ProductDisplayStatus(p.Status, p.PublishAt)
 	return nil
 }
 
+// ProductDisplayStatus is the status shown in the CMS: a Draft waiting on publish_at is "Scheduled"
+func ProductDisplayStatus(status string, publishAt *time.Time) string {
+	if status == ProductStatusDraft && publishAt != nil {
+		return ProductStatusScheduled
+	}
+	return status
+}
+
+const (
+	ProductStatusActive    = "Active"
+	ProductStatusDraft     = "Draft"
+	ProductStatusScheduled = "Scheduled" // Display only; stored as Draft with publish_at set
+)
+
 // TableName specifies the table name
 func (Product) TableName() string {
 	return "products"
@@ -106,31 +208,55 @@ func (Product) TableName() string {
 // ═══════════════════════════════════════════════════════════
 
 type ProductRequest struct {
-	Name          string           `json:"name" binding:"required" example:"Sample Product"`
-	Description   string           `json:"description" binding:"required" example:"This is a sample product"`
-	Composition   []Composition    `json:"composition" binding:"required,dive"`
-	Price         float64          `json:"price" binding:"required,min=0" example:"99.99"`
-	SubCategoryID uuid.UUID        `json:"sub_category_id" binding:"required" example:"018d1234-5678-7abc-def0-123456789abc"`
-	Status        string           `json:"status" binding:"required,oneof=Active Draft" example:"Draft"`
-	Tags          []string         `json:"tags" binding:"required" example:"['cotton', 'summer']"`
-	Media         ProductMedia     `json:"media" binding:"required"`
-	Variants      []ProductVariant `json:"variants" binding:"required,dive"`
-	Inventory     []InventoryField `json:"inventory" binding:"required,dive"`
-	SEO           Seo              `json:"seo" binding:"required"`
+	Name              string            `json:"name" binding:"required" example:"Sample Product"`
+	Description       string            `json:"description" binding:"required" example:"This is a sample product"`
+	Composition       []Composition     `json:"composition" binding:"required,dive"`
+	Price             float64           `json:"price" binding:"required,min=0" example:"99.99"`
+	CompareAtPrice    *float64          `json:"compare_at_price,omitempty" binding:"omitempty,min=0" example:"129.99"`
+	SalePrice         *float64          `json:"sale_price,omitempty" binding:"omitempty,min=0" example:"79.99"` // Must be below price
+	SaleStartsAt      *time.Time        `json:"sale_starts_at,omitempty" example:"2026-11-27T00:00:00Z"`
+	SaleEndsAt        *time.Time        `json:"sale_ends_at,omitempty" example:"2026-12-01T00:00:00Z"`
+	SubCategoryID     uuid.UUID         `json:"sub_category_id" binding:"required" example:"018d1234-5678-7abc-def0-123456789abc"`
+	Status            string            `json:"status" binding:"required,oneof=Active Draft" example:"Draft"`
+	Tags              []string          `json:"tags" binding:"required" example:"['cotton', 'summer']"`
+	Media             ProductMedia      `json:"media" binding:"required"`
+	Variants          []ProductVariant  `json:"variants" binding:"required,dive"`
+	Inventory         []InventoryField  `json:"inventory" binding:"required,dive"`                                                   // Empty for bundles
+	ProductType       string            `json:"product_type,omitempty" binding:"omitempty,oneof=standard bundle" example:"standard"` // Defaults to standard
+	BundleComponents  []BundleComponent `json:"bundle_components,omitempty" binding:"omitempty,dive"`                                // Required for bundles
+	QuantityRules     *QuantityRules    `json:"quantity_rules,omitempty"`
+	Backorder         *BackorderPolicy  `json:"backorder,omitempty"` // Combos can override it in their inventory entry
+	SKUPattern        string            `json:"sku_pattern,omitempty" example:"{product-slug}-{size}-{color}"`
+	LowStockThreshold *int              `json:"low_stock_threshold,omitempty" binding:"omitempty,min=0" example:"10"`
+	PublishAt         *time.Time        `json:"publish_at,omitempty" example:"2026-11-01T00:00:00Z"` // Requires status Draft
+	UnpublishAt       *time.Time        `json:"unpublish_at,omitempty" example:"2026-11-08T00:00:00Z"`
+	SEO               Seo               `json:"seo" binding:"required"`
 }
 
 type UpdateProductRequest struct {
-	Name          *string           `json:"name"`
-	Description   *string           `json:"description"`
-	Composition   *[]Composition    `json:"composition"`
-	Price         *float64          `json:"price" binding:"omitempty,min=0"`
-	SubCategoryID *uuid.UUID        `json:"sub_category_id"`
-	Status        *string           `json:"status" binding:"omitempty,oneof=Active Draft"`
-	Tags          *[]string         `json:"tags"`
-	Media         *ProductMedia     `json:"media"`
-	Variants      *[]ProductVariant `json:"variants"`
-	Inventory     *[]InventoryField `json:"inventory"`
-	SEO           *Seo              `json:"seo"`
+	Name              *string            `json:"name"`
+	Description       *string            `json:"description"`
+	Composition       *[]Composition     `json:"composition"`
+	Price             *float64           `json:"price" binding:"omitempty,min=0"`
+	CompareAtPrice    *float64           `json:"compare_at_price" binding:"omitempty,min=-1"`   // -1 clears it
+	SalePrice         *float64           `json:"sale_price" binding:"omitempty,min=-1"`         // -1 clears the sale (and its window)
+	SaleStartsAt      *string            `json:"sale_starts_at" example:"2026-11-27T00:00:00Z"` // RFC 3339; "" clears it
+	SaleEndsAt        *string            `json:"sale_ends_at" example:"2026-12-01T00:00:00Z"`   // RFC 3339; "" clears it
+	SubCategoryID     *uuid.UUID         `json:"sub_category_id"`
+	Status            *string            `json:"status" binding:"omitempty,oneof=Active Draft"`
+	Tags              *[]string          `json:"tags"`
+	Media             *ProductMedia      `json:"media"`
+	Variants          *[]ProductVariant  `json:"variants"`
+	Inventory         *[]InventoryField  `json:"inventory"`
+	ProductType       *string            `json:"product_type" binding:"omitempty,oneof=standard bundle"`
+	BundleComponents  *[]BundleComponent `json:"bundle_components" binding:"omitempty,dive"` // Replaces the whole list
+	QuantityRules     *QuantityRules     `json:"quantity_rules"`                             // Replaces all rules ({} clears them)
+	Backorder         *BackorderPolicy   `json:"backorder"`                                  // Replaces the product's settings ({} turns backorders off)
+	SKUPattern        *string            `json:"sku_pattern"`
+	LowStockThreshold *int               `json:"low_stock_threshold" binding:"omitempty,min=-1"` // -1 clears it (inherit from category)
+	PublishAt         *string            `json:"publish_at" example:"2026-11-01T00:00:00Z"`      // RFC 3339; "" clears it
+	UnpublishAt       *string            `json:"unpublish_at" example:"2026-11-08T00:00:00Z"`    // RFC 3339; "" clears it
+	SEO               *Seo               `json:"seo"`
 }
 
 // ═══════════════════════════════════════════════════════════
@@ -138,26 +264,54 @@ type UpdateProductRequest struct {
 // ═══════════════════════════════════════════════════════════
 
 type ProductBase struct {
-	ID              uuid.UUID     `json:"id"`
-	Name            string        `json:"name"`
-	Description     string        `json:"description"`
-	Composition     []Composition `json:"composition"`
-	Price           float64       `json:"price"`
-	SubCategoryID   uuid.UUID     `json:"sub_category_id"`
-	SubCategoryName *string       `json:"sub_category_name,omitempty"`
-	Status          string        `json:"status"`
-	Tags            []string      `json:"tags"`
-	CreatedAt       time.Time     `json:"created_at"`
-	UpdatedAt       time.Time     `json:"updated_at"`
-	SubCategoryPath *string       `json:"sub_category_path,omitempty"`
+	ID                uuid.UUID     `json:"id"`
+	Name              string        `json:"name"`
+	Description       string        `json:"description"`
+	Composition       []Composition `json:"composition"`
+	Price             float64       `json:"price"`
+	CompareAtPrice    *float64      `json:"compare_at_price,omitempty"`
+	SalePrice         *float64      `json:"sale_price,omitempty"`
+	SaleStartsAt      *time.Time    `json:"sale_starts_at,omitempty"`
+	SaleEndsAt        *time.Time    `json:"sale_ends_at,omitempty"`
+	SubCategoryID     uuid.UUID     `json:"sub_category_id"`
+	SubCategoryName   *string       `json:"sub_category_name,omitempty"`
+	Status            string        `json:"status"`
+	Tags              []string      `json:"tags"`
+	CreatedAt         time.Time     `json:"created_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+	SubCategoryPath   *string       `json:"sub_category_path,omitempty"`
+	SKUPattern        string        `json:"sku_pattern,omitempty"`
+	LowStockThreshold *int          `json:"low_stock_threshold,omitempty"`
+	PublishAt         *time.Time    `json:"publish_at,omitempty"`
+	UnpublishAt       *time.Time    `json:"unpublish_at,omitempty"`
+	DisplayStatus     string        `json:"display_status"` // Status, or "Scheduled"
+	ProductType       string        `json:"product_type"`
 }
 
 type ProductResponse struct {
-	BasicInfo ProductBase      `json:"basic_info"`
-	SEO       Seo              `json:"seo"`
-	Media     ProductMedia     `json:"media"`
-	Variants  []ProductVariant `json:"variants"`
-	Inventory []InventoryField `json:"inventory"`
+	BasicInfo     ProductBase      `json:"basic_info"`
+	SEO           Seo              `json:"seo"`
+	Media         ProductMedia     `json:"media"`
+	Variants      []ProductVariant `json:"variants"`
+	Inventory     []InventoryField `json:"inventory"`
+	Bundle        *BundleDetails   `json:"bundle,omitempty"` // Bundles only: components and availability
+	QuantityRules QuantityRules    `json:"quantity_rules"`
+	Backorder     BackorderPolicy  `json:"backorder"`
+	Highlight     *SearchHighlight `json:"highlight,omitempty"` // Searches only: where the product matched
+}
+
+// TrashedProduct is a product in the trash, for the trash listing
+type TrashedProduct struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	Price           float64    `json:"price"`
+	Status          string     `json:"status"` // Status it returns with when restored
+	SubCategoryID   uuid.UUID  `json:"sub_category_id"`
+	SubCategoryName *string    `json:"sub_category_name,omitempty"`
+	PrimaryImage    *string    `json:"primary_image,omitempty"`
+	DeletedAt       time.Time  `json:"deleted_at"`
+	DeletedBy       *uuid.UUID `json:"deleted_by,omitempty"`
+	PurgeAt         time.Time  `json:"purge_at"` // When it (and its images) will be deleted for good
 }
 
 type ProductStatsResponseItem struct {
@@ -165,6 +319,7 @@ type ProductStatsResponseItem struct {
 	TotalProducts      int     `json:"total_products,omitempty"`
 	ActiveProducts     int     `json:"active_products,omitempty"`
 	DraftProducts      int     `json:"draft_products,omitempty"`
+	ScheduledProducts  int     `json:"scheduled_products,omitempty"` // Drafts with a publish_at (also counted in draft_products)
 	PercentageActive   float64 `json:"percentage_active,omitempty"`
 	AveragePrice       float64 `json:"average_price,omitempty"`
 	TotalInventory     int     `json:"total_inventory,omitempty"`
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Backorder modes. Backorders are taken while a combo is out of stock; pre-orders are
+// taken before stock has arrived at all and always have an expected ship date.
+const (
+	BackorderNone     = "none"
+	BackorderAllowed  = "backorder"
+	BackorderPreorder = "preorder"
+)
+
+// BackorderPolicy lets a product, or one of its combos, be ordered beyond its stock
+type BackorderPolicy struct {
+	Mode             string     `json:"mode,omitempty" binding:"omitempty,oneof=none backorder preorder" example:"preorder"`
+	ExpectedShipDate *time.Time `json:"expected_ship_date,omitempty" example:"2026-12-01T00:00:00Z"` // Required for pre-orders
+	Limit            int        `json:"limit,omitempty" binding:"omitempty,min=0" example:"50"`      // Max units on backorder per combo (0: no cap)
+
+	// Storefront only: units that can still be backordered (nil: no cap)
+	Available *int `json:"available,omitempty"`
+}
+
+// Allowed reports whether units beyond stock can be ordered
+func (b BackorderPolicy) Allowed() bool {
+	return b.Mode == BackorderAllowed || b.Mode == BackorderPreorder
+}
+
+// Validate checks the settings can be saved
+func (b BackorderPolicy) Validate() error {
+	switch b.Mode {
+	case "", BackorderNone, BackorderAllowed:
+	case BackorderPreorder:
+		if b.ExpectedShipDate == nil {
+			return errors.New("pre-orders need an expected_ship_date")
+		}
+	default:
+		return errors.New("backorder mode must be none, backorder or preorder")
+	}
+	if b.Limit < 0 {
+		return errors.New("backorder limit can't be negative")
+	}
+	return nil
+}
+
+// BackorderFor returns the backorder settings of a combo: its own if it has any, otherwise
+// the product's. Products without combos use the product's.
+func (p *Product) BackorderFor(item *InventoryField) BackorderPolicy {
+	if item != nil && item.Backorder != nil {
+		return *item.Backorder
+	}
+	return p.Backorder
+}
+
+// BackorderReportRow is one combo with units on backorder, for purchasing
+type BackorderReportRow struct {
+	ProductID        uuid.UUID  `json:"product_id"`
+	ProductName      string     `json:"product_name"`
+	SKU              string     `json:"sku"`
+	VariantName      string     `json:"variant_name"`
+	Mode             string     `json:"mode"` // Current setting; none if backorders have since been turned off
+	ExpectedShipDate *time.Time `json:"expected_ship_date,omitempty"`
+	OnHand           int        `json:"on_hand"`
+	Backordered      int        `json:"backordered"` // Units on open orders not yet taken from stock
+	Orders           int        `json:"orders"`
+	OldestOrderAt    time.Time  `json:"oldest_order_at"`
+	ToReorder        int        `json:"to_reorder"` // Backordered units that stock on hand doesn't cover
+}
+
+// BackorderPolicy methods
+func (b *BackorderPolicy) Scan(value interface{}) error {
+	if value == nil {
+		*b = BackorderPolicy{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan BackorderPolicy")
+	}
+	return json.Unmarshal(bytes, b)
+}
+
+func (b BackorderPolicy) Value() (driver.Value, error) {
+	return json.Marshal(b)
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Product types. Bundles (e.g. an outfit) are sold as one item at their own price but
+// hold no stock: they're made of other products' inventory combos.
+const (
+	ProductTypeStandard = "standard"
+	ProductTypeBundle   = "bundle"
+)
+
+// BundleComponent is one part of a bundle: Quantity units of a product's combo per bundle
+type BundleComponent struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required" example:"018d1234-5678-7abc-def0-123456789abc"`
+	SKU       string    `json:"sku" binding:"required" example:"linen-shirt-small-black"`
+	Quantity  int       `json:"quantity" binding:"required,min=1" example:"1"`
+}
+
+type BundleComponentList []BundleComponent
+
+// IsBundle reports whether the product is a bundle
+func (p *Product) IsBundle() bool {
+	return p.ProductType == ProductTypeBundle
+}
+
+// InStockSQL is true when a product can be bought right now: a standard product with a
+// combo in stock, or a bundle whose every component has enough stock for one bundle.
+// It expects the products table aliased as p.
+const InStockSQL = `(CASE WHEN p.product_type = 'bundle' THEN
+		jsonb_array_length(p.bundle_components) > 0 AND NOT EXISTS (
+			SELECT 1
+			FROM jsonb_array_elements(p.bundle_components) AS bc
+			WHERE NOT EXISTS (
+				SELECT 1
+				FROM products cp, jsonb_array_elements(cp.inventory) AS inv
+				WHERE cp.id = (bc->>'product_id')::uuid
+				  AND cp.status = 'Active' AND cp.deleted_at IS NULL
+				  AND LOWER(inv->>'sku') = LOWER(bc->>'sku')
+				  AND (inv->>'quantity')::int >= (bc->>'quantity')::int
+			)
+		)
+	ELSE EXISTS (
+		SELECT 1
+		FROM jsonb_array_elements(p.inventory) AS item
+		WHERE (item->>'quantity')::int > 0
+	) END)`
+
+// BundleComponentDetail is a bundle component with the product and combo it points at
+type BundleComponentDetail struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"` // Empty when the product is gone
+	Image       string    `json:"image,omitempty"`
+	SKU         string    `json:"sku"`
+	VariantName string    `json:"variant_name"`
+	Quantity    int       `json:"quantity"` // Per bundle
+	OnHand      int       `json:"on_hand"`  // 0 when the product is inactive or the combo is gone
+}
+
+// BundleDetails is a bundle's components and how many bundles they make up
+type BundleDetails struct {
+	Available  int                     `json:"available"`
+	Components []BundleComponentDetail `json:"components"`
+}
+
+// NewBundleDetails works out how many bundles the components' stock makes up
+func NewBundleDetails(components []BundleComponentDetail) *BundleDetails {
+	available := 0
+	for i, component := range components {
+		if component.Quantity <= 0 {
+			continue
+		}
+		fits := component.OnHand / component.Quantity
+		if i == 0 || fits < available {
+			available = fits
+		}
+	}
+	if available < 0 {
+		available = 0
+	}
+	return &BundleDetails{Available: available, Components: components}
+}
+
+// OrderBundleComponent is a bundle component as it was ordered, kept on the order line
+type OrderBundleComponent struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	SKU         string `json:"sku"`
+	VariantName string `json:"variant_name"`
+	Quantity    int    `json:"quantity"` // Per bundle
+}
+
+type OrderBundleComponentList []OrderBundleComponent
+
+// BundleComponentList methods
+func (b *BundleComponentList) Scan(value interface{}) error {
+	if value == nil {
+		*b = make(BundleComponentList, 0)
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan BundleComponentList")
+	}
+	return json.Unmarshal(bytes, b)
+}
+
+func (b BundleComponentList) Value() (driver.Value, error) {
+	if b == nil {
+		return json.Marshal([]BundleComponent{})
+	}
+	return json.Marshal(b)
+}
+
+// OrderBundleComponentList methods (NULL for lines that aren't bundles)
+func (o *OrderBundleComponentList) Scan(value interface{}) error {
+	if value == nil {
+		*o = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan OrderBundleComponentList")
+	}
+	return json.Unmarshal(bytes, o)
+}
+
+func (o OrderBundleComponentList) Value() (driver.Value, error) {
+	if len(o) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(o)
+}
@@ -0,0 +1,45 @@
+package models
+
+// ════════════════════════════════════════════════════════════
+// Storefront Facets
+// ════════════════════════════════════════════════════════════
+
+// ProductFacets are the storefront listing's filters, each with its options counted under
+// the shopper's other active filters
+type ProductFacets struct {
+	Total  int            `json:"total"` // Products matching every active filter
+	Facets []ProductFacet `json:"facets"`
+	Price  PriceFacet     `json:"price"`
+}
+
+// ProductFacet is one filter of the listing
+type ProductFacet struct {
+	Name    string               `json:"name" example:"Size"`
+	Param   string               `json:"param" example:"size"` // Listing parameter its option values go in
+	Options []ProductFacetOption `json:"options"`
+}
+
+// ProductFacetOption is an option of a facet; options with no products are dead ends
+type ProductFacetOption struct {
+	Label    string `json:"label" example:"M"`
+	Value    string `json:"value" example:"M"`
+	Group    string `json:"group,omitempty"` // Parent category name of a subcategory
+	Count    int    `json:"count"`
+	Selected bool   `json:"selected"`
+}
+
+// PriceFacet is the listing's price filter: the price range and fixed price buckets,
+// counted on the price the shopper pays
+type PriceFacet struct {
+	Range   PriceRange    `json:"range"`
+	Buckets []PriceBucket `json:"buckets"`
+}
+
+// PriceBucket is a minPrice/maxPrice pair (inclusive, as the listing filters)
+type PriceBucket struct {
+	Label    string   `json:"label" example:"25 - 50"`
+	Min      float64  `json:"min"`
+	Max      *float64 `json:"max"` // null: no upper bound
+	Count    int      `json:"count"`
+	Selected bool     `json:"selected"`
+}
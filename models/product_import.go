@@ -0,0 +1,58 @@
+package models
+
+import "github.com/google/uuid"
+
+// ════════════════════════════════════════════════════════════
+// Product Import/Export Models
+// ════════════════════════════════════════════════════════════
+
+// ProductImportReport is the outcome of a catalogue import (or of its dry run)
+type ProductImportReport struct {
+	Format   string                `json:"format"` // ProductImportFormat*
+	DryRun   bool                  `json:"dry_run"`
+	Rows     int                   `json:"rows"`     // Data rows read (excluding the header)
+	Products int                   `json:"products"` // Products found in the file
+	Created  int                   `json:"created"`  // Created (or that would be, on a dry run)
+	Updated  int                   `json:"updated"`  // Updated (or that would be, on a dry run)
+	Errors   []ProductImportError  `json:"errors"`   // Nothing is written when there are errors
+	Unmapped []ProductImportNote   `json:"unmapped"` // Data that couldn't be converted (doesn't block the import)
+	Results  []ProductImportResult `json:"results"`
+}
+
+// ProductImportError is a validation problem tied to a row of the file
+type ProductImportError struct {
+	Row     int    `json:"row"` // 1-based line number in the file (header is row 1)
+	Handle  string `json:"handle,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProductImportNote records source data the import dropped or had to adapt
+type ProductImportNote struct {
+	Row     int    `json:"row"` // First row affected
+	Handle  string `json:"handle,omitempty"`
+	Field   string `json:"field"`           // Source column
+	Value   string `json:"value,omitempty"` // Example of the value that wasn't converted
+	Message string `json:"message"`
+}
+
+// ProductImportResult is what happened (or would happen) to one product
+type ProductImportResult struct {
+	Row       int        `json:"row"`
+	Handle    string     `json:"handle"`
+	Name      string     `json:"name"`
+	Action    string     `json:"action"` // ProductImportCreate or ProductImportUpdate
+	ProductID *uuid.UUID `json:"product_id,omitempty"`
+}
+
+const (
+	ProductImportCreate = "create"
+	ProductImportUpdate = "update"
+)
+
+// Import file formats
+const (
+	ProductImportFormatModeva      = "modeva" // Our own layout (same as the export)
+	ProductImportFormatShopify     = "shopify"
+	ProductImportFormatWooCommerce = "woocommerce"
+)
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════
+// Sale Pricing
+// ═══════════════════════════════════════════════════════════
+//
+// A product has a regular Price, an optional CompareAtPrice (the "was" price shown
+// struck through) and an optional sale: SalePrice between SaleStartsAt and SaleEndsAt
+// (either bound may be open). Inventory combos can carry their own sale the same way.
+// The price charged for a combo is the lowest of the regular price and every sale that
+// applies to it right now; the price it's compared against is the higher of the regular
+// and compare-at prices. Sale windows are evaluated when read, so nothing has to flip
+// prices back when a sale ends.
+
+// SaleApplies reports whether a sale price is set and its window includes t
+func SaleApplies(salePrice *float64, startsAt, endsAt *time.Time, t time.Time) bool {
+	if salePrice == nil {
+		return false
+	}
+	if startsAt != nil && t.Before(*startsAt) {
+		return false
+	}
+	if endsAt != nil && !t.Before(*endsAt) {
+		return false
+	}
+	return true
+}
+
+// PriceAt returns what a combo of the product costs at t. combo may be nil (simple
+// products, or when the combo isn't known).
+func (p *Product) PriceAt(combo *InventoryField, t time.Time) float64 {
+	price := p.Price
+	if SaleApplies(p.SalePrice, p.SaleStartsAt, p.SaleEndsAt, t) && *p.SalePrice < price {
+		price = *p.SalePrice
+	}
+	if combo != nil && SaleApplies(combo.SalePrice, combo.SaleStartsAt, combo.SaleEndsAt, t) && *combo.SalePrice < price {
+		price = *combo.SalePrice
+	}
+	return price
+}
+
+// SaleEndAt returns when the sale behind PriceAt(combo, t) ends (nil when nothing is on
+// sale or the sale has no end)
+func (p *Product) SaleEndAt(combo *InventoryField, t time.Time) *time.Time {
+	price := p.PriceAt(combo, t)
+	if price >= p.Price {
+		return nil
+	}
+	if combo != nil && SaleApplies(combo.SalePrice, combo.SaleStartsAt, combo.SaleEndsAt, t) && *combo.SalePrice == price {
+		return combo.SaleEndsAt
+	}
+	return p.SaleEndsAt
+}
+
+// FromPriceAt returns the lowest price any combo of the product sells for at t
+func (p *Product) FromPriceAt(t time.Time) float64 {
+	price := p.PriceAt(nil, t)
+	for i := range p.Inventory {
+		if comboPrice := p.PriceAt(&p.Inventory[i], t); comboPrice < price {
+			price = comboPrice
+		}
+	}
+	return price
+}
+
+// OriginalPrice is the price a sale is measured against: the compare-at price when it's
+// above the regular price, otherwise the regular price
+func (p *Product) OriginalPrice() float64 {
+	if p.CompareAtPrice != nil && *p.CompareAtPrice > p.Price {
+		return *p.CompareAtPrice
+	}
+	return p.Price
+}
+
+// DiscountPercent is the whole-number discount of price against original (0 when not discounted)
+func DiscountPercent(original, price float64) int {
+	if original <= 0 || price >= original {
+		return 0
+	}
+	return int(math.Round((original - price) / original * 100))
+}
+
+// WithListPrice returns a copy of the product priced from a customer group's list price
+// (nil keeps the regular price). The list price replaces both the regular and the
+// compare-at price; sales still apply when they're lower.
+func (p *Product) WithListPrice(listPrice *float64) *Product {
+	if listPrice == nil {
+		return p
+	}
+	priced := *p
+	priced.Price = *listPrice
+	priced.CompareAtPrice = nil
+	return &priced
+}
+
+// The same rules in SQL, for storefront filters and sorting. They expect the products
+// table aliased as p.
+const (
+	// ProductSaleActiveSQL is true while the product-level sale applies
+	ProductSaleActiveSQL = `(p.sale_price IS NOT NULL
+		AND (p.sale_starts_at IS NULL OR p.sale_starts_at <= NOW())
+		AND (p.sale_ends_at IS NULL OR p.sale_ends_at > NOW()))`
+
+	// EffectivePriceSQL is the lowest price any combo of the product sells for right now
+	EffectivePriceSQL = `LEAST(
+		p.price,` + saleFloorSQL + `
+	)`
+
+	// OriginalPriceSQL is the price the effective price is compared against
+	OriginalPriceSQL = `GREATEST(p.price, COALESCE(p.compare_at_price, 0))`
+
+	// saleFloorSQL lists the active product and combo sale prices, for LEAST
+	saleFloorSQL = `
+		CASE WHEN ` + ProductSaleActiveSQL + ` THEN p.sale_price END,
+		(SELECT MIN((inv->>'sale_price')::numeric)
+		 FROM jsonb_array_elements(p.inventory) AS inv
+		 WHERE inv->>'sale_price' IS NOT NULL
+		   AND (inv->>'sale_starts_at' IS NULL OR (inv->>'sale_starts_at')::timestamptz <= NOW())
+		   AND (inv->>'sale_ends_at' IS NULL OR (inv->>'sale_ends_at')::timestamptz > NOW()))`
+)
+
+// groupListPriceSQL is a customer group's list price for p (NULL when it has none).
+// The group ID is a parsed UUID, so it's safe to inline.
+func groupListPriceSQL(groupID uuid.UUID) string {
+	return fmt.Sprintf(`(SELECT gp.price FROM customer_group_prices gp
+		WHERE gp.customer_group_id = '%s'::uuid AND gp.product_id = p.id)`, groupID)
+}
+
+// EffectivePriceSQLFor is EffectivePriceSQL for members of a customer group (nil: everyone else)
+func EffectivePriceSQLFor(groupID *uuid.UUID) string {
+	if groupID == nil {
+		return EffectivePriceSQL
+	}
+	return `LEAST(
+		COALESCE(` + groupListPriceSQL(*groupID) + `, p.price),` + saleFloorSQL + `
+	)`
+}
+
+// OriginalPriceSQLFor is OriginalPriceSQL for members of a customer group (nil: everyone else)
+func OriginalPriceSQLFor(groupID *uuid.UUID) string {
+	if groupID == nil {
+		return OriginalPriceSQL
+	}
+	return `COALESCE(` + groupListPriceSQL(*groupID) + `, ` + OriginalPriceSQL + `)`
+}
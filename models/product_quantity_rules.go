@@ -0,0 +1,84 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// QuantityRules limit how many units of a product a customer can buy. Units of every
+// combo of the product count together. Zero fields don't apply.
+type QuantityRules struct {
+	MinPerOrder    int `json:"min_per_order,omitempty" binding:"omitempty,min=0" example:"1"`
+	MaxPerOrder    int `json:"max_per_order,omitempty" binding:"omitempty,min=0" example:"2"`
+	MaxPerCustomer int `json:"max_per_customer,omitempty" binding:"omitempty,min=0" example:"2"`
+	WindowDays     int `json:"max_per_customer_window_days,omitempty" binding:"omitempty,min=0" example:"30"` // 0: ever
+	Step           int `json:"step,omitempty" binding:"omitempty,min=0" example:"3"`                          // Sold in multiples of this
+}
+
+// IsZero reports whether no rule applies
+func (r QuantityRules) IsZero() bool {
+	return r == QuantityRules{}
+}
+
+// Validate checks that the rules can be satisfied together
+func (r QuantityRules) Validate() error {
+	if r.MinPerOrder < 0 || r.MaxPerOrder < 0 || r.MaxPerCustomer < 0 || r.WindowDays < 0 || r.Step < 0 {
+		return errors.New("quantity rules can't be negative")
+	}
+	if r.MaxPerOrder > 0 && r.MinPerOrder > r.MaxPerOrder {
+		return errors.New("min_per_order can't be above max_per_order")
+	}
+	if r.MaxPerCustomer > 0 && r.MinPerOrder > r.MaxPerCustomer {
+		return errors.New("min_per_order can't be above max_per_customer")
+	}
+	if r.WindowDays > 0 && r.MaxPerCustomer == 0 {
+		return errors.New("max_per_customer_window_days needs max_per_customer")
+	}
+	if r.Step > 1 {
+		if r.MinPerOrder%r.Step != 0 {
+			return fmt.Errorf("min_per_order must be a multiple of step (%d)", r.Step)
+		}
+		if r.MaxPerOrder%r.Step != 0 {
+			return fmt.Errorf("max_per_order must be a multiple of step (%d)", r.Step)
+		}
+		if r.MaxPerCustomer%r.Step != 0 {
+			return fmt.Errorf("max_per_customer must be a multiple of step (%d)", r.Step)
+		}
+	}
+	return nil
+}
+
+// CheckOrder returns why quantity units, plus bundled units in bundles, can't be ordered at
+// once ("" if they can). Bundled units only count towards the maximum. The per-customer
+// limit needs the customer's history and isn't checked here.
+func (r QuantityRules) CheckOrder(quantity, bundled int) string {
+	if r.MinPerOrder > 0 && quantity > 0 && quantity < r.MinPerOrder {
+		return fmt.Sprintf("at least %d must be ordered", r.MinPerOrder)
+	}
+	if r.MaxPerOrder > 0 && quantity+bundled > r.MaxPerOrder {
+		return fmt.Sprintf("at most %d can be ordered at once", r.MaxPerOrder)
+	}
+	if r.Step > 1 && quantity%r.Step != 0 {
+		return fmt.Sprintf("sold in multiples of %d", r.Step)
+	}
+	return ""
+}
+
+// QuantityRules methods
+func (r *QuantityRules) Scan(value interface{}) error {
+	if value == nil {
+		*r = QuantityRules{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan QuantityRules")
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+func (r QuantityRules) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
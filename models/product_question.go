@@ -0,0 +1,138 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Moderation statuses of questions and answers
+const (
+	QuestionPending  = "pending"
+	QuestionApproved = "approved"
+	QuestionRejected = "rejected"
+)
+
+// StoreAnswererName is shown on answers given by the store
+const StoreAnswererName = "Modeva"
+
+// ProductQuestion is a customer's question about a product (ecommerce DB). It's shown on
+// the storefront once approved and answered.
+type ProductQuestion struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID       uuid.UUID  `json:"product_id" gorm:"type:uuid;not null"`
+	UserID          uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	Question        string     `json:"question" gorm:"type:text;not null"`
+	Status          string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	RejectionReason *string    `json:"rejection_reason,omitempty" gorm:"type:text"`
+	ModeratedBy     *uuid.UUID `json:"moderated_by,omitempty" gorm:"type:uuid"`
+	ModeratedAt     *time.Time `json:"moderated_at,omitempty"`
+	AnsweredAt      *time.Time `json:"answered_at,omitempty"`       // When the first answer was published
+	AskerNotifiedAt *time.Time `json:"asker_notified_at,omitempty"` // When the asker was emailed about it
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (q *ProductQuestion) BeforeCreate(tx *gorm.DB) error {
+	if q.ID == uuid.Nil {
+		q.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (ProductQuestion) TableName() string {
+	return "product_questions"
+}
+
+// ProductAnswer is an answer to a product question, from the store (AdminID) or from a
+// customer who bought the product (UserID). Customers' answers are moderated.
+type ProductAnswer struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	QuestionID  uuid.UUID  `json:"question_id" gorm:"type:uuid;not null"`
+	UserID      *uuid.UUID `json:"user_id,omitempty" gorm:"type:uuid"`
+	AdminID     *uuid.UUID `json:"admin_id,omitempty" gorm:"type:uuid"`
+	Answer      string     `json:"answer" gorm:"type:text;not null"`
+	Status      string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	ModeratedBy *uuid.UUID `json:"moderated_by,omitempty" gorm:"type:uuid"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (a *ProductAnswer) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (ProductAnswer) TableName() string {
+	return "product_answers"
+}
+
+// ════════════════════════════════════════════════════════════
+// Request/Response Models
+// ════════════════════════════════════════════════════════════
+
+// ProductQuestionRequest asks a question about a product
+type ProductQuestionRequest struct {
+	Question string `json:"question" binding:"required,max=1000" example:"Does the shirt run large?"`
+}
+
+// ProductAnswerRequest answers a product question
+type ProductAnswerRequest struct {
+	Answer string `json:"answer" binding:"required,max=2000" example:"It's true to size; size down for a slimmer fit."`
+}
+
+// RejectQuestionRequest rejects a question, optionally saying why (for the CMS only)
+type RejectQuestionRequest struct {
+	Reason string `json:"reason" binding:"max=500" example:"Asks about another store's order"`
+}
+
+// AdminAnswer is an answer as listed in the CMS
+type AdminAnswer struct {
+	ProductAnswer
+	AnswererName string `json:"answerer_name"`
+}
+
+// AdminQuestion is a question in the CMS queue, with its product, asker and answers
+type AdminQuestion struct {
+	ProductQuestion
+	ProductName   string        `json:"product_name"` // Empty when the product is gone
+	CustomerName  string        `json:"customer_name"`
+	CustomerEmail string        `json:"customer_email"`
+	Answers       []AdminAnswer `json:"answers" gorm:"-"`
+}
+
+// QuestionQueueCounts are the sizes of the CMS question queues
+type QuestionQueueCounts struct {
+	Pending        int64 `json:"pending"`         // Questions waiting for moderation
+	Unanswered     int64 `json:"unanswered"`      // Questions not rejected and without a published answer
+	Answered       int64 `json:"answered"`        // Questions with a published answer
+	PendingAnswers int64 `json:"pending_answers"` // Customers' answers waiting for moderation
+}
+
+// StorefrontAnswer is a published answer as shown on a product page
+type StorefrontAnswer struct {
+	ID            uuid.UUID `json:"id"`
+	Answer        string    `json:"answer"`
+	AnswererName  string    `json:"answerer_name"`
+	FromStore     bool      `json:"from_store"`
+	VerifiedBuyer bool      `json:"verified_buyer"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// StorefrontQuestion is an answered question as shown on a product page
+type StorefrontQuestion struct {
+	ID         uuid.UUID          `json:"id"`
+	Question   string             `json:"question"`
+	AskerName  string             `json:"asker_name"`
+	CreatedAt  time.Time          `json:"created_at"`
+	AnsweredAt time.Time          `json:"answered_at"`
+	Answers    []StorefrontAnswer `json:"answers"`
+}
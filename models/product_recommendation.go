@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Where a recommendation comes from
+const (
+	RecommendationSourceRelated        = "related"                    // Curated in the CMS
+	RecommendationSourceBoughtTogether = "frequently_bought_together" // Mined from completed orders
+)
+
+// RelatedProduct is a product curated as related to another, shown in Position order
+type RelatedProduct struct {
+	ProductID        uuid.UUID `json:"product_id" gorm:"type:uuid;primaryKey"`
+	RelatedProductID uuid.UUID `json:"related_product_id" gorm:"type:uuid;primaryKey"`
+	Position         int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (RelatedProduct) TableName() string {
+	return "related_products"
+}
+
+// ProductCoPurchase is a product that completed orders of another product also contained
+type ProductCoPurchase struct {
+	ProductID        uuid.UUID `json:"product_id" gorm:"type:uuid;primaryKey"`
+	RelatedProductID uuid.UUID `json:"related_product_id" gorm:"type:uuid;primaryKey"`
+	Orders           int       `json:"orders" gorm:"not null"`                       // Completed orders with both
+	Confidence       float64   `json:"confidence" gorm:"type:numeric(5,4);not null"` // Share of ProductID's orders that had both
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
+// TableName specifies the table name
+func (ProductCoPurchase) TableName() string {
+	return "product_co_purchases"
+}
+
+// ═══════════════════════════════════════════════════════════
+// Request / Response Models
+// ═══════════════════════════════════════════════════════════
+
+// SetRelatedProductsRequest replaces a product's related products, in display order
+type SetRelatedProductsRequest struct {
+	ProductIDs []uuid.UUID `json:"product_ids" binding:"max=20" example:"['018d1234-5678-7abc-def0-123456789abc']"`
+}
+
+// RelatedProductSummary is a related product as listed in the CMS
+type RelatedProductSummary struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Image    string    `json:"image"`
+	Status   string    `json:"status"`
+	Position int       `json:"position"`
+}
+
+// CoPurchaseSummary is a "frequently bought together" product as listed in the CMS
+type CoPurchaseSummary struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Image      string    `json:"image"`
+	Status     string    `json:"status"`
+	Orders     int       `json:"orders"`
+	Confidence float64   `json:"confidence"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// StorefrontRecommendation is a recommended product on a product page
+type StorefrontRecommendation struct {
+	StorefrontProductResponse
+	Source string `json:"source"` // related or frequently_bought_together
+}
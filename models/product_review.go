@@ -0,0 +1,141 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// ProductReview is a customer's review of a product they bought (ecommerce DB). Reviews
+// are held as pending until an admin approves or rejects them; only approved reviews are
+// shown on the storefront and count towards the product's rating.
+type ProductReview struct {
+	ID              uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID       uuid.UUID    `json:"product_id" gorm:"type:uuid;not null"`
+	UserID          uuid.UUID    `json:"user_id" gorm:"type:uuid;not null"`
+	OrderID         *uuid.UUID   `json:"order_id,omitempty" gorm:"type:uuid"` // Completed order that verified the purchase
+	Rating          int          `json:"rating" gorm:"not null"`
+	Title           string       `json:"title" gorm:"type:varchar(150);not null"`
+	Body            string       `json:"body" gorm:"type:text;not null"`
+	Photos          ReviewPhotos `json:"photos" gorm:"type:jsonb;not null;default:'[]'"`
+	Status          string       `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	RejectionReason *string      `json:"rejection_reason,omitempty" gorm:"type:text"`
+	AdminReply      *string      `json:"admin_reply,omitempty" gorm:"type:text"`
+	RepliedAt       *time.Time   `json:"replied_at,omitempty"`
+	ModeratedBy     *uuid.UUID   `json:"moderated_by,omitempty" gorm:"type:uuid"`
+	ModeratedAt     *time.Time   `json:"moderated_at,omitempty"`
+	CreatedAt       time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (r *ProductReview) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (ProductReview) TableName() string {
+	return "product_reviews"
+}
+
+// ReviewPhotos are the URLs of a review's photos (uploaded to Cloudinary by the client)
+type ReviewPhotos []string
+
+// ReviewPhotos methods
+func (p *ReviewPhotos) Scan(value interface{}) error {
+	if value == nil {
+		*p = make(ReviewPhotos, 0)
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan ReviewPhotos")
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (p ReviewPhotos) Value() (driver.Value, error) {
+	if p == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal(p)
+}
+
+// ReviewerDisplayName shortens a customer's name for public display ("Jane Doe" → "Jane D.")
+func ReviewerDisplayName(name string) string {
+	parts := strings.Fields(name)
+	switch len(parts) {
+	case 0:
+		return "Customer"
+	case 1:
+		return parts[0]
+	default:
+		last := []rune(parts[len(parts)-1])
+		return parts[0] + " " + strings.ToUpper(string(last[0])) + "."
+	}
+}
+
+// ════════════════════════════════════════════════════════════
+// Request/Response Models
+// ════════════════════════════════════════════════════════════
+
+// ProductReviewRequest is a customer's review of a product they have received
+type ProductReviewRequest struct {
+	Rating int      `json:"rating" binding:"required,min=1,max=5" example:"5"`
+	Title  string   `json:"title" binding:"required,max=150" example:"Perfect summer shirt"`
+	Body   string   `json:"body" binding:"required,max=5000" example:"Light, breathable and true to size."`
+	Photos []string `json:"photos" binding:"max=5,dive,url" example:"['https://res.cloudinary.com/demo/image/upload/review.jpg']"`
+}
+
+// RejectReviewRequest rejects a review, optionally saying why (for the CMS only)
+type RejectReviewRequest struct {
+	Reason string `json:"reason" binding:"max=500" example:"Contains personal information"`
+}
+
+// ReviewReplyRequest is the store's public reply to a review
+type ReviewReplyRequest struct {
+	Reply string `json:"reply" binding:"required,max=2000" example:"Thanks for the kind words!"`
+}
+
+// AdminReview is a review in the CMS moderation queue, with its product and customer
+type AdminReview struct {
+	ProductReview
+	ProductName   string `json:"product_name"` // Empty when the product is gone
+	CustomerName  string `json:"customer_name"`
+	CustomerEmail string `json:"customer_email"`
+}
+
+// StorefrontReview is an approved review as shown on a product page
+type StorefrontReview struct {
+	ID               uuid.UUID    `json:"id"`
+	Rating           int          `json:"rating"`
+	Title            string       `json:"title"`
+	Body             string       `json:"body"`
+	Photos           ReviewPhotos `json:"photos"`
+	ReviewerName     string       `json:"reviewer_name"`
+	VerifiedPurchase bool         `json:"verified_purchase"`
+	AdminReply       *string      `json:"admin_reply,omitempty"`
+	RepliedAt        *time.Time   `json:"replied_at,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+// RatingSummary is a product's average rating, review count and count per star rating
+type RatingSummary struct {
+	Average      float64     `json:"average"`
+	Count        int         `json:"count"`
+	Distribution map[int]int `json:"distribution"` // Star rating → approved reviews
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductRevision is an append-only snapshot of a product taken on every save
+type ProductRevision struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID     uuid.UUID       `json:"product_id" gorm:"type:uuid;not null;index"`
+	Revision      int             `json:"revision" gorm:"not null"` // 1, 2, 3... per product
+	Snapshot      ProductSnapshot `json:"snapshot" gorm:"type:jsonb;not null"`
+	ChangedFields TagsList        `json:"changed_fields" gorm:"type:jsonb;not null;default:'[]'"` // Top-level fields changed since the previous revision
+	Source        string          `json:"source" gorm:"not null"`                                 // RevisionSourceUpdate, RevisionSourceRestore, ...
+	RestoredFrom  *int            `json:"restored_from,omitempty"`                                // Revision a restore copied
+	AdminID       *uuid.UUID      `json:"admin_id,omitempty" gorm:"type:uuid"`
+	AdminEmail    *string         `json:"admin_email,omitempty"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (pr *ProductRevision) BeforeCreate(tx *gorm.DB) error {
+	if pr.ID == uuid.Nil {
+		pr.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (ProductRevision) TableName() string {
+	return "product_revisions"
+}
+
+// ProductSnapshot holds the editable fields of a product at one revision
+type ProductSnapshot struct {
+	Name              string              `json:"name"`
+	Description       string              `json:"description"`
+	Price             float64             `json:"price"`
+	Status            string              `json:"status"`
+	SubCategoryID     uuid.UUID           `json:"sub_category_id"`
+	Tags              TagsList            `json:"tags"`
+	Composition       CompositionList     `json:"composition"`
+	Media             ProductMedia        `json:"media"`
+	Variants          VariantsList        `json:"variants"`
+	Inventory         InventoryList       `json:"inventory"`
+	BundleComponents  BundleComponentList `json:"bundle_components,omitempty"` // Bundles only
+	QuantityRules     QuantityRules       `json:"quantity_rules"`
+	Backorder         BackorderPolicy     `json:"backorder"`
+	SKUPattern        string              `json:"sku_pattern"`
+	LowStockThreshold *int                `json:"low_stock_threshold"`
+	PublishAt         *time.Time          `json:"publish_at"`
+	UnpublishAt       *time.Time          `json:"unpublish_at"`
+	CompareAtPrice    *float64            `json:"compare_at_price"`
+	SalePrice         *float64            `json:"sale_price"`
+	SaleStartsAt      *time.Time          `json:"sale_starts_at"`
+	SaleEndsAt        *time.Time          `json:"sale_ends_at"`
+	SEO               Seo                 `json:"seo"`
+}
+
+// SnapshotOf copies the editable fields of a product
+func SnapshotOf(p *Product) ProductSnapshot {
+	return ProductSnapshot{
+		Name:              p.Name,
+		Description:       p.Description,
+		Price:             p.Price,
+		Status:            p.Status,
+		SubCategoryID:     p.SubCategoryID,
+		Tags:              p.Tags,
+		Composition:       p.Composition,
+		Media:             p.Media,
+		Variants:          p.Variants,
+		Inventory:         p.Inventory,
+		BundleComponents:  p.BundleComponents,
+		QuantityRules:     p.QuantityRules,
+		Backorder:         p.Backorder,
+		SKUPattern:        p.SKUPattern,
+		LowStockThreshold: p.LowStockThreshold,
+		PublishAt:         p.PublishAt,
+		UnpublishAt:       p.UnpublishAt,
+		CompareAtPrice:    p.CompareAtPrice,
+		SalePrice:         p.SalePrice,
+		SaleStartsAt:      p.SaleStartsAt,
+		SaleEndsAt:        p.SaleEndsAt,
+		SEO:               p.SEO,
+	}
+}
+
+// ProductSnapshot methods
+func (s *ProductSnapshot) Scan(value interface{}) error {
+	if value == nil {
+		*s = ProductSnapshot{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan ProductSnapshot")
+	}
+	return json.Unmarshal(bytes, s)
+}
+
+func (s ProductSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// ════════════════════════════════════════════════════════════
+// Request Models
+// ════════════════════════════════════════════════════════════
+
+// RestoreProductRevisionRequest limits a restore to some fields (default: all of them)
+type RestoreProductRevisionRequest struct {
+	Fields []string `json:"fields" binding:"omitempty,dive,oneof=name description price status sub_category_id tags composition media variants inventory bundle_components quantity_rules backorder sku_pattern low_stock_threshold publish_at unpublish_at compare_at_price sale_price sale_starts_at sale_ends_at seo" example:"['description', 'variants']"`
+}
+
+// RestorableProductFields are the snapshot fields a restore can bring back
+var RestorableProductFields = []string{
+	"name", "description", "price", "status", "sub_category_id", "tags", "composition",
+	"media", "variants", "inventory", "bundle_components", "quantity_rules", "backorder", "sku_pattern", "low_stock_threshold", "publish_at", "unpublish_at",
+	"compare_at_price", "sale_price", "sale_starts_at", "sale_ends_at", "seo",
+}
+
+// ════════════════════════════════════════════════════════════
+// Response Models
+// ════════════════════════════════════════════════════════════
+
+// ProductRevisionSummary is a revision without its snapshot, for listings
+type ProductRevisionSummary struct {
+	ID            uuid.UUID  `json:"id"`
+	ProductID     uuid.UUID  `json:"product_id"`
+	Revision      int        `json:"revision"`
+	ChangedFields TagsList   `json:"changed_fields"`
+	Source        string     `json:"source"`
+	RestoredFrom  *int       `json:"restored_from,omitempty"`
+	AdminID       *uuid.UUID `json:"admin_id,omitempty"`
+	AdminEmail    *string    `json:"admin_email,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ProductFieldChange is one difference between two snapshots. Field is a path such as
+// "seo.seo_title", "variants[Size].options" or "inventory[Small / Black].sku";
+// list entries are keyed by their natural key rather than their position.
+type ProductFieldChange struct {
+	Field  string      `json:"field"`
+	Change string      `json:"change"` // FieldChangeAdded, FieldChangeRemoved, FieldChangeChanged, FieldChangeReordered
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// ProductRevisionDiff compares a revision with another (by default the one before it)
+type ProductRevisionDiff struct {
+	Revision ProductRevision      `json:"revision"`
+	Against  *int                 `json:"against"` // nil for the first revision (everything is new)
+	Changes  []ProductFieldChange `json:"changes"`
+}
+
+// ProductRestoreResponse is returned after restoring a revision
+type ProductRestoreResponse struct {
+	Product  Product         `json:"product"`
+	Revision ProductRevision `json:"revision"` // The new revision the restore created
+}
+
+// ════════════════════════════════════════════════════════════
+// Source & Change Constants
+// ════════════════════════════════════════════════════════════
+
+const (
+	RevisionSourceInitial  = "initial" // Seeded when revisions were introduced
+	RevisionSourceCreate   = "create"
+	RevisionSourceUpdate   = "update"
+	RevisionSourceImport   = "import"
+	RevisionSourceRestore  = "restore"
+	RevisionSourceSchedule = "schedule" // Published or unpublished by the product scheduler
+
+	FieldChangeAdded     = "added"
+	FieldChangeRemoved   = "removed"
+	FieldChangeChanged   = "changed"
+	FieldChangeReordered = "reordered"
+)
@@ -0,0 +1,67 @@
+package models
+
+import "gorm.io/gorm"
+
+// ═══════════════════════════════════════════════════════════
+// Product Search
+// ═══════════════════════════════════════════════════════════
+//
+// Products carry a weighted tsvector (search_vector: name, tags, category path,
+// description) kept up to date by triggers. A search matches products whose vector
+// matches the query, or whose name is close to it word by word (pg_trgm), so typos still
+// find something. Results are ranked by both.
+//
+// The SQL below expects the products table aliased as p and the search joined as sq:
+//
+//	FROM products p CROSS JOIN LATERAL (SELECT ... ) sq
+//
+// built with SearchJoinSQL, whose two placeholders take the full-text query and the search
+// as typed (the same text, unless search rules expanded the query; see SearchPlan). Run
+// queries that match with RunSearch.
+
+// SearchJoinSQL parses the search once per query (websearch syntax: quoted phrases, -word, or)
+const SearchJoinSQL = `CROSS JOIN LATERAL (SELECT websearch_to_tsquery('english', ?) AS query, ?::text AS term) sq`
+
+// SearchNameSimilaritySQL is the name word similarity a search needs (0-1) to find a
+// product by name alone, e.g. "dreses" for "Summer Dress"
+const SearchNameSimilaritySQL = `0.4`
+
+// SearchMatchSQL is true for products that match the search: on the full text, or on a
+// name word similarity of at least SearchNameSimilaritySQL. The <% operator, unlike a
+// comparison of word_similarity(), can use the trigram index on lower(name), but goes by
+// pg_trgm.word_similarity_threshold, which RunSearch sets to the same; the comparison keeps
+// the match to it whatever the setting.
+const SearchMatchSQL = `(p.search_vector @@ sq.query OR (sq.term <% lower(p.name) AND word_similarity(sq.term, lower(p.name)) >= ` + SearchNameSimilaritySQL + `))`
+
+// SearchRankSQL scores a match: full-text rank (0-1, name matches weigh most) plus a
+// smaller share of name similarity so near-misses rank below real matches
+const SearchRankSQL = `(ts_rank_cd(p.search_vector, sq.query, 32) + 0.3 * word_similarity(sq.term, lower(p.name)))`
+
+// SearchHeadlineNameSQL is the product name with matched words wrapped in <mark>
+const SearchHeadlineNameSQL = `ts_headline('english', p.name, sq.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')`
+
+// SearchSnippetSQL is the part of the description that best matches the search, with
+// matched words wrapped in <mark> (the start of the description when none match)
+const SearchSnippetSQL = `ts_headline('english', p.description, sq.query, 'StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=12, MaxFragments=1')`
+
+// RunSearch runs fn against db, when searching in a transaction that sets
+// pg_trgm.word_similarity_threshold to SearchNameSimilaritySQL for the <% in SearchMatchSQL
+// (the default, 0.6, misses typos). Set per transaction, it holds on pooled connections and
+// whatever the database's settings.
+func RunSearch(db *gorm.DB, searching bool, fn func(db *gorm.DB) error) error {
+	if !searching {
+		return fn(db)
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SET LOCAL pg_trgm.word_similarity_threshold = ` + SearchNameSimilaritySQL).Error; err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// SearchHighlight shows where a product matched a search
+type SearchHighlight struct {
+	Name    string `json:"name"`    // Name with matched words in <mark>
+	Snippet string `json:"snippet"` // Best-matching part of the description, matched words in <mark>
+}
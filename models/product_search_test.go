@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestSearchSQLAgree(t *testing.T) {
+	// The <% match (indexable) and the explicit threshold must look at the same name form,
+	// and the rank must score what the match matched
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{"match uses the trigram operator on the indexed expression", SearchMatchSQL, []string{"sq.term <% lower(p.name)"}},
+		{"match holds the threshold itself", SearchMatchSQL, []string{"word_similarity(sq.term, lower(p.name)) >= " + SearchNameSimilaritySQL}},
+		{"match keeps full text", SearchMatchSQL, []string{"p.search_vector @@ sq.query"}},
+		{"rank scores the same similarity", SearchRankSQL, []string{"word_similarity(sq.term, lower(p.name))", "ts_rank_cd(p.search_vector, sq.query"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, want := range tt.want {
+				if !strings.Contains(tt.sql, want) {
+					t.Errorf("%s\ndoesn't contain %s", tt.sql, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunSearchWithoutSearch(t *testing.T) {
+	// Listings without a search run as they are, outside a transaction
+	db := &gorm.DB{}
+	called := false
+	if err := RunSearch(db, false, func(got *gorm.DB) error {
+		called = true
+		if got != db {
+			t.Errorf("RunSearch() passed another handle")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("RunSearch() error = %v", err)
+	}
+	if !called {
+		t.Error("RunSearch() didn't run the query")
+	}
+}
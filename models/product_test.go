@@ -0,0 +1,67 @@
+package models
+
+import "testing"
+
+func TestInventoryListIndexOfOptions(t *testing.T) {
+	sizeColour := InventoryList{
+		{Combo: []string{"Small", "Black"}},
+		{Combo: []string{"Small", "White"}},
+		{Combo: []string{"Large", "Black"}},
+	}
+	sizeOnly := InventoryList{
+		{Combo: []string{"Small"}},
+		{Combo: []string{"Large"}},
+	}
+	single := InventoryList{
+		{Combo: []string{"One Size"}},
+	}
+
+	tests := []struct {
+		name      string
+		inventory InventoryList
+		options   []string
+		want      int
+	}{
+		{"every option given", sizeColour, []string{"Large", "Black"}, 2},
+		{"case-insensitive", sizeColour, []string{"small", "WHITE"}, 1},
+		{"order doesn't matter", sizeColour, []string{"Black", "Small"}, 0},
+		{"partial selection matching one combo", sizeColour, []string{"Large", ""}, 2},
+		{"partial selection matching several combos", sizeColour, []string{"Small", ""}, -1},
+		{"colour alone matching several combos", sizeColour, []string{"", "Black"}, -1},
+		{"no such combo", sizeColour, []string{"Large", "White"}, -1},
+		{"unknown option", sizeColour, []string{"Medium"}, -1},
+		{"single variant type", sizeOnly, []string{"Large", ""}, 1},
+		{"no options, several combos", sizeColour, []string{"", ""}, -1},
+		{"no options, single combo", single, nil, 0},
+		{"empty inventory", nil, []string{"Small"}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.inventory.IndexOfOptions(tt.options...); got != tt.want {
+				t.Errorf("IndexOfOptions(%q) = %d, want %d", tt.options, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryListIndexOfSKU(t *testing.T) {
+	inventory := InventoryList{
+		{SKU: "shirt-s"},
+		{SKU: ""},
+		{SKU: "SHIRT-L"},
+	}
+	tests := []struct {
+		sku  string
+		want int
+	}{
+		{"shirt-s", 0},
+		{"shirt-l", 2},
+		{"", -1},
+		{"shirt-m", -1},
+	}
+	for _, tt := range tests {
+		if got := inventory.IndexOfSKU(tt.sku); got != tt.want {
+			t.Errorf("IndexOfSKU(%q) = %d, want %d", tt.sku, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Where a logged search was made
+const (
+	SearchSourceSearch  = "search"  // Storefront product listing with q
+	SearchSourceSuggest = "suggest" // Search box suggestions
+)
+
+// SearchLog is one storefront search (CMS DB), with the clicks on its results
+type SearchLog struct {
+	ID               uuid.UUID     `json:"id" gorm:"type:uuid;primaryKey"`
+	Query            string        `json:"query" gorm:"type:varchar(100);not null"` // Normalized
+	Source           string        `json:"source" gorm:"type:varchar(20);not null"`
+	ResultCount      int           `json:"result_count" gorm:"not null;default:0"`
+	Filters          SearchFilters `json:"filters" gorm:"type:jsonb;not null;default:'{}'"`
+	UserID           *uuid.UUID    `json:"user_id,omitempty" gorm:"type:uuid"` // Logged-in customer
+	Clicks           int           `json:"clicks" gorm:"not null;default:0"`
+	ClickedProductID *uuid.UUID    `json:"clicked_product_id,omitempty" gorm:"type:uuid"` // First product clicked
+	FirstClickedAt   *time.Time    `json:"first_clicked_at,omitempty"`
+	CreatedAt        time.Time     `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (l *SearchLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (SearchLog) TableName() string {
+	return "search_logs"
+}
+
+// SearchFilters are the listing parameters a search was made with (filter → values)
+type SearchFilters map[string][]string
+
+// SearchFilters methods
+func (f *SearchFilters) Scan(value interface{}) error {
+	if value == nil {
+		*f = make(SearchFilters)
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan SearchFilters")
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+func (f SearchFilters) Value() (driver.Value, error) {
+	if f == nil {
+		return json.Marshal(map[string][]string{})
+	}
+	return json.Marshal(f)
+}
+
+// ════════════════════════════════════════════════════════════
+// Request/Response Models
+// ════════════════════════════════════════════════════════════
+
+// SearchClickRequest records a click on a search result
+type SearchClickRequest struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required" example:"01936f8e-1234-7000-8000-000000000000"`
+}
+
+// SearchQueryStats are how a query did over a date range
+type SearchQueryStats struct {
+	Query              string    `json:"query"`
+	Searches           int       `json:"searches"`
+	AverageResults     float64   `json:"average_results"`
+	ZeroResultSearches int       `json:"zero_result_searches"`
+	ClickedSearches    int       `json:"clicked_searches"`   // Searches with at least one result clicked
+	ClickThroughRate   float64   `json:"click_through_rate"` // clicked_searches / searches (0-1)
+	LastSearchedAt     time.Time `json:"last_searched_at"`
+}
+
+// SearchAnalyticsTotals are all the searches of a date range
+type SearchAnalyticsTotals struct {
+	Searches         int     `json:"searches"`
+	UniqueQueries    int     `json:"unique_queries"`
+	ZeroResultRate   float64 `json:"zero_result_rate"`   // Share of searches that found nothing (0-1)
+	ClickThroughRate float64 `json:"click_through_rate"` // Share of searches with a result clicked (0-1)
+}
+
+// SearchAnalyticsReport is a search analytics report over a date range
+type SearchAnalyticsReport struct {
+	From    string                `json:"from"` // YYYY-MM-DD, inclusive
+	To      string                `json:"to"`   // YYYY-MM-DD, inclusive
+	Source  string                `json:"source"`
+	Totals  SearchAnalyticsTotals `json:"totals"`
+	Queries []SearchQueryStats    `json:"queries"`
+}
@@ -0,0 +1,221 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Search pin actions
+const (
+	SearchPinTop  = "pin"  // Shown first, in position order
+	SearchPinBury = "bury" // Shown last
+)
+
+// SearchSynonymGroup is a group of interchangeable search terms (CMS DB): a storefront
+// search containing any of them also matches the others
+type SearchSynonymGroup struct {
+	ID        uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	Terms     SynonymTerms `json:"terms" gorm:"type:jsonb;not null;default:'[]'"`
+	CreatedAt time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (g *SearchSynonymGroup) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (SearchSynonymGroup) TableName() string {
+	return "search_synonyms"
+}
+
+// SynonymTerms are the terms of a synonym group, lowercased
+type SynonymTerms []string
+
+// SynonymTerms methods
+func (t *SynonymTerms) Scan(value interface{}) error {
+	if value == nil {
+		*t = make(SynonymTerms, 0)
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan SynonymTerms")
+	}
+	return json.Unmarshal(bytes, t)
+}
+
+func (t SynonymTerms) Value() (driver.Value, error) {
+	if t == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal(t)
+}
+
+// SearchRedirect sends a storefront search to a page instead of the results (CMS DB)
+type SearchRedirect struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Query     string    `json:"query" gorm:"type:varchar(100);not null;uniqueIndex"` // Lowercased
+	URL       string    `json:"url" gorm:"type:varchar(500);not null"`               // Storefront path or absolute URL
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (r *SearchRedirect) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (SearchRedirect) TableName() string {
+	return "search_redirects"
+}
+
+// SearchPin pins a product to the top of a search's results, or buries it at the bottom
+// (CMS DB). Pinned products are shown even when they don't match the search.
+type SearchPin struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Query     string    `json:"query" gorm:"type:varchar(100);not null"` // Lowercased
+	ProductID uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
+	Action    string    `json:"action" gorm:"type:varchar(10);not null"` // pin or bury
+	Position  int       `json:"position" gorm:"not null;default:0"`      // Order among the query's pins, lowest first
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (p *SearchPin) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (SearchPin) TableName() string {
+	return "search_pins"
+}
+
+// ════════════════════════════════════════════════════════════
+// Search Plan
+// ════════════════════════════════════════════════════════════
+
+// SearchPlan is a storefront search after the search rules are applied: the full-text
+// query with synonyms, where to redirect, and what to pin or bury
+type SearchPlan struct {
+	Query       string      `json:"query"`                  // As typed, trimmed (also used for typo-tolerant name matching)
+	Normalized  string      `json:"normalized"`             // Lowercased and single-spaced, as rules are matched
+	Expanded    string      `json:"expanded"`               // Full-text query, with synonym alternatives joined by "or"
+	Synonyms    [][]string  `json:"synonyms"`               // Synonym groups that applied
+	RedirectURL *string     `json:"redirect_url,omitempty"` // Where the storefront should send the customer
+	Pinned      []uuid.UUID `json:"pinned"`                 // Shown first, in this order
+	Buried      []uuid.UUID `json:"buried"`                 // Shown last
+}
+
+// MatchSQL is true for products the search finds (see SearchMatchSQL), and for the
+// products pinned to it
+func (p *SearchPlan) MatchSQL() string {
+	if len(p.Pinned) == 0 {
+		return SearchMatchSQL
+	}
+	return fmt.Sprintf("(%s OR p.id IN (%s))", SearchMatchSQL, uuidListSQL(p.Pinned))
+}
+
+// RelevanceOrderSQL orders the products the search finds by relevance (see SearchRankSQL),
+// with the pinned products first, in order, and the buried ones last
+func (p *SearchPlan) RelevanceOrderSQL() string {
+	rank := SearchRankSQL + " DESC"
+	if len(p.Pinned) == 0 && len(p.Buried) == 0 {
+		return rank
+	}
+
+	var order strings.Builder
+	order.WriteString("CASE p.id")
+	for i, id := range p.Pinned {
+		fmt.Fprintf(&order, " WHEN '%s' THEN %d", id, i)
+	}
+	for _, id := range p.Buried {
+		fmt.Fprintf(&order, " WHEN '%s' THEN %d", id, len(p.Pinned)+1)
+	}
+	fmt.Fprintf(&order, " ELSE %d END ASC, %s", len(p.Pinned), rank)
+	return order.String()
+}
+
+// uuidListSQL writes IDs as a list of SQL literals (safe: UUIDs are only hex and dashes)
+func uuidListSQL(ids []uuid.UUID) string {
+	literals := make([]string, len(ids))
+	for i, id := range ids {
+		literals[i] = "'" + id.String() + "'"
+	}
+	return strings.Join(literals, ", ")
+}
+
+// ════════════════════════════════════════════════════════════
+// Request/Response Models
+// ════════════════════════════════════════════════════════════
+
+// SearchSynonymRequest creates or replaces a synonym group
+type SearchSynonymRequest struct {
+	Terms []string `json:"terms" binding:"required,min=2,max=20,dive,required,max=100" example:"sneakers,trainers,running shoes"`
+}
+
+// SearchRedirectRequest creates or updates a search redirect
+type SearchRedirectRequest struct {
+	Query string `json:"query" binding:"required,max=100" example:"sale"`
+	URL   string `json:"url" binding:"required,max=500" example:"/collections/sale"`
+}
+
+// SearchPinRequest pins or buries a product for a search
+type SearchPinRequest struct {
+	Query     string    `json:"query" binding:"required,max=100" example:"summer"`
+	ProductID uuid.UUID `json:"product_id" binding:"required" example:"01936f8e-1234-7000-8000-000000000000"`
+	Action    string    `json:"action" binding:"required,oneof=pin bury" example:"pin"`
+	Position  int       `json:"position" binding:"min=0" example:"0"`
+}
+
+// UpdateSearchPinRequest moves a pin or switches it between pin and bury
+type UpdateSearchPinRequest struct {
+	Action   *string `json:"action" binding:"omitempty,oneof=pin bury"`
+	Position *int    `json:"position" binding:"omitempty,min=0"`
+}
+
+// AdminSearchPin is a pin as listed in the CMS, with its product
+type AdminSearchPin struct {
+	SearchPin
+	ProductName   string `json:"product_name"`
+	ProductStatus string `json:"product_status"`
+	ProductImage  string `json:"product_image"`
+}
+
+// SearchPreviewResult is a product as the storefront search would show it
+type SearchPreviewResult struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Image     string    `json:"image"`
+	Rank      float64   `json:"rank"` // Relevance score (see SearchRankSQL)
+	Pinned    bool      `json:"pinned"`
+	Buried    bool      `json:"buried"`
+	Highlight string    `json:"highlight"` // Name with matched words in <mark>
+}
+
+// SearchPreview shows what the storefront does with a search: the rules that apply and
+// the first results
+type SearchPreview struct {
+	Plan    *SearchPlan           `json:"plan"`
+	Total   int                   `json:"total"` // Active products found
+	Results []SearchPreviewResult `json:"results"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+// SearchSuggestions are what the storefront shows under the search box as the customer types
+type SearchSuggestions struct {
+	Products      []SuggestedProduct  `json:"products"`
+	Categories    []SuggestedCategory `json:"categories"`    // Parent categories
+	Subcategories []SuggestedCategory `json:"subcategories"` // With their parent's name
+	Queries       []string            `json:"queries"`       // Popular searches that start with the text
+}
+
+// SuggestedProduct is an active product whose name matches the text
+type SuggestedProduct struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Thumbnail string    `json:"thumbnail"` // Primary image URL; empty when it has none
+}
+
+// SuggestedCategory is an active category or subcategory whose name matches the text
+type SuggestedCategory struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	ParentName *string    `json:"parent_name,omitempty"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockAlert is raised when an inventory combo drops below its low-stock threshold.
+// There is at most one open or dismissed alert per combo; it is resolved once the
+// combo is back at or above the threshold.
+type StockAlert struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID   uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	SKU         string     `json:"sku" gorm:"not null"`
+	VariantName string     `json:"variant_name" gorm:"not null"`
+	Quantity    int        `json:"quantity" gorm:"not null"`  // Combo quantity when last checked
+	Threshold   int        `json:"threshold" gorm:"not null"` // Effective threshold when last checked
+	Status      string     `json:"status" gorm:"not null;default:open"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	DismissedAt *time.Time `json:"dismissed_at,omitempty"`
+	DismissedBy *uuid.UUID `json:"dismissed_by,omitempty" gorm:"type:uuid"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	EmailedAt   *time.Time `json:"emailed_at,omitempty"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (sa *StockAlert) BeforeCreate(tx *gorm.DB) error {
+	if sa.ID == uuid.Nil {
+		sa.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (StockAlert) TableName() string {
+	return "stock_alerts"
+}
+
+// StockAlertRow is a stock alert with its product name
+type StockAlertRow struct {
+	StockAlert
+	ProductName string `json:"product_name"`
+}
+
+// ════════════════════════════════════════════════════════════
+// Status Constants
+// ════════════════════════════════════════════════════════════
+
+const (
+	StockAlertOpen      = "open"
+	StockAlertDismissed = "dismissed" // Acknowledged; stays quiet until the combo is restocked
+	StockAlertResolved  = "resolved"  // Combo back at or above its threshold
+
+	// DefaultLowStockThreshold applies when neither the product nor its categories set one
+	DefaultLowStockThreshold = 5
+)
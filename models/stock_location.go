@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockLocation is a place stock is held and shipped from (warehouse, store, pop-up)
+type StockLocation struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Code      string    `json:"code" gorm:"not null;uniqueIndex"`
+	Type      string    `json:"type" gorm:"not null;default:warehouse"`
+	Address   string    `json:"address" gorm:"not null;default:''"`
+	Priority  int       `json:"priority" gorm:"not null;default:100"` // Lower = allocated first at checkout
+	IsDefault bool      `json:"is_default" gorm:"not null;default:false"`
+	IsActive  bool      `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (sl *StockLocation) BeforeCreate(tx *gorm.DB) error {
+	if sl.ID == uuid.Nil {
+		sl.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (StockLocation) TableName() string {
+	return "stock_locations"
+}
+
+// LocationStock is the quantity of one inventory combo (by SKU) held at a location.
+// products.inventory[].quantity is the sum across all locations.
+type LocationStock struct {
+	LocationID uuid.UUID `json:"location_id" gorm:"type:uuid;primaryKey"`
+	ProductID  uuid.UUID `json:"product_id" gorm:"type:uuid;primaryKey"`
+	SKU        string    `json:"sku" gorm:"primaryKey"`
+	Quantity   int       `json:"quantity" gorm:"not null;default:0"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name
+func (LocationStock) TableName() string {
+	return "location_stock"
+}
+
+// ════════════════════════════════════════════════════════════
+// Request/Response Models
+// ════════════════════════════════════════════════════════════
+
+// StockLocationRequest creates a stock location
+type StockLocationRequest struct {
+	Name      string `json:"name" binding:"required,max=100" example:"Lagos Warehouse"`
+	Code      string `json:"code" binding:"required,max=30" example:"LOS-01"`
+	Type      string `json:"type" binding:"omitempty,oneof=warehouse store popup" example:"warehouse"`
+	Address   string `json:"address" example:"12 Marina Rd, Lagos"`
+	Priority  *int   `json:"priority" binding:"omitempty,min=0" example:"10"`
+	IsDefault bool   `json:"is_default" example:"false"`
+}
+
+// UpdateStockLocationRequest updates a stock location (only non-nil fields)
+type UpdateStockLocationRequest struct {
+	Name      *string `json:"name" binding:"omitempty,max=100"`
+	Code      *string `json:"code" binding:"omitempty,max=30"`
+	Type      *string `json:"type" binding:"omitempty,oneof=warehouse store popup"`
+	Address   *string `json:"address"`
+	Priority  *int    `json:"priority" binding:"omitempty,min=0"`
+	IsDefault *bool   `json:"is_default"`
+	IsActive  *bool   `json:"is_active"`
+}
+
+// StockLocationResponse is a location with its stock on hand
+type StockLocationResponse struct {
+	StockLocation
+	TotalUnits int `json:"total_units"`
+	SKUCount   int `json:"sku_count"`
+}
+
+// StockTransferRequest moves stock of one combo between locations
+type StockTransferRequest struct {
+	ProductID      uuid.UUID `json:"product_id" binding:"required"`
+	SKU            string    `json:"sku" binding:"required" example:"linen-shirt-small-black"`
+	FromLocationID uuid.UUID `json:"from_location_id" binding:"required"`
+	ToLocationID   uuid.UUID `json:"to_location_id" binding:"required"`
+	Quantity       int       `json:"quantity" binding:"required,min=1" example:"10"`
+	Note           *string   `json:"note,omitempty" example:"Restocking pop-up store"`
+}
+
+// LocationStockRow is a location_stock row with product and location names
+type LocationStockRow struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	LocationCode string    `json:"location_code"`
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	SKU          string    `json:"sku"`
+	Quantity     int       `json:"quantity"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
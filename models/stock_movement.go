@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockMovement is an append-only ledger entry for a single inventory combo.
+// products.inventory holds the running quantity; every change to it is recorded here.
+type StockMovement struct {
+	ID                    uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID             uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	SKU                   *string    `json:"sku,omitempty"`
+	VariantName           string     `json:"variant_name" gorm:"not null"`
+	Combo                 TagsList   `json:"combo" gorm:"type:jsonb"`
+	Delta                 int        `json:"delta" gorm:"not null"`          // Positive = stock in, negative = stock out
+	QuantityAfter         int        `json:"quantity_after" gorm:"not null"` // Combo quantity once this movement was applied
+	Reason                string     `json:"reason" gorm:"not null;index"`   // StockReasonSale, StockReasonDamage, ...
+	LocationID            *uuid.UUID `json:"location_id,omitempty" gorm:"type:uuid;index"`
+	LocationQuantityAfter *int       `json:"location_quantity_after,omitempty"` // Combo quantity at the location after this movement
+	ReferenceType         *string    `json:"reference_type,omitempty"`          // order, return, ...
+	ReferenceID           *string    `json:"reference_id,omitempty"`
+	Note                  *string    `json:"note,omitempty"`
+	AdminID               *uuid.UUID `json:"admin_id,omitempty" gorm:"type:uuid"`
+	AdminEmail            *string    `json:"admin_email,omitempty"`
+	CreatedAt             time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate hook - auto-generate UUID v7
+func (sm *StockMovement) BeforeCreate(tx *gorm.DB) error {
+	if sm.ID == uuid.Nil {
+		sm.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
+
+// ════════════════════════════════════════════════════════════
+// Request Models
+// ════════════════════════════════════════════════════════════
+
+// StockAdjustmentRequest is a manual stock change made from the CMS.
+// The combo is identified by SKU, or by variant name for combos without one.
+// Without a location, stock is added to the default location and removed by allocation priority.
+type StockAdjustmentRequest struct {
+	SKU           string     `json:"sku" example:"linen-shirt-small-black"`
+	VariantName   string     `json:"variant_name" example:"Small / Black"`
+	LocationID    *uuid.UUID `json:"location_id,omitempty"`
+	Delta         int        `json:"delta" binding:"required,ne=0" example:"-2"`
+	Reason        string     `json:"reason" binding:"required,oneof=damage adjustment correction restock return" example:"damage"`
+	ReferenceType *string    `json:"reference_type,omitempty" example:"return"`
+	ReferenceID   *string    `json:"reference_id,omitempty" example:"RET-2025-000012"`
+	Note          *string    `json:"note,omitempty" example:"Water damage in storage"`
+}
+
+// ════════════════════════════════════════════════════════════
+// Reason & Reference Constants
+// ════════════════════════════════════════════════════════════
+
+const (
+	StockReasonInitial      = "initial"      // Opening stock when a product/combo is created
+	StockReasonSale         = "sale"         // Checkout
+	StockReasonCancellation = "cancellation" // Order cancelled, stock returned
+	StockReasonReturn       = "return"       // Customer return
+	StockReasonDamage       = "damage"
+	StockReasonAdjustment   = "adjustment" // Stock count / shrinkage
+	StockReasonCorrection   = "correction" // Admin edit of the inventory JSONB
+	StockReasonRestock      = "restock"    // Supplier delivery
+	StockReasonTransfer     = "transfer"   // Moved between locations (total unchanged)
+
+	StockReferenceOrder = "order"
+)
@@ -12,26 +12,67 @@ import (
 
 // StorefrontProduct represents a product in the storefront (customer-facing)
 type StorefrontProduct struct {
-	ID            string          `json:"id"`
-	Name          string          `json:"name"`
-	Description   string          `json:"description"`
-	Price         float64         `json:"price"`
-	Inventory     json.RawMessage `json:"inventory,omitempty"`       // Hidden if not set
-	Status        string          `json:"status,omitempty"`          // Hidden if not set
-	SubCategoryID string          `json:"sub_category_id,omitempty"` // Hidden if not set
-	CategoryName  string          `json:"category_name,omitempty"`   // Hidden if not set
-	Media         json.RawMessage `json:"media,omitempty"`           // Hidden if not set
-	Variants      json.RawMessage `json:"variants,omitempty"`        // Hidden if not set
-	Views         int             `json:"views,omitempty"`           // Hidden if 0
-	CreatedAt     time.Time       `json:"created_at,omitempty"`      // Hidden if not set
-	UpdatedAt     time.Time       `json:"updated_at,omitempty"`      // Hidden if not set
+	ID              string                   `json:"id"`
+	Name            string                   `json:"name"`
+	Description     string                   `json:"description"`
+	Price           float64                  `json:"price"`                // What the cheapest combo costs right now
+	OriginalPrice   float64                  `json:"original_price"`       // Price the sale is measured against
+	SalePrice       *float64                 `json:"sale_price,omitempty"` // Set while on sale (same as price)
+	DiscountPercent int                      `json:"discount_percent"`
+	OnSale          bool                     `json:"on_sale"`
+	SaleEndsAt      *time.Time               `json:"sale_ends_at,omitempty"`     // When the product sale ends, if it has an end
+	LowestPrice30d  *float64                 `json:"lowest_price_30d,omitempty"` // Lowest price in the 30 days before the sale (EU disclosure)
+	QuantityTiers   []StorefrontQuantityTier `json:"quantity_tiers,omitempty"`   // Volume discounts the customer gets
+	ProductType     string                   `json:"product_type"`               // standard or bundle
+	Bundle          *BundleDetails           `json:"bundle,omitempty"`           // Bundles only: components and availability
+	QuantityRules   *QuantityRules           `json:"quantity_rules,omitempty"`   // Purchase limits, if any
+	RemainingLimit  *int                     `json:"remaining_limit,omitempty"`  // Logged in, with a per-customer limit
+	RatingAverage   float64                  `json:"rating_average"`             // Average of approved reviews (0 without any)
+	RatingCount     int                      `json:"rating_count"`               // Approved reviews
+	Questions       []StorefrontQuestion     `json:"questions"`                  // Most recently answered first
+	QuestionCount   int                      `json:"question_count"`             // Answered questions in all
+	Inventory       json.RawMessage          `json:"inventory,omitempty"`        // Hidden if not set
+	Status          string                   `json:"status,omitempty"`           // Hidden if not set
+	SubCategoryID   string                   `json:"sub_category_id,omitempty"`  // Hidden if not set
+	CategoryName    string                   `json:"category_name,omitempty"`    // Hidden if not set
+	Media           json.RawMessage          `json:"media,omitempty"`            // Hidden if not set
+	Variants        json.RawMessage          `json:"variants,omitempty"`         // Hidden if not set
+	Views           int                      `json:"views,omitempty"`            // Hidden if 0
+	CreatedAt       time.Time                `json:"created_at,omitempty"`       // Hidden if not set
+	UpdatedAt       time.Time                `json:"updated_at,omitempty"`       // Hidden if not set
+}
+
+// StorefrontQuantityTier is a volume discount on a product page
+type StorefrontQuantityTier struct {
+	MinQuantity     int     `json:"min_quantity"`
+	DiscountPercent float64 `json:"discount_percent"`
+	UnitPrice       float64 `json:"unit_price"` // From price with the discount applied
 }
 
 type StorefrontProductResponse struct {
-	ID    string  `json:"id"`
-	Name  string  `json:"name"`
-	Image string  `json:"image"`
-	Price float64 `json:"price"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Image           string   `json:"image"`
+	Price           float64  `json:"price"` // Lowest price any combo sells for right now
+	OriginalPrice   float64  `json:"original_price"`
+	SalePrice       *float64 `json:"sale_price,omitempty"` // Set while on sale (same as price)
+	DiscountPercent int      `json:"discount_percent"`
+	OnSale          bool     `json:"on_sale"`
+	RatingAverage   float64  `json:"rating_average"` // Average of approved reviews (0 without any)
+	RatingCount     int      `json:"rating_count"`
+
+	Highlight *SearchHighlight `json:"highlight,omitempty" gorm:"-"` // Searches only: where the product matched
+}
+
+// ApplySale fills in the sale fields from Price and OriginalPrice
+func (p *StorefrontProductResponse) ApplySale() {
+	p.OnSale = p.Price < p.OriginalPrice
+	p.SalePrice = nil
+	if p.OnSale {
+		price := p.Price
+		p.SalePrice = &price
+	}
+	p.DiscountPercent = DiscountPercent(p.OriginalPrice, p.Price)
 }
 
 // StorefrontCategory represents a category in the storefront
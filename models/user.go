@@ -22,6 +22,7 @@ type User struct {
 	BanReason       *string    `json:"banReason,omitempty" gorm:"column:ban_reason;type:text"`
 	SuspendedUntil  *time.Time `json:"suspendedUntil,omitempty" gorm:"column:suspended_until"`
 	SuspendedReason *string    `json:"suspendedReason,omitempty" gorm:"column:suspended_reason;type:text"`
+	CustomerGroupID *uuid.UUID `json:"customerGroupId,omitempty" gorm:"column:customer_group_id;type:uuid"` // CMS customer group (wholesale pricing)
 
 	// Relationships
 	Addresses      []Address           `json:"addresses,omitempty" gorm:"foreignKey:UserID"`
@@ -54,6 +54,7 @@ func SetupAdminRoutes(rg *gin.RouterGroup) {
 		// Orders
 		protected.POST("/orders/:id/send-invoice", order_controller.SendOrderInvoicePDF)
 		protected.GET("/orders/:id/download-invoice", order_controller.DownloadOrderInvoicePDF)
+		protected.GET("/orders/:id/packing-slip", order_controller.DownloadOrderPackingSlipPDF)
 
 	}
 
@@ -14,4 +14,9 @@ func SetupAnalyticsRoutes(rg *gin.RouterGroup) {
 	analytics.GET("/sales-metrics", analytics_controller.GetSalesMetrics)
 	analytics.GET("/geographic-data", analytics_controller.GetGeographicData)
 	analytics.GET("/device-analytics", analytics_controller.GetDeviceAnalytics)
+
+	// Storefront search
+	analytics.GET("/search/top-queries", analytics_controller.GetTopSearchQueries)
+	analytics.GET("/search/zero-results", analytics_controller.GetZeroResultSearchQueries)
+	analytics.GET("/search/low-click-through", analytics_controller.GetLowClickThroughSearchQueries)
 }
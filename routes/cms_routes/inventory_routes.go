@@ -0,0 +1,38 @@
+package cms_routes
+
+import (
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/controllers/cms/inventory_controller"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupInventoryRoutes(rg *gin.RouterGroup) {
+	inventory := rg.Group("/inventory")
+
+	// ════════════════════════════════════════════════════════════
+	// Public Routes (No Auth Required)
+	// ════════════════════════════════════════════════════════════
+	inventory.GET("/locations", inventory_controller.GetStockLocations)
+	inventory.GET("/stock", inventory_controller.GetLocationStock)
+	inventory.GET("/alerts", inventory_controller.GetStockAlerts)
+	inventory.GET("/backorders", inventory_controller.GetBackorderReport)
+
+	// ════════════════════════════════════════════════════════════
+	// Protected Routes (Auth + Activity Logging)
+	// ════════════════════════════════════════════════════════════
+	protected := inventory.Group("")
+	protected.Use(middleware.AdminAuthMiddleware())
+	protected.Use(middleware.ActivityLoggingMiddleware())
+	{
+		// Locations
+		protected.POST("/locations", inventory_controller.CreateStockLocation)
+		protected.PATCH("/locations/:id", inventory_controller.UpdateStockLocation)
+		protected.DELETE("/locations/:id", inventory_controller.DeleteStockLocation)
+
+		// Transfers
+		protected.POST("/transfers", inventory_controller.TransferStock)
+
+		// Low-stock alerts
+		protected.PATCH("/alerts/:id/dismiss", inventory_controller.DismissStockAlert)
+	}
+}
@@ -0,0 +1,42 @@
+package cms_routes
+
+import (
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/controllers/cms/pricing_controller"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPricingRoutes(rg *gin.RouterGroup) {
+	groups := rg.Group("/customer-groups")
+	tiers := rg.Group("/quantity-tiers")
+
+	// ════════════════════════════════════════════════════════════
+	// Public Routes (No Auth Required)
+	// ════════════════════════════════════════════════════════════
+	groups.GET("", pricing_controller.GetCustomerGroups)
+	groups.GET("/:id/prices", pricing_controller.GetCustomerGroupPrices)
+	tiers.GET("", pricing_controller.GetQuantityTiers)
+
+	// ════════════════════════════════════════════════════════════
+	// Protected Routes (Auth + Activity Logging)
+	// ════════════════════════════════════════════════════════════
+	protectedGroups := groups.Group("")
+	protectedGroups.Use(middleware.AdminAuthMiddleware())
+	protectedGroups.Use(middleware.ActivityLoggingMiddleware())
+	{
+		protectedGroups.POST("", pricing_controller.CreateCustomerGroup)
+		protectedGroups.PATCH("/:id", pricing_controller.UpdateCustomerGroup)
+		protectedGroups.DELETE("/:id", pricing_controller.DeleteCustomerGroup)
+
+		// Price list
+		protectedGroups.PUT("/:id/prices", pricing_controller.SetCustomerGroupPrices)
+	}
+
+	protectedTiers := tiers.Group("")
+	protectedTiers.Use(middleware.AdminAuthMiddleware())
+	protectedTiers.Use(middleware.ActivityLoggingMiddleware())
+	{
+		protectedTiers.POST("", pricing_controller.CreateQuantityTier)
+		protectedTiers.DELETE("/:id", pricing_controller.DeleteQuantityTier)
+	}
+}
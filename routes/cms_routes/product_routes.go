@@ -15,7 +15,15 @@ func SetupProductRoutes(rg *gin.RouterGroup) {
 	product.GET("", product_controller.GetProducts)
 	product.GET("/:id", product_controller.GetProductByID)
 	product.GET("/stats", product_controller.GetProductStats)
+	product.GET("/trash", product_controller.GetTrashedProducts)
 	product.GET("/search", product_controller.SearchProducts)
+	product.GET("/sku/:sku", product_controller.GetProductBySKU)
+	product.GET("/:id/stock-movements", product_controller.GetProductStockMovements)
+	product.GET("/:id/back-in-stock", product_controller.GetBackInStockStats)
+	product.GET("/:id/revisions", product_controller.GetProductRevisions)
+	product.GET("/:id/revisions/:revision", product_controller.GetProductRevision)
+	product.GET("/:id/price-history", product_controller.GetProductPriceHistory)
+	product.GET("/:id/related", product_controller.GetRelatedProducts)
 
 	// ════════════════════════════════════════════════════════════
 	// Protected Routes (Auth + Activity Logging)
@@ -30,8 +38,22 @@ func SetupProductRoutes(rg *gin.RouterGroup) {
 		// Update
 		protected.PATCH("/:id", product_controller.UpdateProduct)
 
-		// Delete
+		// Revisions
+		protected.POST("/:id/revisions/:revision/restore", product_controller.RestoreProductRevision)
+
+		// Related products
+		protected.PUT("/:id/related", product_controller.SetRelatedProducts)
+
+		// Stock
+		protected.POST("/:id/stock-adjustments", product_controller.AdjustProductStock)
+
+		// Bulk CSV import/export
+		protected.POST("/import", product_controller.ImportProducts)
+		protected.GET("/export", product_controller.ExportProducts)
+
+		// Delete (to the trash) and restore from the trash
 		protected.DELETE("/:id", product_controller.DeleteProduct)
+		protected.POST("/:id/restore", product_controller.RestoreTrashedProduct)
 
 		// Utility (cleanup - still needs auth + logging)
 		protected.POST("/cleanup-folder", product_controller.CleanupOrphanedFolder)
@@ -0,0 +1,41 @@
+package cms_routes
+
+import (
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/controllers/cms/question_controller"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupQuestionRoutes(rg *gin.RouterGroup) {
+	questions := rg.Group("/questions")
+	answers := rg.Group("/answers")
+
+	// ════════════════════════════════════════════════════════════
+	// Public Routes (No Auth Required)
+	// ════════════════════════════════════════════════════════════
+	questions.GET("", question_controller.GetQuestions)
+	questions.GET("/stats", question_controller.GetQuestionCounts)
+
+	// ════════════════════════════════════════════════════════════
+	// Protected Routes (Auth + Activity Logging)
+	// ════════════════════════════════════════════════════════════
+	protectedQuestions := questions.Group("")
+	protectedQuestions.Use(middleware.AdminAuthMiddleware())
+	protectedQuestions.Use(middleware.ActivityLoggingMiddleware())
+	{
+		// Moderation
+		protectedQuestions.POST("/:id/approve", question_controller.ApproveQuestion)
+		protectedQuestions.POST("/:id/reject", question_controller.RejectQuestion)
+
+		// The store's answer
+		protectedQuestions.POST("/:id/answer", question_controller.AnswerQuestion)
+	}
+
+	protectedAnswers := answers.Group("")
+	protectedAnswers.Use(middleware.AdminAuthMiddleware())
+	protectedAnswers.Use(middleware.ActivityLoggingMiddleware())
+	{
+		protectedAnswers.POST("/:id/approve", question_controller.ApproveAnswer)
+		protectedAnswers.POST("/:id/reject", question_controller.RejectAnswer)
+	}
+}
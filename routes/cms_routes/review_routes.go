@@ -0,0 +1,29 @@
+package cms_routes
+
+import (
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/controllers/cms/review_controller"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReviewRoutes(rg *gin.RouterGroup) {
+	reviews := rg.Group("/reviews")
+
+	// ════════════════════════════════════════════════════════════
+	// Public Routes (No Auth Required)
+	// ════════════════════════════════════════════════════════════
+	reviews.GET("", review_controller.GetReviews)
+
+	// ════════════════════════════════════════════════════════════
+	// Protected Routes (Auth + Activity Logging)
+	// ════════════════════════════════════════════════════════════
+	protected := reviews.Group("")
+	protected.Use(middleware.AdminAuthMiddleware())
+	protected.Use(middleware.ActivityLoggingMiddleware())
+	{
+		// Moderation
+		protected.POST("/:id/approve", review_controller.ApproveReview)
+		protected.POST("/:id/reject", review_controller.RejectReview)
+		protected.POST("/:id/reply", review_controller.ReplyToReview)
+	}
+}
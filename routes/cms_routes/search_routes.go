@@ -0,0 +1,42 @@
+package cms_routes
+
+import (
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/controllers/cms/search_controller"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSearchRoutes(rg *gin.RouterGroup) {
+	search := rg.Group("/search")
+
+	// ════════════════════════════════════════════════════════════
+	// Public Routes (No Auth Required)
+	// ════════════════════════════════════════════════════════════
+	search.GET("/synonyms", search_controller.GetSynonyms)
+	search.GET("/redirects", search_controller.GetRedirects)
+	search.GET("/pins", search_controller.GetPins)
+	search.GET("/preview", search_controller.PreviewSearch)
+
+	// ════════════════════════════════════════════════════════════
+	// Protected Routes (Auth + Activity Logging)
+	// ════════════════════════════════════════════════════════════
+	protected := search.Group("")
+	protected.Use(middleware.AdminAuthMiddleware())
+	protected.Use(middleware.ActivityLoggingMiddleware())
+	{
+		// Synonym groups
+		protected.POST("/synonyms", search_controller.CreateSynonym)
+		protected.PUT("/synonyms/:id", search_controller.UpdateSynonym)
+		protected.DELETE("/synonyms/:id", search_controller.DeleteSynonym)
+
+		// Redirects
+		protected.POST("/redirects", search_controller.CreateRedirect)
+		protected.PUT("/redirects/:id", search_controller.UpdateRedirect)
+		protected.DELETE("/redirects/:id", search_controller.DeleteRedirect)
+
+		// Pinned and buried results
+		protected.POST("/pins", search_controller.CreatePin)
+		protected.PATCH("/pins/:id", search_controller.UpdatePin)
+		protected.DELETE("/pins/:id", search_controller.DeletePin)
+	}
+}
@@ -1,9 +1,12 @@
 package ecommerce_routes
 
 import (
+	"time"
+
 	store_category "github.com/Modeva-Ecommerce/modeva-cms-backend/controllers/ecommerce/category_controller"
 	store_filter "github.com/Modeva-Ecommerce/modeva-cms-backend/controllers/ecommerce/filter_controller"
 	store_product "github.com/Modeva-Ecommerce/modeva-cms-backend/controllers/ecommerce/product_controller"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -14,12 +17,36 @@ func SetupStorefrontRoutes(router *gin.RouterGroup) {
 	// Product routes
 	products := store.Group("/products")
 	{
-		products.GET("", store_product.GetStorefrontProducts) // List with filters
+		// Optional auth: logged-in customers in a group see their group's prices
+		products.GET("", middleware.OptionalAuthMiddleware(), store_product.GetStorefrontProducts) // List with filters
+
+		products.GET("/filters", store_category.GetProductFilters)                                             // Get available filters
+		products.GET("/facets", middleware.OptionalAuthMiddleware(), store_product.GetStorefrontProductFacets) // Filters counted under the active ones
+		products.GET("/:id", middleware.OptionalAuthMiddleware(), store_product.GetStorefrontProductByID)      // Single product
+
+		// Related and frequently-bought-together products
+		products.GET("/:id/recommendations", middleware.OptionalAuthMiddleware(), store_product.GetStorefrontProductRecommendations)
+
+		// Reviews: anyone can read approved ones; customers review what they've bought
+		products.GET("/:id/reviews", store_product.GetProductReviews)
+		products.POST("/:id/reviews", middleware.AuthMiddleware(), store_product.SubmitProductReview)
 
-		products.GET("/filters", store_category.GetProductFilters)   // Get available filters
-		products.GET("/:id", store_product.GetStorefrontProductByID) // Single product
+		// Questions: anyone can read answered ones; customers ask, buyers answer
+		products.GET("/:id/questions", store_product.GetProductQuestions)
+		products.POST("/:id/questions", middleware.AuthMiddleware(), store_product.AskProductQuestion)
+
+		// Back-in-stock notifications (guests or logged-in users; guest emails are confirmed first)
+		products.POST("/:id/notify", middleware.RateLimiter(5, time.Minute), middleware.OptionalAuthMiddleware(), store_product.SubscribeBackInStock)
 	}
 
+	// Search box suggestions (products, categories, popular searches)
+	store.GET("/search/suggest", store_product.GetSearchSuggestions)
+	store.POST("/search/:id/click", store_product.RecordSearchClick) // Search analytics
+
+	store.POST("/notifications/confirm", middleware.RateLimiter(10, time.Minute), store_product.ConfirmBackInStock)
+	store.POST("/notifications/unsubscribe", store_product.UnsubscribeBackInStock)
+	store.POST("/questions/:id/answers", middleware.AuthMiddleware(), store_product.AnswerProductQuestion)
+
 	// Category routes
 	categories := store.Group("/categories")
 	{
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	backInStockBatchSize  = 100         // Resend batch API limit
+	backInStockBatchPause = time.Second // Pause between batches to stay under the Resend rate limit
+)
+
+// BackInStockService emails shoppers subscribed to sold-out combos once they are restocked
+type BackInStockService struct{}
+
+// NewBackInStockService creates a new back-in-stock service
+func NewBackInStockService() *BackInStockService {
+	return &BackInStockService{}
+}
+
+// NotifyRestocked emails every pending subscriber of a combo that is now in stock.
+// Subscriptions can only be created while a combo is sold out, so a pending subscription
+// on an in-stock combo means it went from 0 to positive. Subscribers are claimed in
+// batches (so concurrent runs never email twice) and sent through the Resend batch API.
+// Returns the number of subscribers emailed.
+func (s *BackInStockService) NotifyRestocked(ctx context.Context, productID uuid.UUID) (int, error) {
+	// Step 1: Find in-stock combos of the (published) product
+	var product models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Select("id, name, price, status, media, inventory").
+		First(&product, "id = ?", productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if product.Status != "Active" {
+		return 0, nil // Subscribers stay pending until the product is published
+	}
+
+	inStock := make([]string, 0)
+	variantNames := make(map[string]string)
+	for _, item := range product.Inventory {
+		if item.SKU != "" && item.Quantity > 0 {
+			inStock = append(inStock, item.SKU)
+			variantNames[item.SKU] = item.VariantName
+		}
+	}
+	if len(inStock) == 0 {
+		return 0, nil
+	}
+
+	var pending int64
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Model(&models.BackInStockSubscription{}).
+		Where("product_id = ? AND sku IN ? AND status = ?", productID, inStock, models.BackInStockPending).
+		Count(&pending).Error; err != nil {
+		return 0, err
+	}
+	if pending == 0 {
+		return 0, nil
+	}
+
+	if os.Getenv("RESEND_API_KEY") == "" {
+		return 0, errors.New("RESEND_API_KEY not set")
+	}
+
+	frontendURL := config.GetFrontendURL()
+	productURL := fmt.Sprintf("%s/products/%s", frontendURL, product.ID)
+
+	// Step 2: Claim and email subscribers batch by batch
+	sent := 0
+	for {
+		var batch []models.BackInStockSubscription
+		if err := config.EcommerceGorm.WithContext(ctx).
+			Raw(`
+				UPDATE back_in_stock_subscriptions
+				SET status = ?, notified_at = NOW()
+				WHERE id IN (
+					SELECT id FROM back_in_stock_subscriptions
+					WHERE product_id = ? AND sku IN ? AND status = ?
+					ORDER BY created_at
+					LIMIT ?
+					FOR UPDATE SKIP LOCKED
+				)
+				RETURNING *
+			`, models.BackInStockNotified, productID, inStock, models.BackInStockPending, backInStockBatchSize).
+			Scan(&batch).Error; err != nil {
+			return sent, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		emails := make([]BackInStockEmailData, len(batch))
+		ids := make([]uuid.UUID, len(batch))
+		for i, sub := range batch {
+			variantName := variantNames[sub.SKU]
+			if variantName == "" {
+				variantName = sub.VariantName
+			}
+			emails[i] = BackInStockEmailData{
+				Email:          sub.Email,
+				ProductName:    product.Name,
+				VariantName:    variantName,
+				Price:          product.Price,
+				ImageURL:       product.Media.Primary.URL,
+				ProductURL:     productURL,
+				UnsubscribeURL: fmt.Sprintf("%s/back-in-stock/unsubscribe?token=%s", frontendURL, sub.UnsubscribeToken),
+			}
+			ids[i] = sub.ID
+		}
+
+		if err := NewResendClient().SendBackInStockEmails(emails); err != nil {
+			// Put the batch back so the next restock (or retry) picks it up
+			if revertErr := config.EcommerceGorm.WithContext(ctx).
+				Model(&models.BackInStockSubscription{}).
+				Where("id IN ?", ids).
+				Updates(map[string]interface{}{"status": models.BackInStockPending, "notified_at": nil}).Error; revertErr != nil {
+				log.Printf("[back-in-stock] failed to revert batch for product %s: %v", productID, revertErr)
+			}
+			return sent, err
+		}
+		sent += len(batch)
+
+		if len(batch) < backInStockBatchSize {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return sent, ctx.Err()
+		case <-time.After(backInStockBatchPause):
+		}
+	}
+
+	return sent, nil
+}
+
+// SendConfirmation emails the link confirming an unconfirmed subscription to its address
+// (the link carries the subscription's token)
+func (s *BackInStockService) SendConfirmation(subscription *models.BackInStockSubscription, productName string) error {
+	if os.Getenv("RESEND_API_KEY") == "" {
+		return errors.New("RESEND_API_KEY not set")
+	}
+
+	frontendURL := config.GetFrontendURL()
+	return NewResendClient().SendBackInStockConfirmationEmail(BackInStockConfirmationEmailData{
+		Email:          subscription.Email,
+		ProductName:    productName,
+		VariantName:    subscription.VariantName,
+		ConfirmURL:     fmt.Sprintf("%s/back-in-stock/confirm?token=%s", frontendURL, subscription.UnsubscribeToken),
+		UnsubscribeURL: fmt.Sprintf("%s/back-in-stock/unsubscribe?token=%s", frontendURL, subscription.UnsubscribeToken),
+	})
+}
+
+// NotifyRestockedAsync runs NotifyRestocked for each product in the background
+func (s *BackInStockService) NotifyRestockedAsync(productIDs ...uuid.UUID) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		for _, productID := range productIDs {
+			sent, err := s.NotifyRestocked(ctx, productID)
+			if err != nil {
+				log.Printf("[back-in-stock] notify failed for product %s after %d email(s): %v", productID, sent, err)
+				continue
+			}
+			if sent > 0 {
+				log.Printf("[back-in-stock] notified %d subscriber(s) for product %s", sent, productID)
+			}
+		}
+	}()
+}
+
+// Global instance
+var backInStockService *BackInStockService
+
+// GetBackInStockService returns the global back-in-stock service instance
+func GetBackInStockService() *BackInStockService {
+	if backInStockService == nil {
+		backInStockService = NewBackInStockService()
+	}
+	return backInStockService
+}
+
+// GenerateUnsubscribeToken generates the random token used in unsubscribe links
+// Returns 64 character hex string (32 bytes)
+func GenerateUnsubscribeToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
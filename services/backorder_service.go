@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BackorderService tracks units ordered beyond stock. Those units stay in order_items
+// (backordered_quantity) until the order ships, when they're taken out of stock; until
+// then the stock that arrives is held for them.
+type BackorderService struct{}
+
+// NewBackorderService creates a new backorder service
+func NewBackorderService() *BackorderService {
+	return &BackorderService{}
+}
+
+// Outstanding returns the units on backorder on open orders, by product and lower-cased SKU
+func (s *BackorderService) Outstanding(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]map[string]int, error) {
+	outstanding := make(map[uuid.UUID]map[string]int)
+	if len(productIDs) == 0 {
+		return outstanding, nil
+	}
+
+	var rows []struct {
+		ProductID uuid.UUID
+		SKU       string
+		Quantity  int
+	}
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Raw(`
+			SELECT oi.product_id, LOWER(oi.sku) AS sku, SUM(oi.backordered_quantity) AS quantity
+			FROM order_items oi
+			JOIN orders o ON o.id = oi.order_id
+			WHERE oi.backordered_quantity > 0 AND oi.sku IS NOT NULL
+			  AND o.status <> 'cancelled'
+			  AND oi.product_id IN ?
+			GROUP BY oi.product_id, LOWER(oi.sku)
+		`, productIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if outstanding[row.ProductID] == nil {
+			outstanding[row.ProductID] = make(map[string]int)
+		}
+		outstanding[row.ProductID][row.SKU] = row.Quantity
+	}
+	return outstanding, nil
+}
+
+// ErrOrderCancelled is returned when fulfilling a cancelled order
+var ErrOrderCancelled = errors.New("order is cancelled")
+
+// FulfilOrder takes an order's backordered units out of stock as it ships and clears them
+// from its lines. The order row stays locked throughout, so concurrent fulfilments and
+// cancellations wait for it; cancelled orders are refused (ErrOrderCancelled). Returns an
+// InsufficientStockError while the stock hasn't arrived, and the number of units taken.
+func (s *BackorderService) FulfilOrder(ctx context.Context, orderID uuid.UUID) (int, error) {
+	units := 0
+	err := config.EcommerceGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var status string
+		if err := tx.Raw(`SELECT status FROM orders WHERE id = ? FOR UPDATE`, orderID).
+			Scan(&status).Error; err != nil {
+			return err
+		}
+		if status == "cancelled" {
+			return ErrOrderCancelled
+		}
+
+		var lines []OrderStockLine
+		if err := tx.Raw(`
+			SELECT product_id, sku, backordered_quantity AS quantity
+			FROM order_items
+			WHERE order_id = ? AND backordered_quantity > 0 AND sku IS NOT NULL
+		`, orderID).Scan(&lines).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+
+		// Deducted once per order, so a retry after the lines failed to clear doesn't take
+		// the units again
+		if _, err := GetStockService().DeductBackorderStock(ctx, orderID, lines); err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			UPDATE order_items
+			SET backordered_quantity = 0, updated_at = NOW()
+			WHERE order_id = ? AND backordered_quantity > 0
+		`, orderID).Error; err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			units += line.Quantity
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return units, nil
+}
+
+// Report lists every combo with units on backorder (optionally of one product), the ones
+// stock on hand doesn't cover first
+func (s *BackorderService) Report(ctx context.Context, productID *uuid.UUID) ([]models.BackorderReportRow, error) {
+	// Step 1: Outstanding units per combo (ecommerce DB)
+	query := config.EcommerceGorm.WithContext(ctx).
+		Table("order_items oi").
+		Joins("JOIN orders o ON o.id = oi.order_id").
+		Where("oi.backordered_quantity > 0 AND oi.sku IS NOT NULL AND o.status <> ?", "cancelled")
+	if productID != nil {
+		query = query.Where("oi.product_id = ?", *productID)
+	}
+	var outstanding []struct {
+		ProductID     uuid.UUID
+		SKU           string
+		Backordered   int
+		Orders        int
+		OldestOrderAt time.Time
+	}
+	if err := query.
+		Select(`
+			oi.product_id,
+			MAX(oi.sku) AS sku,
+			SUM(oi.backordered_quantity) AS backordered,
+			COUNT(DISTINCT oi.order_id) AS orders,
+			MIN(o.created_at) AS oldest_order_at
+		`).
+		Group("oi.product_id, LOWER(oi.sku)").
+		Scan(&outstanding).Error; err != nil {
+		return nil, err
+	}
+
+	report := make([]models.BackorderReportRow, 0, len(outstanding))
+	if len(outstanding) == 0 {
+		return report, nil
+	}
+
+	// Step 2: Products, their stock and current policy (CMS DB, trashed ones included)
+	productIDs := make([]uuid.UUID, 0, len(outstanding))
+	for _, row := range outstanding {
+		productIDs = append(productIDs, row.ProductID)
+	}
+	var products []models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Unscoped().
+		Select("id, name, inventory, backorder").
+		Where("id IN ?", productIDs).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	// Step 3: One row per combo
+	for _, row := range outstanding {
+		entry := models.BackorderReportRow{
+			ProductID:     row.ProductID,
+			SKU:           row.SKU,
+			Mode:          models.BackorderNone,
+			Backordered:   row.Backordered,
+			Orders:        row.Orders,
+			OldestOrderAt: row.OldestOrderAt,
+		}
+		if product, ok := byID[row.ProductID]; ok {
+			entry.ProductName = product.Name
+			var combo *models.InventoryField
+			if idx := product.Inventory.IndexOfSKU(row.SKU); idx >= 0 {
+				combo = &product.Inventory[idx]
+				entry.SKU = combo.SKU
+				entry.VariantName = combo.VariantName
+				entry.OnHand = combo.Quantity
+			}
+			policy := product.BackorderFor(combo)
+			if policy.Allowed() {
+				entry.Mode = policy.Mode
+				entry.ExpectedShipDate = policy.ExpectedShipDate
+			}
+		}
+		if entry.Backordered > entry.OnHand {
+			entry.ToReorder = entry.Backordered - entry.OnHand
+		}
+		report = append(report, entry)
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].ToReorder != report[j].ToReorder {
+			return report[i].ToReorder > report[j].ToReorder
+		}
+		if report[i].Backordered != report[j].Backordered {
+			return report[i].Backordered > report[j].Backordered
+		}
+		return strings.ToLower(report[i].ProductName) < strings.ToLower(report[j].ProductName)
+	})
+	return report, nil
+}
+
+// Global instance
+var backorderService *BackorderService
+
+// GetBackorderService returns the global backorder service instance
+func GetBackorderService() *BackorderService {
+	if backorderService == nil {
+		backorderService = NewBackorderService()
+	}
+	return backorderService
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBundle is returned when a bundle's components can't be saved as-is
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// BundleService checks bundle components and looks up the products they point at
+type BundleService struct{}
+
+// NewBundleService creates a new bundle service
+func NewBundleService() *BundleService {
+	return &BundleService{}
+}
+
+// Validate checks that every component is an existing standard product's combo, other
+// than the bundle itself, and listed once. SKUs are normalised to the combo's own casing
+// in place.
+func (s *BundleService) Validate(ctx context.Context, bundleID uuid.UUID, components []models.BundleComponent) error {
+	if len(components) == 0 {
+		return fmt.Errorf("%w: a bundle needs at least one component", ErrInvalidBundle)
+	}
+
+	products, err := s.loadProducts(ctx, components)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(components))
+	for i := range components {
+		component := &components[i]
+		component.SKU = strings.TrimSpace(component.SKU)
+
+		if component.Quantity < 1 {
+			return fmt.Errorf("%w: component quantities must be at least 1", ErrInvalidBundle)
+		}
+		if component.ProductID == bundleID {
+			return fmt.Errorf("%w: a bundle can't contain itself", ErrInvalidBundle)
+		}
+		product, ok := products[component.ProductID]
+		if !ok {
+			return fmt.Errorf("%w: component product %s not found", ErrInvalidBundle, component.ProductID)
+		}
+		if product.IsBundle() {
+			return fmt.Errorf("%w: %q is a bundle; bundles can't contain other bundles", ErrInvalidBundle, product.Name)
+		}
+		idx := product.Inventory.IndexOfSKU(component.SKU)
+		if idx < 0 {
+			return fmt.Errorf("%w: %q has no combo with SKU %q", ErrInvalidBundle, product.Name, component.SKU)
+		}
+		component.SKU = product.Inventory[idx].SKU
+
+		key := strings.ToLower(component.SKU)
+		if seen[key] {
+			return fmt.Errorf("%w: SKU %q is listed more than once; raise its quantity instead", ErrInvalidBundle, component.SKU)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Details resolves a bundle's components to their products and combos, with the stock on
+// hand of each. Components whose product is gone, inactive or no longer has the combo
+// have nothing on hand, so the bundle is unavailable until it's fixed.
+func (s *BundleService) Details(ctx context.Context, components []models.BundleComponent) (*models.BundleDetails, error) {
+	products, err := s.loadProducts(ctx, components)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]models.BundleComponentDetail, 0, len(components))
+	for _, component := range components {
+		detail := models.BundleComponentDetail{
+			ProductID: component.ProductID,
+			SKU:       component.SKU,
+			Quantity:  component.Quantity,
+		}
+		if product, ok := products[component.ProductID]; ok {
+			detail.ProductName = product.Name
+			detail.Image = product.Media.Primary.URL
+			if idx := product.Inventory.IndexOfSKU(component.SKU); idx >= 0 {
+				detail.VariantName = product.Inventory[idx].VariantName
+				if product.Status == models.ProductStatusActive {
+					detail.OnHand = product.Inventory[idx].Quantity
+				}
+			}
+		}
+		details = append(details, detail)
+	}
+	return models.NewBundleDetails(details), nil
+}
+
+// loadProducts fetches the (non-deleted) products the components point at, by ID
+func (s *BundleService) loadProducts(ctx context.Context, components []models.BundleComponent) (map[uuid.UUID]models.Product, error) {
+	productIDs := make([]uuid.UUID, 0, len(components))
+	for _, component := range components {
+		productIDs = append(productIDs, component.ProductID)
+	}
+
+	byID := make(map[uuid.UUID]models.Product, len(productIDs))
+	if len(productIDs) == 0 {
+		return byID, nil
+	}
+
+	var products []models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Select("id, name, status, product_type, media, inventory").
+		Where("id IN ?", productIDs).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+	return byID, nil
+}
+
+// Global instance
+var bundleService *BundleService
+
+// GetBundleService returns the global bundle service instance
+func GetBundleService() *BundleService {
+	if bundleService == nil {
+		bundleService = NewBundleService()
+	}
+	return bundleService
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+)
+
+// CustomerPricingService looks up the prices a customer's group gets: the group's price
+// list and the quantity tiers that apply to it
+type CustomerPricingService struct{}
+
+// NewCustomerPricingService creates a new customer pricing service
+func NewCustomerPricingService() *CustomerPricingService {
+	return &CustomerPricingService{}
+}
+
+// GroupOf returns the customer group of a user (nil when they aren't in one)
+func (s *CustomerPricingService) GroupOf(ctx context.Context, userID uuid.UUID) (*uuid.UUID, error) {
+	var groupIDs []uuid.UUID
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Table("users").
+		Where("id = ? AND customer_group_id IS NOT NULL", userID).
+		Pluck("customer_group_id", &groupIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	return &groupIDs[0], nil
+}
+
+// ListPrices returns the group's prices for the given products, by product (nil group: none)
+func (s *CustomerPricingService) ListPrices(ctx context.Context, groupID *uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	prices := make(map[uuid.UUID]float64)
+	if groupID == nil || len(productIDs) == 0 {
+		return prices, nil
+	}
+
+	var rows []models.CustomerGroupPrice
+	if err := config.CmsGorm.WithContext(ctx).
+		Where("customer_group_id = ? AND product_id IN ?", *groupID, productIDs).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		prices[row.ProductID] = row.Price
+	}
+	return prices, nil
+}
+
+// Tiers returns the quantity tiers that apply to the group's members (nil group: tiers
+// for every customer) on the given products, lowest threshold first
+func (s *CustomerPricingService) Tiers(ctx context.Context, groupID *uuid.UUID, productIDs []uuid.UUID) ([]models.QuantityTier, error) {
+	query := config.CmsGorm.WithContext(ctx).
+		Where("(product_id IS NULL OR product_id IN ?)", productIDs)
+	if groupID == nil {
+		query = query.Where("customer_group_id IS NULL")
+	} else {
+		query = query.Where("(customer_group_id IS NULL OR customer_group_id = ?)", *groupID)
+	}
+
+	tiers := make([]models.QuantityTier, 0)
+	if err := query.Order("min_quantity ASC, discount_percent DESC").Find(&tiers).Error; err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// Global instance
+var customerPricingService *CustomerPricingService
+
+// GetCustomerPricingService returns the global customer pricing service instance
+func GetCustomerPricingService() *CustomerPricingService {
+	if customerPricingService == nil {
+		customerPricingService = NewCustomerPricingService()
+	}
+	return customerPricingService
+}
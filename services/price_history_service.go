@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PriceReferencePeriod is how far before a sale the lowest price is looked up
+const PriceReferencePeriod = 30 * 24 * time.Hour
+
+// PriceHistoryService records product pricing in price_history and answers questions
+// about past prices
+type PriceHistoryService struct{}
+
+// NewPriceHistoryService creates a new price history service
+func NewPriceHistoryService() *PriceHistoryService {
+	return &PriceHistoryService{}
+}
+
+// Record writes a row for the product's pricing and for each combo sale that changed
+// since they were last recorded. Must be called inside the CMS transaction that saved
+// the product, after the save (like ProductRevisionService.Record).
+func (s *PriceHistoryService) Record(tx *gorm.DB, productID uuid.UUID, meta ProductRevisionMeta) error {
+	var product models.Product
+	if err := tx.
+		Select("id, price, compare_at_price, sale_price, sale_starts_at, sale_ends_at, inventory").
+		First(&product, "id = ?", productID).Error; err != nil {
+		return err
+	}
+
+	// Latest row of the product and of each combo
+	var latestRows []models.PriceHistory
+	if err := tx.Raw(`
+		SELECT DISTINCT ON (COALESCE(sku, '')) *
+		FROM price_history
+		WHERE product_id = ?
+		ORDER BY COALESCE(sku, ''), created_at DESC, id DESC
+	`, productID).Scan(&latestRows).Error; err != nil {
+		return err
+	}
+	latest := make(map[string]*models.PriceHistory, len(latestRows))
+	for i := range latestRows {
+		key := ""
+		if latestRows[i].SKU != nil {
+			key = *latestRows[i].SKU
+		}
+		latest[key] = &latestRows[i]
+	}
+
+	rows := make([]models.PriceHistory, 0)
+	newRow := func(sku *string) models.PriceHistory {
+		return models.PriceHistory{
+			ProductID:  productID,
+			SKU:        sku,
+			Price:      product.Price,
+			Source:     meta.Source,
+			AdminID:    meta.AdminID,
+			AdminEmail: meta.AdminEmail,
+		}
+	}
+
+	// The product's own pricing
+	row := newRow(nil)
+	row.CompareAtPrice = product.CompareAtPrice
+	row.SalePrice, row.SaleStartsAt, row.SaleEndsAt = product.SalePrice, product.SaleStartsAt, product.SaleEndsAt
+	if previous, ok := latest[""]; !ok || !previous.SamePricing(&row) {
+		rows = append(rows, row)
+	}
+
+	// Combo sales; a combo is only recorded once it has had a sale
+	seen := make(map[string]bool, len(product.Inventory))
+	for _, item := range product.Inventory {
+		if item.SKU == "" {
+			continue
+		}
+		seen[item.SKU] = true
+		sku := item.SKU
+		row := newRow(&sku)
+		row.SalePrice, row.SaleStartsAt, row.SaleEndsAt = item.SalePrice, item.SaleStartsAt, item.SaleEndsAt
+
+		previous, ok := latest[sku]
+		if !ok {
+			if row.SalePrice != nil {
+				rows = append(rows, row)
+			}
+			continue
+		}
+		row.Price = previous.Price // A product price change alone is recorded on the product row
+		if !previous.SamePricing(&row) {
+			row.Price = product.Price
+			rows = append(rows, row)
+		}
+	}
+
+	// A removed combo's sale ends with it
+	for key, previous := range latest {
+		if key == "" || seen[key] || previous.SalePrice == nil {
+			continue
+		}
+		sku := key
+		rows = append(rows, newRow(&sku))
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+// LowestPriceBeforeSale returns the lowest price the product (any combo) sold for in the
+// PriceReferencePeriod before its current sale began, or nil when nothing is on sale at t
+// or there's no history from before the sale.
+func (s *PriceHistoryService) LowestPriceBeforeSale(ctx context.Context, productID uuid.UUID, t time.Time) (*float64, error) {
+	var rows []models.PriceHistory
+	if err := config.CmsGorm.WithContext(ctx).
+		Where("product_id = ? AND created_at <= ?", productID, t).
+		Order("created_at ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	timeline := newPriceTimeline(rows)
+
+	// Only while a sale (not just a compare-at price) applies
+	current, ok := timeline.at(t)
+	if !ok || current.FromPriceAt(t) >= current.Price {
+		return nil, nil
+	}
+
+	// Walk back to where the sale began: the price is constant between breakpoints
+	points := timeline.breakpoints(t)
+	saleStart := t
+	for i := len(points) - 1; i >= 0; i-- {
+		state, ok := timeline.at(points[i])
+		if !ok || state.FromPriceAt(points[i]) >= state.Price {
+			break
+		}
+		saleStart = points[i]
+	}
+
+	// Lowest price over the reference period before it
+	from := saleStart.Add(-PriceReferencePeriod)
+	var lowest *float64
+	check := func(at time.Time) {
+		state, ok := timeline.at(at)
+		if !ok {
+			return
+		}
+		if price := state.FromPriceAt(at); lowest == nil || price < *lowest {
+			lowest = &price
+		}
+	}
+	check(from)
+	for _, point := range points {
+		if point.After(from) && point.Before(saleStart) {
+			check(point)
+		}
+	}
+	return lowest, nil
+}
+
+// priceTimeline rebuilds a product's pricing at past moments from its price_history rows
+type priceTimeline struct {
+	rows []models.PriceHistory // Oldest first
+}
+
+func newPriceTimeline(rows []models.PriceHistory) *priceTimeline {
+	return &priceTimeline{rows: rows}
+}
+
+// at returns the product's pricing (with combo sales as inventory) as recorded at t;
+// false when nothing had been recorded yet
+func (pt *priceTimeline) at(t time.Time) (*models.Product, bool) {
+	var product *models.Product
+	combos := make(map[string]models.InventoryField)
+	for _, row := range pt.rows {
+		if row.CreatedAt.After(t) {
+			break
+		}
+		if row.SKU == nil {
+			product = &models.Product{
+				Price:          row.Price,
+				CompareAtPrice: row.CompareAtPrice,
+				SalePrice:      row.SalePrice,
+				SaleStartsAt:   row.SaleStartsAt,
+				SaleEndsAt:     row.SaleEndsAt,
+			}
+			continue
+		}
+		combos[*row.SKU] = models.InventoryField{
+			SKU:          *row.SKU,
+			SalePrice:    row.SalePrice,
+			SaleStartsAt: row.SaleStartsAt,
+			SaleEndsAt:   row.SaleEndsAt,
+		}
+	}
+	if product == nil {
+		return nil, false
+	}
+	for _, combo := range combos {
+		product.Inventory = append(product.Inventory, combo)
+	}
+	return product, true
+}
+
+// breakpoints lists the moments up to t where the price can change: when rows were
+// written and when recorded sales start or end. Sorted, oldest first.
+func (pt *priceTimeline) breakpoints(t time.Time) []time.Time {
+	points := make([]time.Time, 0, len(pt.rows)*3)
+	add := func(at *time.Time) {
+		if at != nil && !at.After(t) {
+			points = append(points, *at)
+		}
+	}
+	for i := range pt.rows {
+		add(&pt.rows[i].CreatedAt)
+		add(pt.rows[i].SaleStartsAt)
+		add(pt.rows[i].SaleEndsAt)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Before(points[j]) })
+	return points
+}
+
+// Global instance
+var priceHistoryService *PriceHistoryService
+
+// GetPriceHistoryService returns the global price history service instance
+func GetPriceHistoryService() *PriceHistoryService {
+	if priceHistoryService == nil {
+		priceHistoryService = NewPriceHistoryService()
+	}
+	return priceHistoryService
+}
@@ -0,0 +1,368 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProductRevisionMeta describes who saved a product and how
+type ProductRevisionMeta struct {
+	Source       string // models.RevisionSource*
+	RestoredFrom *int
+	AdminID      *uuid.UUID
+	AdminEmail   *string
+}
+
+// listKeys names the field that identifies entries of the JSONB lists, so entries are
+// matched by identity rather than position (a combo keeps its key when its SKU changes)
+var listKeys = map[string]func(map[string]interface{}) string{
+	"variants":          func(m map[string]interface{}) string { return fmt.Sprint(m["type"]) },
+	"composition":       func(m map[string]interface{}) string { return fmt.Sprint(m["label"]) },
+	"media.other":       func(m map[string]interface{}) string { return fmt.Sprint(m["url"]) },
+	"bundle_components": func(m map[string]interface{}) string { return fmt.Sprint(m["sku"]) },
+	"inventory": func(m map[string]interface{}) string {
+		if combo, ok := m["combo"].([]interface{}); ok && len(combo) > 0 {
+			parts := make([]string, len(combo))
+			for i, value := range combo {
+				parts[i] = fmt.Sprint(value)
+			}
+			return strings.Join(parts, " / ")
+		}
+		return fmt.Sprint(m["variant_name"])
+	},
+}
+
+// ProductRevisionService snapshots products into product_revisions and diffs snapshots
+type ProductRevisionService struct{}
+
+// NewProductRevisionService creates a new product revision service
+func NewProductRevisionService() *ProductRevisionService {
+	return &ProductRevisionService{}
+}
+
+// Record snapshots the product as saved in this transaction. Must be called inside the
+// CMS transaction that saved it, after the save. Returns nil (and records nothing) when
+// the product is identical to its latest revision, unless this is a restore.
+func (s *ProductRevisionService) Record(tx *gorm.DB, productID uuid.UUID, meta ProductRevisionMeta) (*models.ProductRevision, error) {
+	// The row lock serializes revision numbers per product
+	var product models.Product
+	if err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&product, "id = ?", productID).Error; err != nil {
+		return nil, err
+	}
+	snapshot := models.SnapshotOf(&product)
+
+	var latest models.ProductRevision
+	hasLatest := true
+	if err := tx.
+		Where("product_id = ?", productID).
+		Order("revision DESC").
+		First(&latest).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		hasLatest = false
+	}
+
+	changedFields := make(models.TagsList, 0)
+	if hasLatest {
+		changes, err := s.Diff(&latest.Snapshot, &snapshot)
+		if err != nil {
+			return nil, err
+		}
+		changedFields = ChangedFields(changes)
+		if len(changedFields) == 0 && meta.Source != models.RevisionSourceRestore {
+			return nil, nil
+		}
+	}
+
+	revision := models.ProductRevision{
+		ProductID:     productID,
+		Revision:      latest.Revision + 1,
+		Snapshot:      snapshot,
+		ChangedFields: changedFields,
+		Source:        meta.Source,
+		RestoredFrom:  meta.RestoredFrom,
+		AdminID:       meta.AdminID,
+		AdminEmail:    meta.AdminEmail,
+	}
+	if err := tx.Create(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// Diff lists the field-by-field differences between two snapshots, in
+// RestorableProductFields order. A nil before
+// reports every field of after as added.
+func (s *ProductRevisionService) Diff(before, after *models.ProductSnapshot) ([]models.ProductFieldChange, error) {
+	beforeMap := map[string]interface{}{}
+	if before != nil {
+		var err error
+		if beforeMap, err = toJSONMap(before); err != nil {
+			return nil, err
+		}
+	}
+	afterMap, err := toJSONMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]models.ProductFieldChange, 0)
+	for _, field := range models.RestorableProductFields {
+		beforeValue, inBefore := beforeMap[field]
+		afterValue, inAfter := afterMap[field]
+		switch {
+		case !inBefore && !inAfter:
+		case !inBefore:
+			changes = append(changes, models.ProductFieldChange{Field: field, Change: models.FieldChangeAdded, After: afterValue})
+		case !inAfter:
+			changes = append(changes, models.ProductFieldChange{Field: field, Change: models.FieldChangeRemoved, Before: beforeValue})
+		default:
+			diffValues(field, field, beforeValue, afterValue, &changes)
+		}
+	}
+	return changes, nil
+}
+
+// ChangedFields reduces changes to the distinct top-level fields they touch
+func ChangedFields(changes []models.ProductFieldChange) models.TagsList {
+	fields := make(models.TagsList, 0)
+	seen := make(map[string]bool)
+	for _, change := range changes {
+		field := change.Field
+		if i := strings.IndexAny(field, ".["); i >= 0 {
+			field = field[:i]
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// diffValues compares two decoded JSON values. path is the reported field path;
+// schema is the same path without list keys, used to look up listKeys.
+func diffValues(path, schema string, before, after interface{}, changes *[]models.ProductFieldChange) {
+	if reflect.DeepEqual(before, after) || (isEmptyJSON(before) && isEmptyJSON(after)) {
+		return
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := make([]string, 0, len(beforeMap)+len(afterMap))
+		for key := range beforeMap {
+			keys = append(keys, key)
+		}
+		for key := range afterMap {
+			if _, exists := beforeMap[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			beforeValue, inBefore := beforeMap[key]
+			afterValue, inAfter := afterMap[key]
+			childPath, childSchema := path+"."+key, schema+"."+key
+			switch {
+			case !inBefore:
+				*changes = append(*changes, models.ProductFieldChange{Field: childPath, Change: models.FieldChangeAdded, After: afterValue})
+			case !inAfter:
+				*changes = append(*changes, models.ProductFieldChange{Field: childPath, Change: models.FieldChangeRemoved, Before: beforeValue})
+			default:
+				diffValues(childPath, childSchema, beforeValue, afterValue, changes)
+			}
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList {
+		if keyOf, ok := listKeys[schema]; ok {
+			if diffKeyedLists(path, schema, beforeList, afterList, keyOf, changes) {
+				return
+			}
+		} else if diffStringSets(path, beforeList, afterList, changes) {
+			return
+		}
+	}
+
+	*changes = append(*changes, models.ProductFieldChange{Field: path, Change: models.FieldChangeChanged, Before: before, After: after})
+}
+
+// diffKeyedLists matches list entries by key. Returns false when the entries can't be
+// keyed (not objects, or duplicate keys) so the caller falls back to a whole-value change.
+func diffKeyedLists(
+	path, schema string,
+	before, after []interface{},
+	keyOf func(map[string]interface{}) string,
+	changes *[]models.ProductFieldChange,
+) bool {
+	beforeKeys, beforeByKey, ok := keyList(before, keyOf)
+	if !ok {
+		return false
+	}
+	afterKeys, afterByKey, ok := keyList(after, keyOf)
+	if !ok {
+		return false
+	}
+
+	for _, key := range beforeKeys {
+		if _, exists := afterByKey[key]; !exists {
+			*changes = append(*changes, models.ProductFieldChange{
+				Field: fmt.Sprintf("%s[%s]", path, key), Change: models.FieldChangeRemoved, Before: beforeByKey[key],
+			})
+		}
+	}
+	for _, key := range afterKeys {
+		entryPath := fmt.Sprintf("%s[%s]", path, key)
+		if beforeEntry, exists := beforeByKey[key]; exists {
+			diffValues(entryPath, schema+"[]", beforeEntry, afterByKey[key], changes)
+		} else {
+			*changes = append(*changes, models.ProductFieldChange{
+				Field: entryPath, Change: models.FieldChangeAdded, After: afterByKey[key],
+			})
+		}
+	}
+
+	// Same entries in a different order (e.g. images shuffled)
+	common := func(keys []string, other map[string]interface{}) []string {
+		out := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if _, exists := other[key]; exists {
+				out = append(out, key)
+			}
+		}
+		return out
+	}
+	beforeOrder, afterOrder := common(beforeKeys, afterByKey), common(afterKeys, beforeByKey)
+	if !reflect.DeepEqual(beforeOrder, afterOrder) {
+		*changes = append(*changes, models.ProductFieldChange{
+			Field: path, Change: models.FieldChangeReordered, Before: beforeOrder, After: afterOrder,
+		})
+	}
+	return true
+}
+
+// keyList indexes list entries by key, keeping their order
+func keyList(list []interface{}, keyOf func(map[string]interface{}) string) ([]string, map[string]interface{}, bool) {
+	keys := make([]string, 0, len(list))
+	byKey := make(map[string]interface{}, len(list))
+	for _, entry := range list {
+		m, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, nil, false
+		}
+		key := keyOf(m)
+		if _, exists := byKey[key]; exists {
+			return nil, nil, false
+		}
+		keys = append(keys, key)
+		byKey[key] = entry
+	}
+	return keys, byKey, true
+}
+
+// diffStringSets reports lists of strings (tags, variant options) as added/removed values.
+// Returns false for lists that aren't all strings, or that only differ in duplicates.
+func diffStringSets(path string, before, after []interface{}, changes *[]models.ProductFieldChange) bool {
+	beforeSet, ok := stringSet(before)
+	if !ok {
+		return false
+	}
+	afterSet, ok := stringSet(after)
+	if !ok {
+		return false
+	}
+
+	added, removed := make([]string, 0), make([]string, 0)
+	for _, value := range after {
+		if !beforeSet[value.(string)] {
+			added = append(added, value.(string))
+		}
+	}
+	for _, value := range before {
+		if !afterSet[value.(string)] {
+			removed = append(removed, value.(string))
+		}
+	}
+
+	if len(added) > 0 {
+		*changes = append(*changes, models.ProductFieldChange{Field: path, Change: models.FieldChangeAdded, After: added})
+	}
+	if len(removed) > 0 {
+		*changes = append(*changes, models.ProductFieldChange{Field: path, Change: models.FieldChangeRemoved, Before: removed})
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		if len(before) != len(after) {
+			return false
+		}
+		*changes = append(*changes, models.ProductFieldChange{Field: path, Change: models.FieldChangeReordered, Before: before, After: after})
+	}
+	return true
+}
+
+// stringSet returns the list as a set, or false if any entry isn't a string
+func stringSet(list []interface{}) (map[string]bool, bool) {
+	set := make(map[string]bool, len(list))
+	for _, value := range list {
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		set[s] = true
+	}
+	return set, true
+}
+
+// isEmptyJSON reports whether a decoded JSON value is null, an empty list or an empty
+// object, which snapshots use interchangeably (a list saved as null reads back as [])
+func isEmptyJSON(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+// toJSONMap decodes a value's JSON form into a generic map
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Global instance
+var productRevisionService *ProductRevisionService
+
+// GetProductRevisionService returns the global product revision service instance
+func GetProductRevisionService() *ProductRevisionService {
+	if productRevisionService == nil {
+		productRevisionService = NewProductRevisionService()
+	}
+	return productRevisionService
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+)
+
+func TestProductRevisionDiff(t *testing.T) {
+	base := func() *models.ProductSnapshot {
+		return &models.ProductSnapshot{
+			Name:   "Linen Shirt",
+			Price:  49.99,
+			Status: models.ProductStatusActive,
+			Tags:   models.TagsList{"linen", "summer"},
+			Variants: models.VariantsList{
+				{Type: "Size", Options: []string{"S", "M"}},
+				{Type: "Color", Options: []string{"Black"}},
+			},
+			Inventory: models.InventoryList{
+				{Combo: []string{"S", "Black"}, VariantName: "S-Black", Quantity: 3, SKU: "ls-s-black"},
+				{Combo: []string{"M", "Black"}, VariantName: "M-Black", Quantity: 5, SKU: "ls-m-black"},
+			},
+			SEO: models.Seo{SEOTitle: "Linen Shirt", SEODescription: "A linen shirt"},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		edit   func(s *models.ProductSnapshot)
+		fields []string // Field and change of each expected change, in order
+	}{
+		{
+			name: "no changes",
+			edit: func(s *models.ProductSnapshot) {},
+		},
+		{
+			name:   "scalar field",
+			edit:   func(s *models.ProductSnapshot) { s.Price = 39.99 },
+			fields: []string{"price changed"},
+		},
+		{
+			name:   "nested object field",
+			edit:   func(s *models.ProductSnapshot) { s.SEO.SEOTitle = "Shirt" },
+			fields: []string{"seo.seo_title changed"},
+		},
+		{
+			name:   "tags added and removed",
+			edit:   func(s *models.ProductSnapshot) { s.Tags = models.TagsList{"linen", "sale"} },
+			fields: []string{"tags added", "tags removed"},
+		},
+		{
+			name:   "tags reordered",
+			edit:   func(s *models.ProductSnapshot) { s.Tags = models.TagsList{"summer", "linen"} },
+			fields: []string{"tags reordered"},
+		},
+		{
+			name:   "duplicate tag dropped",
+			edit:   func(s *models.ProductSnapshot) { s.Tags = models.TagsList{"linen", "summer", "linen"} },
+			fields: []string{"tags changed"},
+		},
+		{
+			name:   "tags cleared",
+			edit:   func(s *models.ProductSnapshot) { s.Tags = models.TagsList{} },
+			fields: []string{"tags removed"},
+		},
+		{
+			name:   "combo keyed by its options, not its position or SKU",
+			edit:   func(s *models.ProductSnapshot) { s.Inventory[1].SKU = "ls-m-blk" },
+			fields: []string{"inventory[M / Black].sku changed"},
+		},
+		{
+			name: "combo added and removed",
+			edit: func(s *models.ProductSnapshot) {
+				s.Inventory[0] = models.InventoryField{Combo: []string{"L", "Black"}, VariantName: "L-Black"}
+			},
+			fields: []string{"inventory[S / Black] removed", "inventory[L / Black] added"},
+		},
+		{
+			name: "combos reordered",
+			edit: func(s *models.ProductSnapshot) {
+				s.Inventory[0], s.Inventory[1] = s.Inventory[1], s.Inventory[0]
+			},
+			fields: []string{"inventory reordered"},
+		},
+		{
+			name:   "variant option added",
+			edit:   func(s *models.ProductSnapshot) { s.Variants[0].Options = append(s.Variants[0].Options, "L") },
+			fields: []string{"variants[Size].options added"},
+		},
+		{
+			name: "duplicate combo keys fall back to a whole-field change",
+			edit: func(s *models.ProductSnapshot) {
+				s.Inventory[1].Combo = []string{"S", "Black"}
+			},
+			fields: []string{"inventory changed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := base(), base()
+			tt.edit(after)
+
+			changes, err := GetProductRevisionService().Diff(before, after)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			got := make([]string, 0, len(changes))
+			for _, change := range changes {
+				got = append(got, change.Field+" "+change.Change)
+			}
+			if len(got) != len(tt.fields) || (len(got) > 0 && !reflect.DeepEqual(got, tt.fields)) {
+				t.Errorf("Diff() = %q, want %q", got, tt.fields)
+			}
+		})
+	}
+}
+
+func TestProductRevisionDiffNullLists(t *testing.T) {
+	before := &models.ProductSnapshot{Name: "Tote"}
+	after := &models.ProductSnapshot{Name: "Tote", Tags: models.TagsList{}, Inventory: models.InventoryList{}}
+
+	changes, err := GetProductRevisionService().Diff(before, after)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(changes) != 0 {
+		raw, _ := json.Marshal(changes)
+		t.Errorf("Diff() = %s, want no changes between null and empty lists", raw)
+	}
+}
+
+func TestProductRevisionDiffFromNothing(t *testing.T) {
+	after := &models.ProductSnapshot{Name: "Tote", Price: 20}
+
+	changes, err := GetProductRevisionService().Diff(nil, after)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	// Every field of the snapshot, bar bundle_components (bundles only)
+	want := make([]string, 0, len(models.RestorableProductFields))
+	for _, field := range models.RestorableProductFields {
+		if field != "bundle_components" {
+			want = append(want, field)
+		}
+	}
+	got := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.Change != models.FieldChangeAdded {
+			t.Errorf("%s %s, want added", change.Field, change.Change)
+		}
+		got = append(got, change.Field)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() fields = %q, want %q", got, want)
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	changes := []models.ProductFieldChange{
+		{Field: "price"},
+		{Field: "seo.seo_title"},
+		{Field: "inventory[S / Black].sku"},
+		{Field: "seo.seo_description"},
+		{Field: "inventory"},
+	}
+	want := models.TagsList{"price", "seo", "inventory"}
+	if got := ChangedFields(changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangedFields() = %q, want %q", got, want)
+	}
+}
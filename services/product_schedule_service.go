@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	category_cache "github.com/Modeva-Ecommerce/modeva-cms-backend/cache"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// productScheduleBatch caps how many due products one tick handles; the rest wait a tick
+const productScheduleBatch = 200
+
+// ProductScheduleService flips products to Active at publish_at and back to Draft at
+// unpublish_at, on behalf of the system actor
+type ProductScheduleService struct{}
+
+// NewProductScheduleService creates a new product schedule service
+func NewProductScheduleService() *ProductScheduleService {
+	return &ProductScheduleService{}
+}
+
+// scheduledChange is one product the scheduler changed
+type scheduledChange struct {
+	product   models.Product // As it was before the change
+	status    string         // Status after the change
+	published bool
+}
+
+// RunDue applies every publish_at/unpublish_at that has passed and returns how many
+// products changed status. Each product is handled in its own transaction, so one
+// failure doesn't hold back the others.
+func (s *ProductScheduleService) RunDue(ctx context.Context) (int, error) {
+	var ids []uuid.UUID
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("publish_at <= NOW() OR unpublish_at <= NOW()").
+		Order("LEAST(COALESCE(publish_at, unpublish_at), COALESCE(unpublish_at, publish_at))").
+		Limit(productScheduleBatch).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	changed := make([]uuid.UUID, 0)
+	published := make([]uuid.UUID, 0)
+	for _, id := range ids {
+		change, err := s.apply(ctx, id)
+		if err != nil {
+			log.Printf("[products.schedule] failed to apply schedule for %s: %v", id, err)
+			continue
+		}
+		if change == nil {
+			continue
+		}
+		changed = append(changed, id)
+		if change.published {
+			published = append(published, id)
+		}
+		s.logChange(change)
+	}
+
+	if len(changed) > 0 {
+		category_cache.Invalidate() // Product counts
+		// Published products suggested, unpublished ones no longer
+		GetSearchSuggestService().IndexProductsAsync(changed...)
+	}
+	if len(published) > 0 {
+		GetBackInStockService().NotifyRestockedAsync(published...)
+	}
+	return len(changed), nil
+}
+
+// apply runs one product's due schedule. Returns nil when only a schedule time was
+// cleared (e.g. a publish_at on a product already made Active by hand).
+func (s *ProductScheduleService) apply(ctx context.Context, productID uuid.UUID) (*scheduledChange, error) {
+	var change *scheduledChange
+	err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED: another instance (or an admin save) already has it
+		var product models.Product
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			First(&product, "id = ?", productID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now()
+		status := product.Status
+		updates := make(map[string]interface{})
+		if product.PublishAt != nil && !product.PublishAt.After(now) {
+			status = models.ProductStatusActive
+			updates["publish_at"] = nil
+		}
+		// Also when both have passed (e.g. the server was down for the whole window)
+		if product.UnpublishAt != nil && !product.UnpublishAt.After(now) {
+			status = models.ProductStatusDraft
+			updates["unpublish_at"] = nil
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		if status != product.Status {
+			updates["status"] = status
+		}
+
+		if err := tx.Model(&models.Product{}).Where("id = ?", productID).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		actorID, actorEmail := models.SystemActorID, models.SystemActorEmail
+		if _, err := GetProductRevisionService().Record(tx, productID, ProductRevisionMeta{
+			Source:     models.RevisionSourceSchedule,
+			AdminID:    &actorID,
+			AdminEmail: &actorEmail,
+		}); err != nil {
+			return err
+		}
+
+		if status != product.Status {
+			change = &scheduledChange{
+				product:   product,
+				status:    status,
+				published: status == models.ProductStatusActive,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// logChange records the status flip in the activity log as the system actor
+func (s *ProductScheduleService) logChange(change *scheduledChange) {
+	action := models.ActionUnpublishProduct
+	if change.published {
+		action = models.ActionPublishProduct
+	}
+
+	before := map[string]interface{}{
+		"status":       change.product.Status,
+		"publish_at":   change.product.PublishAt,
+		"unpublish_at": change.product.UnpublishAt,
+	}
+	after := map[string]interface{}{"status": change.status}
+
+	LogActivity(LogActivityRequest{
+		AdminID:      models.SystemActorID,
+		AdminEmail:   models.SystemActorEmail,
+		Action:       action,
+		ResourceType: models.ResourceTypeProduct,
+		ResourceID:   change.product.ID.String(),
+		ResourceName: change.product.Name,
+		Changes:      CreateChanges(before, after),
+	})
+}
+
+// StartScheduler applies due publish_at/unpublish_at times in the background.
+//
+// PRODUCT_SCHEDULE_INTERVAL: how often to check, as a Go duration (default 1m)
+func (s *ProductScheduleService) StartScheduler() {
+	interval := time.Minute
+	if raw := os.Getenv("PRODUCT_SCHEDULE_INTERVAL"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			log.Printf("[products.schedule] invalid PRODUCT_SCHEDULE_INTERVAL %q, using %s", raw, interval)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.runScheduledCheck()
+			<-ticker.C
+		}
+	}()
+}
+
+// runScheduledCheck is a single scheduler tick
+func (s *ProductScheduleService) runScheduledCheck() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	changed, err := s.RunDue(ctx)
+	if err != nil {
+		log.Printf("[products.schedule] scheduled check failed: %v", err)
+		return
+	}
+	if changed > 0 {
+		log.Printf("[products.schedule] %d product(s) published or unpublished", changed)
+	}
+}
+
+// Global instance
+var productScheduleService *ProductScheduleService
+
+// GetProductScheduleService returns the global product schedule service instance
+func GetProductScheduleService() *ProductScheduleService {
+	if productScheduleService == nil {
+		productScheduleService = NewProductScheduleService()
+	}
+	return productScheduleService
+}
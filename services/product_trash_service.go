@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+)
+
+// productTrashPurgeBatch caps how many products one purge run deletes; the rest wait a run
+const productTrashPurgeBatch = 200
+
+// ProductTrashService permanently deletes products that have been in the trash for longer
+// than the retention period, together with their Cloudinary folder
+type ProductTrashService struct {
+	retention  time.Duration
+	cloudinary *CloudinaryService
+}
+
+// NewProductTrashService creates a new product trash service.
+//
+// PRODUCT_TRASH_RETENTION: how long trashed products are kept, as a Go duration (default 720h, 30 days)
+func NewProductTrashService() *ProductTrashService {
+	retention := 30 * 24 * time.Hour
+	if raw := os.Getenv("PRODUCT_TRASH_RETENTION"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			retention = parsed
+		} else {
+			log.Printf("[products.trash] invalid PRODUCT_TRASH_RETENTION %q, using %s", raw, retention)
+		}
+	}
+	return &ProductTrashService{retention: retention}
+}
+
+// Retention returns how long trashed products are kept before being purged
+func (s *ProductTrashService) Retention() time.Duration {
+	return s.retention
+}
+
+// UseCloudinary sets the service purged products' images are deleted from
+func (s *ProductTrashService) UseCloudinary(cloudinary *CloudinaryService) {
+	s.cloudinary = cloudinary
+}
+
+// PurgeExpired permanently deletes products trashed more than the retention period ago
+// and returns how many were deleted. Their SKUs, revisions, stock history and alerts go
+// with them (ON DELETE CASCADE); order items keep the product name they were placed with.
+func (s *ProductTrashService) PurgeExpired(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-s.retention)
+
+	var products []models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Unscoped().
+		Select("id, name, deleted_at").
+		Where("deleted_at IS NOT NULL AND deleted_at <= ?", cutoff).
+		Order("deleted_at ASC").
+		Limit(productTrashPurgeBatch).
+		Find(&products).Error; err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, product := range products {
+		// Re-checked in the delete: the product may have been restored since
+		result := config.CmsGorm.WithContext(ctx).
+			Unscoped().
+			Where("id = ? AND deleted_at IS NOT NULL AND deleted_at <= ?", product.ID, cutoff).
+			Delete(&models.Product{})
+		if result.Error != nil {
+			log.Printf("[products.trash] failed to purge product %s: %v", product.ID, result.Error)
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		purged++
+
+		s.deleteMedia(ctx, product.ID)
+
+		LogActivity(LogActivityRequest{
+			AdminID:      models.SystemActorID,
+			AdminEmail:   models.SystemActorEmail,
+			Action:       models.ActionPurgeProduct,
+			ResourceType: models.ResourceTypeProduct,
+			ResourceID:   product.ID.String(),
+			ResourceName: product.Name,
+			Changes: CreateChanges(
+				map[string]interface{}{"deleted_at": product.DeletedAt.Time},
+				nil,
+			),
+		})
+	}
+	return purged, nil
+}
+
+// deleteMedia removes a purged product's Cloudinary folder
+func (s *ProductTrashService) deleteMedia(ctx context.Context, productID uuid.UUID) {
+	if s.cloudinary == nil {
+		return
+	}
+
+	folderPath := fmt.Sprintf("modeva/products/%s", productID.String())
+	if err := s.cloudinary.DeleteFolder(ctx, folderPath); err != nil {
+		log.Printf("[products.trash] failed to delete Cloudinary folder %s: %v", folderPath, err)
+	}
+}
+
+// StartScheduler purges expired trashed products in the background.
+//
+// PRODUCT_TRASH_PURGE_INTERVAL: how often to run, as a Go duration (default 1h)
+func (s *ProductTrashService) StartScheduler() {
+	interval := time.Hour
+	if raw := os.Getenv("PRODUCT_TRASH_PURGE_INTERVAL"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			log.Printf("[products.trash] invalid PRODUCT_TRASH_PURGE_INTERVAL %q, using %s", raw, interval)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.runScheduledPurge()
+			<-ticker.C
+		}
+	}()
+}
+
+// runScheduledPurge is a single scheduler tick
+func (s *ProductTrashService) runScheduledPurge() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	purged, err := s.PurgeExpired(ctx)
+	if err != nil {
+		log.Printf("[products.trash] scheduled purge failed: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("[products.trash] purged %d product(s) from the trash", purged)
+	}
+}
+
+// Global instance
+var productTrashService *ProductTrashService
+
+// GetProductTrashService returns the global product trash service instance
+func GetProductTrashService() *ProductTrashService {
+	if productTrashService == nil {
+		productTrashService = NewProductTrashService()
+	}
+	return productTrashService
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuantityRuleError is returned when an order breaks a product's quantity rules
+type QuantityRuleError struct {
+	ProductName string
+	Reason      string
+}
+
+func (e *QuantityRuleError) Error() string {
+	return fmt.Sprintf("%s: %s", e.ProductName, e.Reason)
+}
+
+// QuantityRuleService enforces products' purchase quantity rules, including the limits
+// per customer that depend on what they've ordered before
+type QuantityRuleService struct{}
+
+// NewQuantityRuleService creates a new quantity rule service
+func NewQuantityRuleService() *QuantityRuleService {
+	return &QuantityRuleService{}
+}
+
+// CheckOrder checks every product of an order against its rules. quantities are the units
+// of each product ordered on its own, bundled the units in the order's bundles; db is the
+// ecommerce database (the checkout transaction).
+func (s *QuantityRuleService) CheckOrder(
+	ctx context.Context,
+	db *gorm.DB,
+	userID uuid.UUID,
+	quantities map[uuid.UUID]int,
+	bundled map[uuid.UUID]int,
+) error {
+	productIDs := make([]uuid.UUID, 0, len(quantities)+len(bundled))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	for productID := range bundled {
+		if _, ok := quantities[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	var products []models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Select("id, name, quantity_rules").
+		Where("id IN ?", productIDs).
+		Order("id").
+		Find(&products).Error; err != nil {
+		return err
+	}
+	for _, product := range products {
+		if product.QuantityRules.IsZero() {
+			continue
+		}
+		if err := s.Check(db, userID, product.ID, product.Name, product.QuantityRules, quantities[product.ID], bundled[product.ID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check checks an order of quantity units of a product, plus bundled units in bundles,
+// against its rules. db is the ecommerce database (the checkout transaction); with a
+// per-customer limit the customer's row is locked so two checkouts at once can't both fit
+// under it.
+func (s *QuantityRuleService) Check(
+	db *gorm.DB,
+	userID uuid.UUID,
+	productID uuid.UUID,
+	productName string,
+	rules models.QuantityRules,
+	quantity int,
+	bundled int,
+) error {
+	if reason := rules.CheckOrder(quantity, bundled); reason != "" {
+		return &QuantityRuleError{ProductName: productName, Reason: reason}
+	}
+	if rules.MaxPerCustomer == 0 {
+		return nil
+	}
+
+	if err := db.Exec("SELECT 1 FROM users WHERE id = ? FOR UPDATE", userID).Error; err != nil {
+		return err
+	}
+	purchased, err := s.purchased(db, userID, productID, rules.WindowDays)
+	if err != nil {
+		return err
+	}
+	if purchased+quantity+bundled > rules.MaxPerCustomer {
+		return &QuantityRuleError{ProductName: productName, Reason: limitReason(rules, purchased)}
+	}
+	return nil
+}
+
+// Remaining returns how many more units the customer can order under the product's
+// per-customer limit (nil when it has none)
+func (s *QuantityRuleService) Remaining(ctx context.Context, userID, productID uuid.UUID, rules models.QuantityRules) (*int, error) {
+	if rules.MaxPerCustomer == 0 {
+		return nil, nil
+	}
+	purchased, err := s.purchased(config.EcommerceGorm.WithContext(ctx), userID, productID, rules.WindowDays)
+	if err != nil {
+		return nil, err
+	}
+	remaining := rules.MaxPerCustomer - purchased
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &remaining, nil
+}
+
+// purchased counts the units of a product the customer has ordered, on its own or as a
+// bundle component, leaving out cancelled orders, within the last windowDays days (0: ever)
+func (s *QuantityRuleService) purchased(db *gorm.DB, userID, productID uuid.UUID, windowDays int) (int, error) {
+	asComponent := fmt.Sprintf(`[{"product_id": %q}]`, productID.String())
+	query := db.Table("order_items oi").
+		Joins("JOIN orders o ON o.id = oi.order_id").
+		Where("o.user_id = ? AND o.status <> ?", userID, "cancelled").
+		Where("(oi.product_id = ? OR oi.bundle_components @> ?::jsonb)", productID, asComponent)
+	if windowDays > 0 {
+		query = query.Where("o.created_at >= ?", time.Now().AddDate(0, 0, -windowDays))
+	}
+
+	var purchased int
+	if err := query.Select(`COALESCE(SUM(
+		CASE WHEN oi.product_id = ? THEN oi.quantity ELSE 0 END
+		+ oi.quantity * COALESCE((
+			SELECT SUM((component->>'quantity')::int)
+			FROM jsonb_array_elements(oi.bundle_components) AS component
+			WHERE component->>'product_id' = ?
+		), 0)
+	), 0)`, productID, productID.String()).Scan(&purchased).Error; err != nil {
+		return 0, err
+	}
+	return purchased, nil
+}
+
+// limitReason explains a per-customer limit that an order would go over
+func limitReason(rules models.QuantityRules, purchased int) string {
+	limit := fmt.Sprintf("limit of %d per customer", rules.MaxPerCustomer)
+	if rules.WindowDays > 0 {
+		limit += fmt.Sprintf(" every %d days", rules.WindowDays)
+	}
+	if purchased == 0 {
+		return limit
+	}
+	return fmt.Sprintf("%s (%d already ordered)", limit, purchased)
+}
+
+// Global instance
+var quantityRuleService *QuantityRuleService
+
+// GetQuantityRuleService returns the global quantity rule service instance
+func GetQuantityRuleService() *QuantityRuleService {
+	if quantityRuleService == nil {
+		quantityRuleService = NewQuantityRuleService()
+	}
+	return quantityRuleService
+}
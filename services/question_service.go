@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+)
+
+// QuestionService loads product questions with their answers and emails askers when their
+// question is answered. Questions and answers live in the ecommerce DB.
+type QuestionService struct{}
+
+// NewQuestionService creates a new question service
+func NewQuestionService() *QuestionService {
+	return &QuestionService{}
+}
+
+// answerRow is an answer with the name of the customer who gave it (empty for the store)
+type answerRow struct {
+	models.ProductAnswer
+	CustomerName string
+}
+
+// Answered returns a page of a product's approved, answered questions (most recently
+// answered first) with their published answers, and how many there are in all
+func (s *QuestionService) Answered(ctx context.Context, productID uuid.UUID, limit, offset int) ([]models.StorefrontQuestion, int, error) {
+	query := config.EcommerceGorm.WithContext(ctx).
+		Table("product_questions q").
+		Where("q.product_id = ? AND q.status = ? AND q.answered_at IS NOT NULL", productID, models.QuestionApproved)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		models.ProductQuestion
+		CustomerName string
+	}
+	if err := query.
+		Select("q.*, u.name AS customer_name").
+		Joins("JOIN users u ON u.id = q.user_id").
+		Order("q.answered_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	questionIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		questionIDs = append(questionIDs, row.ID)
+	}
+	answers, err := s.loadAnswers(ctx, questionIDs, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	questions := make([]models.StorefrontQuestion, 0, len(rows))
+	for _, row := range rows {
+		question := models.StorefrontQuestion{
+			ID:        row.ID,
+			Question:  row.Question,
+			AskerName: models.ReviewerDisplayName(row.CustomerName),
+			CreatedAt: row.CreatedAt,
+			Answers:   make([]models.StorefrontAnswer, 0),
+		}
+		if row.AnsweredAt != nil {
+			question.AnsweredAt = *row.AnsweredAt
+		}
+		for _, answer := range answers[row.ID] {
+			storefrontAnswer := models.StorefrontAnswer{
+				ID:        answer.ID,
+				Answer:    answer.Answer,
+				FromStore: answer.AdminID != nil,
+				CreatedAt: answer.CreatedAt,
+			}
+			if storefrontAnswer.FromStore {
+				storefrontAnswer.AnswererName = models.StoreAnswererName
+			} else {
+				storefrontAnswer.AnswererName = models.ReviewerDisplayName(answer.CustomerName)
+				storefrontAnswer.VerifiedBuyer = true // Only buyers can answer
+			}
+			question.Answers = append(question.Answers, storefrontAnswer)
+		}
+		questions = append(questions, question)
+	}
+	return questions, int(total), nil
+}
+
+// AdminAnswers returns every answer to the questions, in any status, by question
+func (s *QuestionService) AdminAnswers(ctx context.Context, questionIDs []uuid.UUID) (map[uuid.UUID][]models.AdminAnswer, error) {
+	rows, err := s.loadAnswers(ctx, questionIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	answers := make(map[uuid.UUID][]models.AdminAnswer, len(rows))
+	for questionID, questionAnswers := range rows {
+		for _, answer := range questionAnswers {
+			name := models.StoreAnswererName
+			if answer.AdminID == nil {
+				name = answer.CustomerName
+			}
+			answers[questionID] = append(answers[questionID], models.AdminAnswer{
+				ProductAnswer: answer.ProductAnswer,
+				AnswererName:  name,
+			})
+		}
+	}
+	return answers, nil
+}
+
+// loadAnswers fetches the answers to the questions, oldest first, by question
+func (s *QuestionService) loadAnswers(ctx context.Context, questionIDs []uuid.UUID, approvedOnly bool) (map[uuid.UUID][]answerRow, error) {
+	byQuestion := make(map[uuid.UUID][]answerRow, len(questionIDs))
+	if len(questionIDs) == 0 {
+		return byQuestion, nil
+	}
+
+	query := config.EcommerceGorm.WithContext(ctx).
+		Table("product_answers a").
+		Select("a.*, COALESCE(u.name, '') AS customer_name").
+		Joins("LEFT JOIN users u ON u.id = a.user_id").
+		Where("a.question_id IN ?", questionIDs)
+	if approvedOnly {
+		query = query.Where("a.status = ?", models.QuestionApproved)
+	}
+
+	var rows []answerRow
+	if err := query.Order("a.created_at ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		byQuestion[row.QuestionID] = append(byQuestion[row.QuestionID], row)
+	}
+	return byQuestion, nil
+}
+
+// SyncAnswered sets a question's answered_at when it first has a published answer (and
+// clears it when it no longer has one), then emails the asker if they haven't been yet.
+// Call it whenever an answer is published or rejected.
+func (s *QuestionService) SyncAnswered(ctx context.Context, questionID uuid.UUID) error {
+	if err := config.EcommerceGorm.WithContext(ctx).Exec(`
+		UPDATE product_questions
+		SET answered_at = CASE
+				WHEN EXISTS (
+					SELECT 1 FROM product_answers
+					WHERE question_id = product_questions.id AND status = ?
+				) THEN COALESCE(answered_at, NOW())
+				ELSE NULL
+			END,
+			updated_at = NOW()
+		WHERE id = ?
+	`, models.QuestionApproved, questionID).Error; err != nil {
+		return err
+	}
+	s.NotifyAskerAsync(questionID)
+	return nil
+}
+
+// NotifyAskerAsync emails the asker of an approved, answered question in the background,
+// once. Nothing is sent until both are true.
+func (s *QuestionService) NotifyAskerAsync(questionID uuid.UUID) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		sent, err := s.notifyAsker(ctx, questionID)
+		if err != nil {
+			log.Printf("[questions] failed to email the asker of question %s: %v", questionID, err)
+			return
+		}
+		if sent {
+			log.Printf("[questions] emailed the asker of question %s", questionID)
+		}
+	}()
+}
+
+// notifyAsker claims the question's notification and sends the email, releasing the claim
+// if sending fails so the next published answer tries again
+func (s *QuestionService) notifyAsker(ctx context.Context, questionID uuid.UUID) (bool, error) {
+	if os.Getenv("RESEND_API_KEY") == "" {
+		return false, errors.New("RESEND_API_KEY not set")
+	}
+
+	// Step 1: Claim it
+	var claimed []models.ProductQuestion
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Raw(`
+			UPDATE product_questions
+			SET asker_notified_at = NOW()
+			WHERE id = ? AND status = ? AND answered_at IS NOT NULL AND asker_notified_at IS NULL
+			RETURNING *
+		`, questionID, models.QuestionApproved).
+		Scan(&claimed).Error; err != nil {
+		return false, err
+	}
+	if len(claimed) == 0 {
+		return false, nil
+	}
+	question := claimed[0]
+	release := func() {
+		if err := config.EcommerceGorm.WithContext(context.Background()).
+			Model(&models.ProductQuestion{}).
+			Where("id = ?", questionID).
+			Update("asker_notified_at", nil).Error; err != nil {
+			log.Printf("[questions] failed to release notification of question %s: %v", questionID, err)
+		}
+	}
+
+	// Step 2: Gather the email's contents
+	var asker models.User
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Select("id, name, email").
+		First(&asker, "id = ?", question.UserID).Error; err != nil {
+		release()
+		return false, err
+	}
+	var answer models.ProductAnswer
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Where("question_id = ? AND status = ?", questionID, models.QuestionApproved).
+		Order("created_at ASC").
+		First(&answer).Error; err != nil {
+		release()
+		return false, err
+	}
+	var product models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Unscoped().
+		Select("id, name").
+		First(&product, "id = ?", question.ProductID).Error; err != nil {
+		release()
+		return false, err
+	}
+
+	// Step 3: Send it
+	data := QuestionAnsweredEmailData{
+		Email:       asker.Email,
+		Name:        asker.Name,
+		ProductName: product.Name,
+		Question:    question.Question,
+		Answer:      answer.Answer,
+		ProductURL:  fmt.Sprintf("%s/products/%s", config.GetFrontendURL(), product.ID),
+	}
+	if err := NewResendClient().SendQuestionAnsweredEmail(data); err != nil {
+		release()
+		return false, err
+	}
+	return true, nil
+}
+
+// Global instance
+var questionService *QuestionService
+
+// GetQuestionService returns the global question service instance
+func GetQuestionService() *QuestionService {
+	if questionService == nil {
+		questionService = NewQuestionService()
+	}
+	return questionService
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	coPurchaseMinOrders   = 2  // Pairs seen in fewer completed orders are noise
+	coPurchasesPerProduct = 20 // Pairs kept per product, most frequent first
+)
+
+// RecommendationCandidate is a product that may be recommended, before it's checked to be
+// active and in stock
+type RecommendationCandidate struct {
+	ProductID uuid.UUID
+	Source    string
+}
+
+// RecommendationService keeps the "frequently bought together" pairs up to date and blends
+// them with the curated related products
+type RecommendationService struct {
+	lookback time.Duration
+}
+
+// NewRecommendationService creates a new recommendation service.
+//
+// RECOMMENDATIONS_LOOKBACK: how far back completed orders are mined, as a Go duration (default 8760h, a year)
+func NewRecommendationService() *RecommendationService {
+	lookback := 365 * 24 * time.Hour
+	if raw := os.Getenv("RECOMMENDATIONS_LOOKBACK"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			lookback = parsed
+		} else {
+			log.Printf("[recommendations] invalid RECOMMENDATIONS_LOOKBACK %q, using %s", raw, lookback)
+		}
+	}
+	return &RecommendationService{lookback: lookback}
+}
+
+// RefreshCoPurchases rebuilds product_co_purchases from the completed orders of the
+// lookback window and returns how many pairs were stored. Each order counts once per pair,
+// however many units or combos it had.
+func (s *RecommendationService) RefreshCoPurchases(ctx context.Context) (int, error) {
+	// Step 1: Mine the pairs (ecommerce DB)
+	var pairs []models.ProductCoPurchase
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Raw(`
+			WITH lines AS (
+				SELECT DISTINCT oi.order_id, oi.product_id
+				FROM order_items oi
+				JOIN orders o ON o.id = oi.order_id
+				WHERE o.status = 'completed' AND o.created_at >= ?
+			),
+			totals AS (
+				SELECT product_id, COUNT(*) AS orders
+				FROM lines
+				GROUP BY product_id
+			),
+			pairs AS (
+				SELECT a.product_id, b.product_id AS related_product_id, COUNT(*) AS orders
+				FROM lines a
+				JOIN lines b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+				GROUP BY a.product_id, b.product_id
+				HAVING COUNT(*) >= ?
+			),
+			ranked AS (
+				SELECT
+					p.product_id,
+					p.related_product_id,
+					p.orders,
+					ROUND(p.orders::numeric / t.orders, 4) AS confidence,
+					ROW_NUMBER() OVER (
+						PARTITION BY p.product_id
+						ORDER BY p.orders DESC, p.orders::numeric / t.orders DESC
+					) AS rank
+				FROM pairs p
+				JOIN totals t ON t.product_id = p.product_id
+			)
+			SELECT product_id, related_product_id, orders, confidence
+			FROM ranked
+			WHERE rank <= ?
+		`, time.Now().Add(-s.lookback), coPurchaseMinOrders, coPurchasesPerProduct).
+		Scan(&pairs).Error; err != nil {
+		return 0, err
+	}
+
+	// Step 2: Skip products that have since been purged
+	productIDs := make([]uuid.UUID, 0, len(pairs))
+	for _, pair := range pairs {
+		productIDs = append(productIDs, pair.ProductID, pair.RelatedProductID)
+	}
+	existing := make(map[uuid.UUID]bool)
+	if len(productIDs) > 0 {
+		var ids []uuid.UUID
+		if err := config.CmsGorm.WithContext(ctx).
+			Model(&models.Product{}).
+			Unscoped().
+			Where("id IN ?", productIDs).
+			Pluck("id", &ids).Error; err != nil {
+			return 0, err
+		}
+		for _, id := range ids {
+			existing[id] = true
+		}
+	}
+	computedAt := time.Now()
+	rows := make([]models.ProductCoPurchase, 0, len(pairs))
+	for _, pair := range pairs {
+		if existing[pair.ProductID] && existing[pair.RelatedProductID] {
+			pair.ComputedAt = computedAt
+			rows = append(rows, pair)
+		}
+	}
+
+	// Step 3: Swap the table contents in one go (CMS DB)
+	if err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM product_co_purchases").Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, 500).Error
+	}); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// Candidates returns the products that may be recommended alongside a product: its
+// curated related products in their order, then the ones most often bought with it
+func (s *RecommendationService) Candidates(ctx context.Context, productID uuid.UUID) ([]RecommendationCandidate, error) {
+	var related []uuid.UUID
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.RelatedProduct{}).
+		Where("product_id = ?", productID).
+		Order("position ASC, created_at ASC").
+		Pluck("related_product_id", &related).Error; err != nil {
+		return nil, err
+	}
+
+	var boughtTogether []uuid.UUID
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.ProductCoPurchase{}).
+		Where("product_id = ?", productID).
+		Order("orders DESC, confidence DESC").
+		Pluck("related_product_id", &boughtTogether).Error; err != nil {
+		return nil, err
+	}
+
+	seen := map[uuid.UUID]bool{productID: true}
+	candidates := make([]RecommendationCandidate, 0, len(related)+len(boughtTogether))
+	add := func(ids []uuid.UUID, source string) {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				candidates = append(candidates, RecommendationCandidate{ProductID: id, Source: source})
+			}
+		}
+	}
+	add(related, models.RecommendationSourceRelated)
+	add(boughtTogether, models.RecommendationSourceBoughtTogether)
+	return candidates, nil
+}
+
+// StartScheduler rebuilds the "frequently bought together" pairs in the background.
+//
+// RECOMMENDATIONS_REFRESH_INTERVAL: how often to run, as a Go duration (default 24h)
+func (s *RecommendationService) StartScheduler() {
+	interval := 24 * time.Hour
+	if raw := os.Getenv("RECOMMENDATIONS_REFRESH_INTERVAL"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			log.Printf("[recommendations] invalid RECOMMENDATIONS_REFRESH_INTERVAL %q, using %s", raw, interval)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.runScheduledRefresh()
+			<-ticker.C
+		}
+	}()
+}
+
+// runScheduledRefresh is a single scheduler tick
+func (s *RecommendationService) runScheduledRefresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	stored, err := s.RefreshCoPurchases(ctx)
+	if err != nil {
+		log.Printf("[recommendations] scheduled refresh failed: %v", err)
+		return
+	}
+	log.Printf("[recommendations] stored %d frequently-bought-together pair(s)", stored)
+}
+
+// Global instance
+var recommendationService *RecommendationService
+
+// GetRecommendationService returns the global recommendation service instance
+func GetRecommendationService() *RecommendationService {
+	if recommendationService == nil {
+		recommendationService = NewRecommendationService()
+	}
+	return recommendationService
+}
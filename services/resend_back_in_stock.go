@@ -0,0 +1,233 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+)
+
+// BackInStockEmailData holds data for one back-in-stock email
+type BackInStockEmailData struct {
+	Email          string
+	ProductName    string
+	VariantName    string
+	Price          float64
+	ImageURL       string
+	ProductURL     string
+	UnsubscribeURL string
+}
+
+// BackInStockConfirmationEmailData holds data for the email confirming a guest subscription
+type BackInStockConfirmationEmailData struct {
+	Email          string
+	ProductName    string
+	VariantName    string
+	ConfirmURL     string
+	UnsubscribeURL string
+}
+
+// SendBackInStockConfirmationEmail asks a guest to confirm their back-in-stock subscription via Resend
+func (r *ResendClient) SendBackInStockConfirmationEmail(data BackInStockConfirmationEmailData) error {
+	payload := map[string]interface{}{
+		"from":    r.from,
+		"to":      data.Email,
+		"subject": fmt.Sprintf("Confirm your restock alert for %s", data.ProductName),
+		"html":    r.buildBackInStockConfirmationHTML(data),
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("[resend] failed to marshal payload: %v", err)
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.resend.com/emails", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		log.Printf("[resend] failed to create request: %v", err)
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.apiKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("[resend] failed to send request: %v", err)
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Printf("[resend] failed to read response: %v", err)
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		log.Printf("[resend] api returned status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("resend api error: status %d", resp.StatusCode)
+	}
+
+	log.Printf("[resend] back-in-stock confirmation sent to %s", data.Email)
+	return nil
+}
+
+// SendBackInStockEmails sends up to 100 back-in-stock emails in one Resend batch request
+func (r *ResendClient) SendBackInStockEmails(emails []BackInStockEmailData) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	payload := make([]map[string]interface{}, len(emails))
+	for i, data := range emails {
+		payload[i] = map[string]interface{}{
+			"from":    r.from,
+			"to":      data.Email,
+			"subject": fmt.Sprintf("%s is back in stock", data.ProductName),
+			"html":    r.buildBackInStockHTML(data),
+			"headers": map[string]string{
+				"List-Unsubscribe": fmt.Sprintf("<%s>", data.UnsubscribeURL),
+			},
+		}
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("[resend] failed to marshal payload: %v", err)
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.resend.com/emails/batch", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		log.Printf("[resend] failed to create request: %v", err)
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.apiKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("[resend] failed to send request: %v", err)
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Printf("[resend] failed to read response: %v", err)
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		log.Printf("[resend] api returned status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("resend api error: status %d", resp.StatusCode)
+	}
+
+	log.Printf("[resend] back-in-stock batch sent (%d email(s))", len(emails))
+	return nil
+}
+
+// buildBackInStockHTML creates the HTML body for a back-in-stock email with inline styles
+func (r *ResendClient) buildBackInStockHTML(data BackInStockEmailData) string {
+	image := ""
+	if data.ImageURL != "" {
+		image = fmt.Sprintf(`<img src="%s" alt="%s" style="display: block; width: 100%%; max-width: 600px; height: auto; margin: 0 0 32px 0;" />`,
+			html.EscapeString(data.ImageURL), html.EscapeString(data.ProductName))
+	}
+
+	return fmt.Sprintf(`<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>Back in stock</title>
+  </head>
+  <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', 'Roboto', 'Helvetica Neue', sans-serif; background-color: #ffffff; color: #1a1a1a; line-height: 1.6;">
+    <div style="background-color: #ffffff; padding: 60px 20px;">
+      <div style="max-width: 600px; margin: 0 auto; background: #ffffff;">
+        <div style="padding: 0 0 48px 0; text-align: left;">
+          <div style="font-size: 24px; font-weight: 700; color: #1a1a1a; letter-spacing: -0.3px;">Modeva</div>
+        </div>
+
+        <p style="font-size: 30px; font-weight: 700; color: #000000; margin: 0 0 16px 0; letter-spacing: -0.6px; line-height: 1.2;">It's back.</p>
+        <p style="font-size: 17px; color: #626262; margin: 0 0 32px 0;">
+          <span style="color: #000000; font-weight: 600;">%s</span> in <span style="color: #000000; font-weight: 600;">%s</span> is back in stock. Sizes go fast, so grab yours while it lasts.
+        </p>
+
+        %s
+
+        <p style="font-size: 17px; font-weight: 600; color: #000000; margin: 0 0 32px 0;">$%.2f</p>
+
+        <div style="text-align: left; margin: 0 0 60px 0;">
+          <a href="%s" style="display: inline-block; padding: 16px 32px; background: #000000; color: #ffffff; text-decoration: none; border-radius: 6px; font-weight: 600; font-size: 16px;">Shop now</a>
+        </div>
+
+        <hr style="border: 0; height: 1px; background: #e5e5e5; margin: 0 0 32px 0;" />
+
+        <p style="font-size: 13px; color: #626262; line-height: 1.7; margin: 0 0 8px 0;">
+          You're receiving this because you asked to be notified when this item was restocked. This is a one-time email.
+          <a href="%s" style="color: #0066cc; text-decoration: none;">Unsubscribe</a>
+        </p>
+        <p style="font-size: 13px; color: #626262; line-height: 1.8; margin: 0;">© 2026 Modeva. All rights reserved.</p>
+      </div>
+    </div>
+  </body>
+</html>`,
+		html.EscapeString(data.ProductName),
+		html.EscapeString(data.VariantName),
+		image,
+		data.Price,
+		html.EscapeString(data.ProductURL),
+		html.EscapeString(data.UnsubscribeURL),
+	)
+}
+
+// buildBackInStockConfirmationHTML creates the HTML body for a back-in-stock confirmation email with inline styles
+func (r *ResendClient) buildBackInStockConfirmationHTML(data BackInStockConfirmationEmailData) string {
+	return fmt.Sprintf(`<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>Confirm your restock alert</title>
+  </head>
+  <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', 'Roboto', 'Helvetica Neue', sans-serif; background-color: #ffffff; color: #1a1a1a; line-height: 1.6;">
+    <div style="background-color: #ffffff; padding: 60px 20px;">
+      <div style="max-width: 600px; margin: 0 auto; background: #ffffff;">
+        <div style="padding: 0 0 48px 0; text-align: left;">
+          <div style="font-size: 24px; font-weight: 700; color: #1a1a1a; letter-spacing: -0.3px;">Modeva</div>
+        </div>
+
+        <p style="font-size: 30px; font-weight: 700; color: #000000; margin: 0 0 16px 0; letter-spacing: -0.6px; line-height: 1.2;">One more step.</p>
+        <p style="font-size: 17px; color: #626262; margin: 0 0 32px 0;">
+          Confirm your email and we'll let you know as soon as <span style="color: #000000; font-weight: 600;">%s</span> in <span style="color: #000000; font-weight: 600;">%s</span> is back in stock.
+        </p>
+
+        <div style="text-align: left; margin: 0 0 60px 0;">
+          <a href="%s" style="display: inline-block; padding: 16px 32px; background: #000000; color: #ffffff; text-decoration: none; border-radius: 6px; font-weight: 600; font-size: 16px;">Confirm restock alert</a>
+        </div>
+
+        <hr style="border: 0; height: 1px; background: #e5e5e5; margin: 0 0 32px 0;" />
+
+        <p style="font-size: 13px; color: #626262; line-height: 1.7; margin: 0 0 8px 0;">
+          Didn't ask for this? Ignore this email and you won't hear from us, or
+          <a href="%s" style="color: #0066cc; text-decoration: none;">unsubscribe</a>.
+        </p>
+        <p style="font-size: 13px; color: #626262; line-height: 1.8; margin: 0;">© 2026 Modeva. All rights reserved.</p>
+      </div>
+    </div>
+  </body>
+</html>`,
+		html.EscapeString(data.ProductName),
+		html.EscapeString(data.VariantName),
+		html.EscapeString(data.ConfirmURL),
+		html.EscapeString(data.UnsubscribeURL),
+	)
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// LowStockDigestItem is one combo listed in the low-stock digest
+type LowStockDigestItem struct {
+	ProductName string
+	VariantName string
+	SKU         string
+	Quantity    int
+	Threshold   int
+}
+
+// SendLowStockDigestEmail sends the low-stock digest to the configured admins via Resend
+func (r *ResendClient) SendLowStockDigestEmail(to []string, items []LowStockDigestItem) error {
+	htmlBody := r.buildLowStockDigestHTML(items)
+
+	payload := map[string]interface{}{
+		"from":    r.from,
+		"to":      to,
+		"subject": fmt.Sprintf("Low stock: %d item(s) need restocking", len(items)),
+		"html":    htmlBody,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("[resend] failed to marshal payload: %v", err)
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.resend.com/emails", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		log.Printf("[resend] failed to create request: %v", err)
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.apiKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("[resend] failed to send request: %v", err)
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Printf("[resend] failed to read response: %v", err)
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		log.Printf("[resend] api returned status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("resend api error: status %d", resp.StatusCode)
+	}
+
+	log.Printf("[resend] low-stock digest sent to %d recipient(s)", len(to))
+	return nil
+}
+
+// buildLowStockDigestHTML creates the HTML body for the low-stock digest with inline styles
+func (r *ResendClient) buildLowStockDigestHTML(items []LowStockDigestItem) string {
+	var rows strings.Builder
+	for _, item := range items {
+		rows.WriteString(fmt.Sprintf(`
+            <tr>
+              <td style="padding: 10px 0; font-size: 14px; color: #1a1a1a; border-bottom: 1px solid #e5e5e5;">%s<br><span style="font-size: 12px; color: #626262;">%s · SKU: %s</span></td>
+              <td style="padding: 10px 0; font-size: 14px; text-align: right; font-weight: 600; color: %s; border-bottom: 1px solid #e5e5e5;">%d</td>
+              <td style="padding: 10px 0; font-size: 14px; text-align: right; color: #626262; border-bottom: 1px solid #e5e5e5;">%d</td>
+            </tr>`,
+			html.EscapeString(item.ProductName),
+			html.EscapeString(item.VariantName),
+			html.EscapeString(item.SKU),
+			quantityColor(item.Quantity),
+			item.Quantity,
+			item.Threshold,
+		))
+	}
+
+	return fmt.Sprintf(`<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>Low stock digest</title>
+  </head>
+  <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', 'Roboto', 'Helvetica Neue', sans-serif; background-color: #ffffff; color: #1a1a1a; line-height: 1.6;">
+    <div style="background-color: #ffffff; padding: 60px 20px;">
+      <div style="max-width: 600px; margin: 0 auto; background: #ffffff;">
+        <div style="padding: 0 0 48px 0; text-align: left;">
+          <div style="font-size: 24px; font-weight: 700; color: #1a1a1a; letter-spacing: -0.3px;">Modeva</div>
+        </div>
+
+        <p style="font-size: 30px; font-weight: 700; color: #000000; margin: 0 0 16px 0; letter-spacing: -0.6px; line-height: 1.2;">Low stock</p>
+        <p style="font-size: 16px; color: #626262; margin: 0 0 32px 0;">%d item(s) have dropped below their low-stock threshold.</p>
+
+        <table style="width: 100%%; border-collapse: collapse;">
+          <thead>
+            <tr>
+              <th style="padding: 0 0 8px 0; font-size: 12px; font-weight: 600; color: #626262; text-transform: uppercase; letter-spacing: 0.8px; text-align: left;">Item</th>
+              <th style="padding: 0 0 8px 0; font-size: 12px; font-weight: 600; color: #626262; text-transform: uppercase; letter-spacing: 0.8px; text-align: right;">In stock</th>
+              <th style="padding: 0 0 8px 0; font-size: 12px; font-weight: 600; color: #626262; text-transform: uppercase; letter-spacing: 0.8px; text-align: right;">Threshold</th>
+            </tr>
+          </thead>
+          <tbody>%s
+          </tbody>
+        </table>
+
+        <p style="font-size: 13px; color: #626262; line-height: 1.7; margin-top: 40px;">
+          Dismiss or review these alerts from the Inventory section of the Modeva admin.
+        </p>
+
+        <div style="padding: 40px 0 0 0; text-align: left;">
+          <p style="font-size: 13px; color: #626262; line-height: 1.8; margin: 0;">© 2026 Modeva. All rights reserved.</p>
+        </div>
+      </div>
+    </div>
+  </body>
+</html>`, len(items), rows.String())
+}
+
+// quantityColor highlights sold-out combos in the digest
+func quantityColor(quantity int) string {
+	if quantity <= 0 {
+		return "#c0392b"
+	}
+	return "#1a1a1a"
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// QuestionAnsweredEmailData holds data for the email telling a customer their question was answered
+type QuestionAnsweredEmailData struct {
+	Email       string
+	Name        string
+	ProductName string
+	Question    string
+	Answer      string // The first published answer
+	ProductURL  string
+}
+
+// SendQuestionAnsweredEmail tells a customer their product question has been answered via Resend
+func (r *ResendClient) SendQuestionAnsweredEmail(data QuestionAnsweredEmailData) error {
+	payload := map[string]interface{}{
+		"from":    r.from,
+		"to":      data.Email,
+		"subject": fmt.Sprintf("Your question about %s has been answered", data.ProductName),
+		"html":    r.buildQuestionAnsweredHTML(data),
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("[resend] failed to marshal payload: %v", err)
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.resend.com/emails", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		log.Printf("[resend] failed to create request: %v", err)
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.apiKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("[resend] failed to send request: %v", err)
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Printf("[resend] failed to read response: %v", err)
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		log.Printf("[resend] api returned status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("resend api error: status %d", resp.StatusCode)
+	}
+
+	log.Printf("[resend] question-answered email sent to %s", data.Email)
+	return nil
+}
+
+// buildQuestionAnsweredHTML creates the HTML body for a question-answered email with inline styles
+func (r *ResendClient) buildQuestionAnsweredHTML(data QuestionAnsweredEmailData) string {
+	greeting := "Hi,"
+	if fields := strings.Fields(data.Name); len(fields) > 0 {
+		greeting = fmt.Sprintf("Hi %s,", html.EscapeString(fields[0]))
+	}
+
+	return fmt.Sprintf(`<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>Your question has been answered</title>
+  </head>
+  <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', 'Roboto', 'Helvetica Neue', sans-serif; background-color: #ffffff; color: #1a1a1a; line-height: 1.6;">
+    <div style="background-color: #ffffff; padding: 60px 20px;">
+      <div style="max-width: 600px; margin: 0 auto; background: #ffffff;">
+        <div style="padding: 0 0 48px 0; text-align: left;">
+          <div style="font-size: 24px; font-weight: 700; color: #1a1a1a; letter-spacing: -0.3px;">Modeva</div>
+        </div>
+
+        <p style="font-size: 30px; font-weight: 700; color: #000000; margin: 0 0 16px 0; letter-spacing: -0.6px; line-height: 1.2;">You've got an answer.</p>
+        <p style="font-size: 17px; color: #626262; margin: 0 0 32px 0;">
+          %s your question about <span style="color: #000000; font-weight: 600;">%s</span> has been answered.
+        </p>
+
+        <div style="border-left: 3px solid #e5e5e5; padding: 0 0 0 16px; margin: 0 0 24px 0;">
+          <p style="font-size: 13px; font-weight: 600; color: #626262; margin: 0 0 4px 0; text-transform: uppercase; letter-spacing: 0.5px;">Your question</p>
+          <p style="font-size: 16px; color: #1a1a1a; margin: 0;">%s</p>
+        </div>
+        <div style="border-left: 3px solid #000000; padding: 0 0 0 16px; margin: 0 0 40px 0;">
+          <p style="font-size: 13px; font-weight: 600; color: #626262; margin: 0 0 4px 0; text-transform: uppercase; letter-spacing: 0.5px;">Answer</p>
+          <p style="font-size: 16px; color: #1a1a1a; margin: 0;">%s</p>
+        </div>
+
+        <div style="text-align: left; margin: 0 0 60px 0;">
+          <a href="%s" style="display: inline-block; padding: 16px 32px; background: #000000; color: #ffffff; text-decoration: none; border-radius: 6px; font-weight: 600; font-size: 16px;">View product</a>
+        </div>
+
+        <hr style="border: 0; height: 1px; background: #e5e5e5; margin: 0 0 32px 0;" />
+
+        <p style="font-size: 13px; color: #626262; line-height: 1.7; margin: 0 0 8px 0;">
+          You're receiving this because you asked a question on Modeva. We only email you once per question.
+        </p>
+        <p style="font-size: 13px; color: #626262; line-height: 1.8; margin: 0;">© 2026 Modeva. All rights reserved.</p>
+      </div>
+    </div>
+  </body>
+</html>`,
+		greeting,
+		html.EscapeString(data.ProductName),
+		html.EscapeString(data.Question),
+		html.EscapeString(data.Answer),
+		html.EscapeString(data.ProductURL),
+	)
+}
@@ -34,9 +34,11 @@ type OrderInvoicePDFEmailData struct {
 // OrderInvoiceItem represents a line item in an invoice
 type OrderInvoiceItem struct {
 	ProductName string
+	SKU         string // Empty for items ordered before SKUs existed
 	Quantity    int
 	Price       float64
 	Subtotal    float64
+	Components  []string // Bundle contents, one line per component
 }
 
 // SendOrderInvoicePDFEmail sends an order invoice with HTML preview + PDF attachment via Resend
@@ -44,6 +46,13 @@ func (r *ResendClient) SendOrderInvoicePDFEmail(data OrderInvoicePDFEmailData) e
 	// Build invoice items HTML rows
 	var itemsRows strings.Builder
 	for _, item := range data.Items {
+		description := item.ProductName
+		if item.SKU != "" {
+			description = fmt.Sprintf(`%s<br><span style="font-size: 12px; color: #79776d;">SKU: %s</span>`, item.ProductName, item.SKU)
+		}
+		for _, component := range item.Components {
+			description += fmt.Sprintf(`<br><span style="font-size: 12px; color: #79776d;">&nbsp;&nbsp;%s</span>`, component)
+		}
 		itemsRows.WriteString(fmt.Sprintf(`
       <tr>
         <td style="padding: 8px 0; font-size: 14px; color: #262622;">%s</td>
@@ -51,7 +60,7 @@ func (r *ResendClient) SendOrderInvoicePDFEmail(data OrderInvoicePDFEmailData) e
         <td style="padding: 8px 0; font-size: 14px; text-align: right; color: #262622;">$%.2f</td>
         <td style="padding: 8px 0; font-size: 14px; text-align: right; font-weight: 600; color: #262622;">$%.2f</td>
       </tr>
-    `, item.ProductName, item.Quantity, item.Price, item.Subtotal))
+    `, description, item.Quantity, item.Price, item.Subtotal))
 	}
 
 	// Discount row
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+)
+
+// ReviewService checks who may review a product and keeps product ratings in step with
+// the approved reviews. Reviews live in the ecommerce DB; ratings on CMS products.
+type ReviewService struct{}
+
+// NewReviewService creates a new review service
+func NewReviewService() *ReviewService {
+	return &ReviewService{}
+}
+
+// VerifiedOrder returns the customer's most recent completed order containing the
+// product, or nil if they have none (and so can't review it)
+func (s *ReviewService) VerifiedOrder(ctx context.Context, userID, productID uuid.UUID) (*uuid.UUID, error) {
+	var orderIDs []uuid.UUID
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Table("orders o").
+		Select("o.id").
+		Joins("JOIN order_items oi ON oi.order_id = o.id").
+		Where("o.user_id = ? AND o.status = 'completed' AND oi.product_id = ?", userID, productID).
+		Order("o.created_at DESC").
+		Limit(1).
+		Pluck("o.id", &orderIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+	return &orderIDs[0], nil
+}
+
+// Summary returns a product's rating from its approved reviews, with the number of
+// reviews for each star rating
+func (s *ReviewService) Summary(ctx context.Context, productID uuid.UUID) (*models.RatingSummary, error) {
+	var rows []struct {
+		Rating int
+		Count  int
+	}
+	if err := config.EcommerceGorm.WithContext(ctx).
+		Model(&models.ProductReview{}).
+		Select("rating, COUNT(*) AS count").
+		Where("product_id = ? AND status = ?", productID, models.ReviewApproved).
+		Group("rating").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	summary := &models.RatingSummary{Distribution: map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}}
+	total := 0
+	for _, row := range rows {
+		summary.Distribution[row.Rating] = row.Count
+		summary.Count += row.Count
+		total += row.Rating * row.Count
+	}
+	if summary.Count > 0 {
+		summary.Average = math.Round(float64(total)/float64(summary.Count)*100) / 100
+	}
+	return summary, nil
+}
+
+// RefreshRating recalculates a product's rating_average and rating_count from its approved
+// reviews. Call it after a review is approved, rejected or an approved one is removed.
+func (s *ReviewService) RefreshRating(ctx context.Context, productID uuid.UUID) error {
+	summary, err := s.Summary(ctx, productID)
+	if err != nil {
+		return err
+	}
+	// Unscoped: trashed products keep their rating for when they're restored
+	return config.CmsGorm.WithContext(ctx).
+		Model(&models.Product{}).
+		Unscoped().
+		Where("id = ?", productID).
+		UpdateColumns(map[string]interface{}{
+			"rating_average": summary.Average,
+			"rating_count":   summary.Count,
+		}).Error
+}
+
+// ProductNames looks up product names by ID, trashed products included
+func (s *ReviewService) ProductNames(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	names := make(map[uuid.UUID]string, len(productIDs))
+	if len(productIDs) == 0 {
+		return names, nil
+	}
+
+	var products []models.Product
+	if err := config.CmsGorm.WithContext(ctx).
+		Unscoped().
+		Select("id, name").
+		Where("id IN ?", productIDs).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+	for _, product := range products {
+		names[product.ID] = product.Name
+	}
+	return names, nil
+}
+
+// Global instance
+var reviewService *ReviewService
+
+// GetReviewService returns the global review service instance
+func GetReviewService() *ReviewService {
+	if reviewService == nil {
+		reviewService = NewReviewService()
+	}
+	return reviewService
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+)
+
+// Search analytics reports
+const (
+	SearchReportTop             = "top"               // Most made
+	SearchReportZeroResults     = "zero_results"      // Made and found nothing
+	SearchReportLowClickThrough = "low_click_through" // Found something, but it's rarely clicked
+)
+
+// SearchReportOptions select what a search analytics report covers
+type SearchReportOptions struct {
+	From        time.Time // Inclusive
+	To          time.Time // Exclusive
+	Source      string    // models.SearchSource*, or "" for all
+	Limit       int
+	MinSearches int     // Low click-through only: queries made fewer times are left out
+	MaxCTR      float64 // Low click-through only: queries clicked more often are left out
+}
+
+// SearchAnalyticsService logs storefront searches and the clicks on their results, and
+// reports on them. Logs are written in the background so searches aren't slowed down.
+type SearchAnalyticsService struct{}
+
+// NewSearchAnalyticsService creates a new search analytics service
+func NewSearchAnalyticsService() *SearchAnalyticsService {
+	return &SearchAnalyticsService{}
+}
+
+// LogSearch logs a search in the background and returns its ID, for the storefront to
+// report clicks on its results with. Empty queries aren't logged (uuid.Nil).
+func (s *SearchAnalyticsService) LogSearch(query, source string, results int, filters models.SearchFilters, userID *uuid.UUID) uuid.UUID {
+	entry := models.SearchLog{
+		ID:          uuid.Must(uuid.NewV7()),
+		Query:       NormalizeSearchText(query),
+		Source:      source,
+		ResultCount: results,
+		Filters:     filters,
+		UserID:      userID,
+	}
+	if entry.Query == "" {
+		return uuid.Nil
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := config.CmsGorm.WithContext(ctx).Create(&entry).Error; err != nil {
+			log.Printf("[search.analytics] failed to log search %q: %v", entry.Query, err)
+		}
+	}()
+	return entry.ID
+}
+
+// RecordClick counts a click on one of a search's results, remembering the first product
+// clicked. It reports false when there's no such search.
+func (s *SearchAnalyticsService) RecordClick(ctx context.Context, searchID, productID uuid.UUID) (bool, error) {
+	result := config.CmsGorm.WithContext(ctx).Exec(`
+		UPDATE search_logs
+		SET clicks = clicks + 1,
+			clicked_product_id = COALESCE(clicked_product_id, ?),
+			first_clicked_at = COALESCE(first_clicked_at, NOW())
+		WHERE id = ?
+	`, productID, searchID)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Report returns the totals of the date range and the queries of the report, grouped by
+// normalized query
+func (s *SearchAnalyticsService) Report(ctx context.Context, report string, opts SearchReportOptions) (models.SearchAnalyticsTotals, []models.SearchQueryStats, error) {
+	where := "created_at >= ? AND created_at < ?"
+	args := []interface{}{opts.From, opts.To}
+	if opts.Source != "" {
+		where += " AND source = ?"
+		args = append(args, opts.Source)
+	}
+
+	// Step 1: Totals
+	var totals models.SearchAnalyticsTotals
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(fmt.Sprintf(`
+			SELECT
+				COUNT(*) AS searches,
+				COUNT(DISTINCT query) AS unique_queries,
+				COALESCE(AVG((result_count = 0)::int), 0)::float8 AS zero_result_rate,
+				COALESCE(AVG((clicks > 0)::int), 0)::float8 AS click_through_rate
+			FROM search_logs
+			WHERE %s
+		`, where), args...).
+		Scan(&totals).Error; err != nil {
+		return totals, nil, err
+	}
+
+	// Step 2: The report's queries
+	having := ""
+	order := "searches DESC"
+	switch report {
+	case SearchReportZeroResults:
+		having = "HAVING COUNT(*) FILTER (WHERE result_count = 0) > 0"
+		order = "zero_result_searches DESC"
+	case SearchReportLowClickThrough:
+		having = "HAVING COUNT(*) >= ? AND AVG(result_count) > 0 AND AVG((clicks > 0)::int) <= ?"
+		args = append(args, opts.MinSearches, opts.MaxCTR)
+		order = "click_through_rate ASC, searches DESC"
+	}
+	args = append(args, opts.Limit)
+
+	queries := make([]models.SearchQueryStats, 0, opts.Limit)
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(fmt.Sprintf(`
+			SELECT
+				query,
+				COUNT(*) AS searches,
+				AVG(result_count)::float8 AS average_results,
+				COUNT(*) FILTER (WHERE result_count = 0) AS zero_result_searches,
+				COUNT(*) FILTER (WHERE clicks > 0) AS clicked_searches,
+				AVG((clicks > 0)::int)::float8 AS click_through_rate,
+				MAX(created_at) AS last_searched_at
+			FROM search_logs
+			WHERE %s
+			GROUP BY query
+			%s
+			ORDER BY %s, query ASC
+			LIMIT ?
+		`, where, having, order), args...).
+		Scan(&queries).Error; err != nil {
+		return totals, nil, err
+	}
+	return totals, queries, nil
+}
+
+// Global instance
+var searchAnalyticsService *SearchAnalyticsService
+
+// GetSearchAnalyticsService returns the global search analytics service instance
+func GetSearchAnalyticsService() *SearchAnalyticsService {
+	if searchAnalyticsService == nil {
+		searchAnalyticsService = NewSearchAnalyticsService()
+	}
+	return searchAnalyticsService
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+)
+
+const (
+	searchRulesTTL     = time.Minute // Other servers see rule changes within this
+	searchMaxVariants  = 8           // Synonym alternatives of one search, at most
+	searchPinsPerQuery = 50          // Pins and buries applied per search, at most
+)
+
+// searchRules are all the search rules, as loaded
+type searchRules struct {
+	synonyms  []models.SearchSynonymGroup
+	redirects map[string]string
+	pins      map[string][]models.SearchPin // By query, in position order
+	fetchedAt time.Time
+}
+
+// SearchRuleService applies the CMS-managed search rules (synonyms, redirects and pinned
+// or buried products) to storefront searches. The rules are few and read on every search,
+// so they're kept in memory and reloaded every searchRulesTTL, or as soon as they change
+// on this server.
+type SearchRuleService struct {
+	mu    sync.RWMutex
+	rules *searchRules
+}
+
+// NewSearchRuleService creates a new search rule service
+func NewSearchRuleService() *SearchRuleService {
+	return &SearchRuleService{}
+}
+
+// Plan applies the rules to a search. If they can't be loaded the search goes ahead
+// without them.
+func (s *SearchRuleService) Plan(ctx context.Context, query string) *models.SearchPlan {
+	query = strings.TrimSpace(query)
+	normalized := NormalizeSearchText(query)
+	plan := &models.SearchPlan{
+		Query:      query,
+		Normalized: normalized,
+		Expanded:   normalized,
+		Synonyms:   make([][]string, 0),
+		Pinned:     make([]uuid.UUID, 0),
+		Buried:     make([]uuid.UUID, 0),
+	}
+
+	rules, err := s.load(ctx)
+	if err != nil {
+		log.Printf("[search.rules] failed to load search rules, searching without them: %v", err)
+		return plan
+	}
+
+	if url, ok := rules.redirects[normalized]; ok {
+		plan.RedirectURL = &url
+	}
+	for _, pin := range rules.pins[normalized] {
+		if pin.Action == models.SearchPinBury {
+			plan.Buried = append(plan.Buried, pin.ProductID)
+		} else {
+			plan.Pinned = append(plan.Pinned, pin.ProductID)
+		}
+	}
+	plan.Expanded, plan.Synonyms = expandSynonyms(normalized, rules.synonyms)
+	return plan
+}
+
+// Invalidate drops the loaded rules; call it after changing any of them
+func (s *SearchRuleService) Invalidate() {
+	s.mu.Lock()
+	s.rules = nil
+	s.mu.Unlock()
+}
+
+// load returns the rules, reloading them when they're older than searchRulesTTL
+func (s *SearchRuleService) load(ctx context.Context) (*searchRules, error) {
+	s.mu.RLock()
+	rules := s.rules
+	s.mu.RUnlock()
+	if rules != nil && time.Since(rules.fetchedAt) < searchRulesTTL {
+		return rules, nil
+	}
+
+	rules = &searchRules{
+		redirects: make(map[string]string),
+		pins:      make(map[string][]models.SearchPin),
+		fetchedAt: time.Now(),
+	}
+	if err := config.CmsGorm.WithContext(ctx).
+		Order("created_at ASC").
+		Find(&rules.synonyms).Error; err != nil {
+		return nil, err
+	}
+
+	var redirects []models.SearchRedirect
+	if err := config.CmsGorm.WithContext(ctx).Find(&redirects).Error; err != nil {
+		return nil, err
+	}
+	for _, redirect := range redirects {
+		rules.redirects[redirect.Query] = redirect.URL
+	}
+
+	// Only pins of active products; the rest wouldn't be shown anyway
+	var pins []models.SearchPin
+	if err := config.CmsGorm.WithContext(ctx).
+		Table("search_pins sp").
+		Select("sp.*").
+		Joins("JOIN products p ON p.id = sp.product_id AND p.deleted_at IS NULL AND p.status = 'Active'").
+		Order("sp.query, sp.position ASC, sp.created_at ASC").
+		Scan(&pins).Error; err != nil {
+		return nil, err
+	}
+	for _, pin := range pins {
+		if len(rules.pins[pin.Query]) < searchPinsPerQuery {
+			rules.pins[pin.Query] = append(rules.pins[pin.Query], pin)
+		}
+	}
+
+	s.mu.Lock()
+	s.rules = rules
+	s.mu.Unlock()
+	return rules, nil
+}
+
+// SynonymGroupWithTerm returns the synonym group (other than exceptID) that has any of
+// the terms, if there is one. A term belongs to one group at most.
+func (s *SearchRuleService) SynonymGroupWithTerm(ctx context.Context, terms []string, exceptID uuid.UUID) (*models.SearchSynonymGroup, string, error) {
+	var groups []models.SearchSynonymGroup
+	if err := config.CmsGorm.WithContext(ctx).Find(&groups).Error; err != nil {
+		return nil, "", err
+	}
+	for i := range groups {
+		if groups[i].ID == exceptID {
+			continue
+		}
+		for _, existing := range groups[i].Terms {
+			for _, term := range terms {
+				if existing == term {
+					return &groups[i], term, nil
+				}
+			}
+		}
+	}
+	return nil, "", nil
+}
+
+// expandSynonyms turns a normalized search into a websearch query matching its synonyms
+// too: for each group with a term in the search, the search is repeated with the term
+// swapped for each of the others, and the variants are joined with "or"
+// ("white sneakers" → "white sneakers or white trainers"). Excluded terms ("-sneakers")
+// aren't swapped.
+func expandSynonyms(normalized string, groups []models.SearchSynonymGroup) (string, [][]string) {
+	variants := []string{normalized}
+	applied := make([][]string, 0)
+
+	for _, group := range groups {
+		var matched string
+		for _, term := range group.Terms {
+			if findSearchTerm(normalized, term) != nil {
+				matched = term
+				break
+			}
+		}
+		if matched == "" {
+			continue
+		}
+
+		next := make([]string, 0, len(variants)*len(group.Terms))
+		seen := make(map[string]bool)
+		for _, variant := range variants {
+			loc := findSearchTerm(variant, matched)
+			for _, alternative := range group.Terms {
+				swapped := variant
+				if loc != nil {
+					swapped = variant[:loc[0]] + alternative + variant[loc[1]:]
+				}
+				if !seen[swapped] {
+					seen[swapped] = true
+					next = append(next, swapped)
+				}
+			}
+		}
+		if len(next) > searchMaxVariants {
+			break // The groups before this one still apply
+		}
+		variants = next
+		applied = append(applied, group.Terms)
+	}
+
+	return strings.Join(variants, " or "), applied
+}
+
+// findSearchTerm returns where the term is in the text as whole words (not part of a
+// longer word, not excluded with a leading "-"), or nil
+func findSearchTerm(text, term string) []int {
+	pattern := regexp.MustCompile(`(^|[^\p{L}\p{N}-])(` + regexp.QuoteMeta(term) + `)($|[^\p{L}\p{N}])`)
+	match := pattern.FindStringSubmatchIndex(text)
+	if match == nil {
+		return nil
+	}
+	return match[4:6]
+}
+
+// Global instance
+var searchRuleService *SearchRuleService
+
+// GetSearchRuleService returns the global search rule service instance
+func GetSearchRuleService() *SearchRuleService {
+	if searchRuleService == nil {
+		searchRuleService = NewSearchRuleService()
+	}
+	return searchRuleService
+}
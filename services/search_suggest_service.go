@@ -0,0 +1,634 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis keys of the suggestion index
+const (
+	suggestTermsKey    = "search:suggest:terms"     // "term\x00kind:id" members, all scored 0 so they sort by bytes
+	suggestItemsKey    = "search:suggest:items"     // kind:id → suggestItem JSON
+	suggestQueriesKey  = "search:suggest:queries"   // Storefront search → times searched
+	suggestQueryLexKey = "search:suggest:query-lex" // Searched queries, all scored 0 so they sort by bytes
+	suggestBuiltKey    = "search:suggest:built"     // When the index was last built
+)
+
+// Kinds of indexed items
+const (
+	suggestKindProduct     = "p"
+	suggestKindCategory    = "c"
+	suggestKindSubcategory = "s"
+)
+
+const (
+	suggestProducts    = 6    // Products suggested per lookup
+	suggestCategories  = 4    // Categories, and subcategories, suggested per lookup
+	suggestQueries     = 5    // Popular searches suggested per lookup
+	suggestScanLimit   = 200  // Index entries read per lookup before picking the best
+	suggestMinSearches = 3    // Searches made fewer times aren't suggested (typos, one-offs, personal details)
+	suggestKeptQueries = 5000 // Most-made searches kept at each refresh
+	searchMaxTextLen   = 100  // Longer text is cut to this many characters
+	suggestMaxTermLen  = 60   // Indexed terms are cut to this many characters
+	suggestWriteBatch  = 1000 // Members written per Redis command when rebuilding
+)
+
+// errSuggestIndexMissing means the Redis index hasn't been built (yet)
+var errSuggestIndexMissing = errors.New("search suggestion index not built")
+
+// suggestItem is a product or category as stored in the index
+type suggestItem struct {
+	Kind       string     `json:"k"`
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"n"`
+	Thumbnail  string     `json:"t,omitempty"`
+	ParentID   *uuid.UUID `json:"pid,omitempty"`
+	ParentName *string    `json:"pn,omitempty"`
+	Views      int        `json:"v,omitempty"`
+}
+
+// key is the item's field in the items hash
+func (i suggestItem) key() string {
+	return i.Kind + ":" + i.ID.String()
+}
+
+// SearchSuggestService suggests products, categories and popular searches as customers type
+// in the storefront search box. It reads a prefix index in Redis: every word-start of each
+// active product and category name ("linen dress" and "dress" for "Linen Dress") in a
+// lexicographically sorted set, rebuilt from the catalogue on a schedule, and the searches
+// customers make, counted as they're made. When Redis is unavailable it falls back to name
+// prefix queries on the CMS database, without popular searches.
+type SearchSuggestService struct{}
+
+// NewSearchSuggestService creates a new search suggestion service
+func NewSearchSuggestService() *SearchSuggestService {
+	return &SearchSuggestService{}
+}
+
+// Suggest returns the suggestions for what the customer has typed so far
+func (s *SearchSuggestService) Suggest(ctx context.Context, text string) (*models.SearchSuggestions, error) {
+	prefix := NormalizeSearchText(text)
+	if prefix == "" {
+		return emptySuggestions(), nil
+	}
+
+	suggestions, err := s.suggestFromIndex(ctx, prefix)
+	if err == nil {
+		return suggestions, nil
+	}
+	if !errors.Is(err, errSuggestIndexMissing) {
+		log.Printf("[search.suggest] index lookup failed, using the database: %v", err)
+	}
+	return s.suggestFromDB(ctx, prefix)
+}
+
+// suggestFromIndex looks the prefix up in the Redis index
+func (s *SearchSuggestService) suggestFromIndex(ctx context.Context, prefix string) (*models.SearchSuggestions, error) {
+	if config.RedisClient == nil {
+		return nil, errors.New("redis not connected")
+	}
+
+	// Step 1: Find the names and searches starting with the prefix
+	pipe := config.RedisClient.Pipeline()
+	built := pipe.Exists(ctx, suggestBuiltKey)
+	terms := pipe.ZRangeByLex(ctx, suggestTermsKey, suggestLexRange(prefix))
+	queries := pipe.ZRangeByLex(ctx, suggestQueryLexKey, suggestLexRange(prefix))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	if built.Val() == 0 {
+		return nil, errSuggestIndexMissing
+	}
+
+	keys := make([]string, 0, len(terms.Val()))
+	seen := make(map[string]bool, len(terms.Val()))
+	for _, member := range terms.Val() {
+		_, key, ok := strings.Cut(member, "\x00")
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	candidates := make([]string, 0, len(queries.Val()))
+	for _, query := range queries.Val() {
+		if query != prefix {
+			candidates = append(candidates, query)
+		}
+	}
+
+	// Step 2: Load the items and how often the searches were made
+	var itemsCmd *redis.SliceCmd
+	var scoresCmd *redis.FloatSliceCmd
+	pipe = config.RedisClient.Pipeline()
+	if len(keys) > 0 {
+		itemsCmd = pipe.HMGet(ctx, suggestItemsKey, keys...)
+	}
+	if len(candidates) > 0 {
+		scoresCmd = pipe.ZMScore(ctx, suggestQueriesKey, candidates...)
+	}
+	if itemsCmd != nil || scoresCmd != nil {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 3: Pick the best of each
+	items := make([]suggestItem, 0, len(keys))
+	if itemsCmd != nil {
+		for _, value := range itemsCmd.Val() {
+			raw, ok := value.(string)
+			if !ok {
+				continue // Gone since the terms were read (the index was rebuilt)
+			}
+			var item suggestItem
+			if err := json.Unmarshal([]byte(raw), &item); err != nil {
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+	suggestions := rankSuggestItems(prefix, items)
+
+	if scoresCmd != nil {
+		type popularQuery struct {
+			query    string
+			searches float64
+		}
+		popular := make([]popularQuery, 0, len(candidates))
+		for i, searches := range scoresCmd.Val() {
+			if searches >= suggestMinSearches {
+				popular = append(popular, popularQuery{query: candidates[i], searches: searches})
+			}
+		}
+		sort.SliceStable(popular, func(i, j int) bool {
+			return popular[i].searches > popular[j].searches
+		})
+		for i := 0; i < len(popular) && i < suggestQueries; i++ {
+			suggestions.Queries = append(suggestions.Queries, popular[i].query)
+		}
+	}
+	return suggestions, nil
+}
+
+// rankSuggestItems sorts the matched items into products, categories and subcategories:
+// names that start with the prefix before names with a later word that does, then the most
+// viewed products and alphabetically
+func rankSuggestItems(prefix string, items []suggestItem) *models.SearchSuggestions {
+	startsWith := func(item suggestItem) bool {
+		return strings.HasPrefix(NormalizeSearchText(item.Name), prefix)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if a, b := startsWith(items[i]), startsWith(items[j]); a != b {
+			return a
+		}
+		if items[i].Views != items[j].Views {
+			return items[i].Views > items[j].Views
+		}
+		return strings.ToLower(items[i].Name) < strings.ToLower(items[j].Name)
+	})
+
+	suggestions := emptySuggestions()
+	for _, item := range items {
+		switch item.Kind {
+		case suggestKindProduct:
+			if len(suggestions.Products) < suggestProducts {
+				suggestions.Products = append(suggestions.Products, models.SuggestedProduct{
+					ID:        item.ID,
+					Name:      item.Name,
+					Thumbnail: item.Thumbnail,
+				})
+			}
+		case suggestKindCategory:
+			if len(suggestions.Categories) < suggestCategories {
+				suggestions.Categories = append(suggestions.Categories, models.SuggestedCategory{
+					ID:   item.ID,
+					Name: item.Name,
+				})
+			}
+		case suggestKindSubcategory:
+			if len(suggestions.Subcategories) < suggestCategories {
+				suggestions.Subcategories = append(suggestions.Subcategories, models.SuggestedCategory{
+					ID:         item.ID,
+					Name:       item.Name,
+					ParentID:   item.ParentID,
+					ParentName: item.ParentName,
+				})
+			}
+		}
+	}
+	return suggestions
+}
+
+// suggestFromDB finds the products and categories with a name word starting with the
+// prefix in the CMS database (trigram indexed on lower(name))
+func (s *SearchSuggestService) suggestFromDB(ctx context.Context, prefix string) (*models.SearchSuggestions, error) {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+	startsWith := escaped + "%"
+	wordStartsWith := "% " + escaped + "%"
+
+	suggestions := emptySuggestions()
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`
+			SELECT id, name, COALESCE(media->'primary'->>'url', '') AS thumbnail
+			FROM products
+			WHERE status = 'Active' AND deleted_at IS NULL
+			  AND (lower(name) LIKE ? OR lower(name) LIKE ?)
+			ORDER BY lower(name) LIKE ? DESC, views DESC, name ASC
+			LIMIT ?
+		`, startsWith, wordStartsWith, startsWith, suggestProducts).
+		Scan(&suggestions.Products).Error; err != nil {
+		return nil, err
+	}
+
+	for _, target := range []struct {
+		parentClause string
+		dest         *[]models.SuggestedCategory
+	}{
+		{"parent_id IS NULL", &suggestions.Categories},
+		{"parent_id IS NOT NULL", &suggestions.Subcategories},
+	} {
+		if err := config.CmsGorm.WithContext(ctx).
+			Raw(`
+				SELECT id, name, parent_id, parent_name
+				FROM categories
+				WHERE status = 'Active' AND `+target.parentClause+`
+				  AND (lower(name) LIKE ? OR lower(name) LIKE ?)
+				ORDER BY lower(name) LIKE ? DESC, name ASC
+				LIMIT ?
+			`, startsWith, wordStartsWith, startsWith, suggestCategories).
+			Scan(target.dest).Error; err != nil {
+			return nil, err
+		}
+	}
+	return suggestions, nil
+}
+
+// RecordSearch counts a storefront search towards the popular searches, in the background.
+// Only count searches that found something, once each (not per page).
+func (s *SearchSuggestService) RecordSearch(query string) {
+	query = NormalizeSearchText(query)
+	if query == "" || config.RedisClient == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		pipe := config.RedisClient.Pipeline()
+		pipe.ZIncrBy(ctx, suggestQueriesKey, 1, query)
+		pipe.ZAdd(ctx, suggestQueryLexKey, redis.Z{Member: query})
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("[search.suggest] failed to record search %q: %v", query, err)
+		}
+	}()
+}
+
+// Refresh rebuilds the product and category index from the catalogue and prunes the
+// searches to the most made, returning how many products and categories were indexed
+func (s *SearchSuggestService) Refresh(ctx context.Context) (int, error) {
+	if config.RedisClient == nil {
+		return 0, errors.New("redis not connected")
+	}
+
+	// Step 1: Load the active catalogue
+	var items []suggestItem
+	var products []struct {
+		ID        uuid.UUID
+		Name      string
+		Thumbnail string
+		Views     int
+	}
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`
+			SELECT id, name, COALESCE(media->'primary'->>'url', '') AS thumbnail, views
+			FROM products
+			WHERE status = 'Active' AND deleted_at IS NULL
+		`).
+		Scan(&products).Error; err != nil {
+		return 0, err
+	}
+	for _, product := range products {
+		items = append(items, suggestItem{
+			Kind:      suggestKindProduct,
+			ID:        product.ID,
+			Name:      product.Name,
+			Thumbnail: product.Thumbnail,
+			Views:     product.Views,
+		})
+	}
+
+	var categories []models.Category
+	if err := config.CmsGorm.WithContext(ctx).
+		Select("id, name, parent_id, parent_name").
+		Where("status = ?", "Active").
+		Find(&categories).Error; err != nil {
+		return 0, err
+	}
+	for _, category := range categories {
+		item := suggestItem{
+			Kind: suggestKindCategory,
+			ID:   category.ID,
+			Name: category.Name,
+		}
+		if category.ParentID != nil {
+			item.Kind = suggestKindSubcategory
+			item.ParentID = category.ParentID
+			item.ParentName = category.ParentName
+		}
+		items = append(items, item)
+	}
+
+	// Step 2: Write the new index beside the live one, then swap it in
+	nextTermsKey := suggestTermsKey + ":next"
+	nextItemsKey := suggestItemsKey + ":next"
+	if err := config.RedisClient.Del(ctx, nextTermsKey, nextItemsKey).Err(); err != nil {
+		return 0, err
+	}
+
+	terms := make([]redis.Z, 0, suggestWriteBatch)
+	fields := make([]interface{}, 0, 2*suggestWriteBatch)
+	flush := func() error {
+		pipe := config.RedisClient.Pipeline()
+		if len(terms) > 0 {
+			pipe.ZAdd(ctx, nextTermsKey, terms...)
+		}
+		if len(fields) > 0 {
+			pipe.HSet(ctx, nextItemsKey, fields...)
+		}
+		_, err := pipe.Exec(ctx)
+		terms, fields = terms[:0], fields[:0]
+		return err
+	}
+	for _, item := range items {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return 0, err
+		}
+		fields = append(fields, item.key(), string(encoded))
+		for _, term := range suggestTerms(item.Name) {
+			terms = append(terms, redis.Z{Member: term + "\x00" + item.key()})
+		}
+		if len(terms) >= suggestWriteBatch {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+
+	if _, err := config.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(items) > 0 {
+			pipe.Rename(ctx, nextTermsKey, suggestTermsKey)
+			pipe.Rename(ctx, nextItemsKey, suggestItemsKey)
+		} else {
+			pipe.Del(ctx, suggestTermsKey, suggestItemsKey) // An empty catalogue suggests nothing
+		}
+		pipe.Set(ctx, suggestBuiltKey, time.Now().Unix(), 0)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	// Step 3: Keep the most-made searches, and only suggest those made often enough
+	if err := s.pruneSearches(ctx); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// IndexProducts brings the given products up to date in the index between refreshes:
+// active ones are (re)indexed under their current name, others dropped. Does nothing
+// until the index has been built.
+func (s *SearchSuggestService) IndexProducts(ctx context.Context, productIDs ...uuid.UUID) error {
+	if config.RedisClient == nil {
+		return errors.New("redis not connected")
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+	built, err := config.RedisClient.Exists(ctx, suggestBuiltKey).Result()
+	if err != nil || built == 0 {
+		return err
+	}
+
+	// Step 1: Load the ones still active
+	var products []struct {
+		ID        uuid.UUID
+		Name      string
+		Thumbnail string
+		Views     int
+	}
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`
+			SELECT id, name, COALESCE(media->'primary'->>'url', '') AS thumbnail, views
+			FROM products
+			WHERE id IN ? AND status = 'Active' AND deleted_at IS NULL
+		`, productIDs).
+		Scan(&products).Error; err != nil {
+		return err
+	}
+
+	// Step 2: Find the terms they're indexed under now
+	keys := make([]string, 0, len(productIDs))
+	for _, id := range productIDs {
+		keys = append(keys, suggestItem{Kind: suggestKindProduct, ID: id}.key())
+	}
+	indexed, err := config.RedisClient.HMGet(ctx, suggestItemsKey, keys...).Result()
+	if err != nil {
+		return err
+	}
+
+	// Step 3: Swap the old entries for the new
+	pipe := config.RedisClient.TxPipeline()
+	for i, value := range indexed {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var old suggestItem
+		if err := json.Unmarshal([]byte(raw), &old); err != nil {
+			continue
+		}
+		for _, term := range suggestTerms(old.Name) {
+			pipe.ZRem(ctx, suggestTermsKey, term+"\x00"+keys[i])
+		}
+		pipe.HDel(ctx, suggestItemsKey, keys[i])
+	}
+	for _, product := range products {
+		item := suggestItem{
+			Kind:      suggestKindProduct,
+			ID:        product.ID,
+			Name:      product.Name,
+			Thumbnail: product.Thumbnail,
+			Views:     product.Views,
+		}
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		pipe.HSet(ctx, suggestItemsKey, item.key(), string(encoded))
+		for _, term := range suggestTerms(item.Name) {
+			pipe.ZAdd(ctx, suggestTermsKey, redis.Z{Member: term + "\x00" + item.key()})
+		}
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// IndexProductsAsync runs IndexProducts in the background
+func (s *SearchSuggestService) IndexProductsAsync(productIDs ...uuid.UUID) {
+	if config.RedisClient == nil || len(productIDs) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.IndexProducts(ctx, productIDs...); err != nil {
+			log.Printf("[search.suggest] failed to index %d product(s): %v", len(productIDs), err)
+		}
+	}()
+}
+
+// pruneSearches drops all but the most-made searches and rebuilds the lexicographic set
+// of searches from those made often enough to be suggested
+func (s *SearchSuggestService) pruneSearches(ctx context.Context) error {
+	if err := config.RedisClient.ZRemRangeByRank(ctx, suggestQueriesKey, 0, -suggestKeptQueries-1).Err(); err != nil {
+		return err
+	}
+
+	popular, err := config.RedisClient.ZRangeByScore(ctx, suggestQueriesKey, &redis.ZRangeBy{
+		Min: strconv.Itoa(suggestMinSearches),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return err
+	}
+	if len(popular) == 0 {
+		return config.RedisClient.Del(ctx, suggestQueryLexKey).Err()
+	}
+
+	members := make([]redis.Z, 0, len(popular))
+	for _, query := range popular {
+		members = append(members, redis.Z{Member: query})
+	}
+	nextKey := suggestQueryLexKey + ":next"
+	_, err = config.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, nextKey)
+		pipe.ZAdd(ctx, nextKey, members...)
+		pipe.Rename(ctx, nextKey, suggestQueryLexKey)
+		return nil
+	})
+	return err
+}
+
+// StartScheduler rebuilds the suggestion index in the background. Products and categories
+// added, renamed or hidden in between show up (or go) at the next refresh, unless indexed
+// sooner with IndexProducts.
+//
+// SEARCH_SUGGEST_REFRESH_INTERVAL: how often to run, as a Go duration (default 10m)
+func (s *SearchSuggestService) StartScheduler() {
+	interval := 10 * time.Minute
+	if raw := os.Getenv("SEARCH_SUGGEST_REFRESH_INTERVAL"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			log.Printf("[search.suggest] invalid SEARCH_SUGGEST_REFRESH_INTERVAL %q, using %s", raw, interval)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.runScheduledRefresh()
+			<-ticker.C
+		}
+	}()
+}
+
+// runScheduledRefresh is a single scheduler tick
+func (s *SearchSuggestService) runScheduledRefresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	indexed, err := s.Refresh(ctx)
+	if err != nil {
+		log.Printf("[search.suggest] scheduled refresh failed: %v", err)
+		return
+	}
+	log.Printf("[search.suggest] indexed %d products and categories", indexed)
+}
+
+// NormalizeSearchText lowercases the text, collapses its whitespace and cuts it to length:
+// the form searches are counted, indexed and matched against search rules in
+func NormalizeSearchText(text string) string {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	if runes := []rune(text); len(runes) > searchMaxTextLen {
+		text = strings.TrimSpace(string(runes[:searchMaxTextLen]))
+	}
+	return text
+}
+
+// suggestTerms are the index terms of a name: the name from each word on
+// ("summer linen dress", "linen dress", "dress")
+func suggestTerms(name string) []string {
+	words := strings.Fields(NormalizeSearchText(name))
+	terms := make([]string, 0, len(words))
+	for i := range words {
+		term := strings.Join(words[i:], " ")
+		if runes := []rune(term); len(runes) > suggestMaxTermLen {
+			term = string(runes[:suggestMaxTermLen])
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// suggestLexRange selects the members of a lexicographic set that start with the prefix
+func suggestLexRange(prefix string) *redis.ZRangeBy {
+	return &redis.ZRangeBy{
+		Min:   "[" + prefix,
+		Max:   "[" + prefix + "\xff",
+		Count: suggestScanLimit,
+	}
+}
+
+// emptySuggestions has empty (not nil) lists so they're [] in JSON
+func emptySuggestions() *models.SearchSuggestions {
+	return &models.SearchSuggestions{
+		Products:      make([]models.SuggestedProduct, 0),
+		Categories:    make([]models.SuggestedCategory, 0),
+		Subcategories: make([]models.SuggestedCategory, 0),
+		Queries:       make([]string, 0),
+	}
+}
+
+// Global instance
+var searchSuggestService *SearchSuggestService
+
+// GetSearchSuggestService returns the global search suggestion service instance
+func GetSearchSuggestService() *SearchSuggestService {
+	if searchSuggestService == nil {
+		searchSuggestService = NewSearchSuggestService()
+	}
+	return searchSuggestService
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// lowStockCombosSQL lists every inventory combo with its effective low-stock threshold
+// (product → subcategory → parent category → default). %s is an optional product filter.
+// Trashed products have no combos, so their alerts resolve.
+const lowStockCombosSQL = `
+	SELECT
+		p.id AS product_id,
+		inv->>'sku' AS sku,
+		inv->>'variant_name' AS variant_name,
+		COALESCE((inv->>'quantity')::int, 0) AS quantity,
+		COALESCE(p.low_stock_threshold, sc.low_stock_threshold, pc.low_stock_threshold, ?) AS threshold
+	FROM products p
+	LEFT JOIN categories sc ON sc.id = p.sub_category_id
+	LEFT JOIN categories pc ON pc.id = sc.parent_id
+	CROSS JOIN LATERAL jsonb_array_elements(p.inventory) AS inv
+	WHERE p.deleted_at IS NULL AND COALESCE(inv->>'sku', '') <> '' %s
+`
+
+// StockAlertService raises and resolves low-stock alerts and sends the admin digest
+type StockAlertService struct{}
+
+// NewStockAlertService creates a new stock alert service
+func NewStockAlertService() *StockAlertService {
+	return &StockAlertService{}
+}
+
+// CheckProducts compares every combo of the given products (all products when none are
+// given) against its threshold: combos below it get an open alert (or have their live
+// alert refreshed), live alerts for combos that recovered or no longer exist are resolved.
+func (s *StockAlertService) CheckProducts(ctx context.Context, productIDs ...uuid.UUID) error {
+	filter := ""
+	args := []interface{}{models.DefaultLowStockThreshold}
+	alertFilter := ""
+	if len(productIDs) > 0 {
+		filter = "AND p.id IN ?"
+		alertFilter = "AND a.product_id IN ?"
+		args = append(args, productIDs)
+	}
+	combos := fmt.Sprintf(lowStockCombosSQL, filter)
+
+	return config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Step 1: Raise or refresh alerts for combos below their threshold
+		if err := tx.Exec(`
+			WITH combos AS (`+combos+`)
+			INSERT INTO stock_alerts (id, product_id, sku, variant_name, quantity, threshold)
+			SELECT gen_random_uuid(), product_id, sku, variant_name, quantity, threshold
+			FROM combos
+			WHERE quantity < threshold
+			ON CONFLICT (product_id, sku) WHERE status IN ('open', 'dismissed')
+			DO UPDATE SET
+				variant_name = EXCLUDED.variant_name,
+				quantity = EXCLUDED.quantity,
+				threshold = EXCLUDED.threshold
+			WHERE stock_alerts.quantity <> EXCLUDED.quantity
+				OR stock_alerts.threshold <> EXCLUDED.threshold
+				OR stock_alerts.variant_name <> EXCLUDED.variant_name
+		`, args...).Error; err != nil {
+			return err
+		}
+
+		// Step 2: Resolve live alerts whose combo is no longer low
+		resolveArgs := append([]interface{}{}, args...)
+		if len(productIDs) > 0 {
+			resolveArgs = append(resolveArgs, productIDs)
+		}
+		return tx.Exec(`
+			WITH combos AS (`+combos+`)
+			UPDATE stock_alerts a
+			SET status = 'resolved', resolved_at = NOW()
+			WHERE a.status IN ('open', 'dismissed') `+alertFilter+`
+				AND NOT EXISTS (
+					SELECT 1 FROM combos c
+					WHERE c.product_id = a.product_id
+						AND c.sku = a.sku
+						AND c.quantity < c.threshold
+				)
+		`, resolveArgs...).Error
+	})
+}
+
+// CheckProductsAsync runs CheckProducts in the background so request handlers
+// never wait on (or fail because of) alerting
+func (s *StockAlertService) CheckProductsAsync(productIDs ...uuid.UUID) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.CheckProducts(ctx, productIDs...); err != nil {
+			log.Printf("[stock.alerts] low-stock check failed: %v", err)
+		}
+	}()
+}
+
+// SendDigest emails every open alert that hasn't been emailed yet and marks them as sent.
+// Returns the number of alerts included.
+func (s *StockAlertService) SendDigest(ctx context.Context, recipients []string) (int, error) {
+	var alerts []models.StockAlertRow
+	if err := config.CmsGorm.WithContext(ctx).
+		Table("stock_alerts a").
+		Select("a.*, p.name AS product_name").
+		Joins("JOIN products p ON p.id = a.product_id AND p.deleted_at IS NULL").
+		Where("a.status = ? AND a.emailed_at IS NULL", models.StockAlertOpen).
+		Order("a.quantity ASC, p.name ASC").
+		Scan(&alerts).Error; err != nil {
+		return 0, err
+	}
+	if len(alerts) == 0 {
+		return 0, nil
+	}
+
+	items := make([]LowStockDigestItem, len(alerts))
+	ids := make([]uuid.UUID, len(alerts))
+	for i, alert := range alerts {
+		items[i] = LowStockDigestItem{
+			ProductName: alert.ProductName,
+			VariantName: alert.VariantName,
+			SKU:         alert.SKU,
+			Quantity:    alert.Quantity,
+			Threshold:   alert.Threshold,
+		}
+		ids[i] = alert.ID
+	}
+
+	if err := NewResendClient().SendLowStockDigestEmail(recipients, items); err != nil {
+		return 0, err
+	}
+
+	if err := config.CmsGorm.WithContext(ctx).
+		Model(&models.StockAlert{}).
+		Where("id IN ?", ids).
+		Update("emailed_at", time.Now()).Error; err != nil {
+		return 0, err
+	}
+	return len(alerts), nil
+}
+
+// StartScheduler periodically re-checks all products (catching changes made outside
+// the API, e.g. threshold edits on categories) and sends the email digest.
+//
+// STOCK_ALERT_EMAILS: comma-separated digest recipients (no digest when empty)
+// STOCK_ALERT_INTERVAL: how often to run, as a Go duration (default 1h)
+func (s *StockAlertService) StartScheduler() {
+	interval := time.Hour
+	if raw := os.Getenv("STOCK_ALERT_INTERVAL"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			log.Printf("[stock.alerts] invalid STOCK_ALERT_INTERVAL %q, using %s", raw, interval)
+		}
+	}
+
+	var recipients []string
+	for _, email := range strings.Split(os.Getenv("STOCK_ALERT_EMAILS"), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			recipients = append(recipients, email)
+		}
+	}
+	if len(recipients) > 0 && os.Getenv("RESEND_API_KEY") == "" {
+		log.Println("[stock.alerts] RESEND_API_KEY not set, low-stock digest disabled")
+		recipients = nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.runScheduledCheck(recipients)
+			<-ticker.C
+		}
+	}()
+}
+
+// runScheduledCheck is a single scheduler tick
+func (s *StockAlertService) runScheduledCheck(recipients []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if err := s.CheckProducts(ctx); err != nil {
+		log.Printf("[stock.alerts] scheduled low-stock check failed: %v", err)
+		return
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	sent, err := s.SendDigest(ctx, recipients)
+	if err != nil {
+		log.Printf("[stock.alerts] failed to send low-stock digest: %v", err)
+		return
+	}
+	if sent > 0 {
+		log.Printf("[stock.alerts] low-stock digest sent with %d alert(s)", sent)
+	}
+}
+
+// Global instance
+var stockAlertService *StockAlertService
+
+// GetStockAlertService returns the global stock alert service instance
+func GetStockAlertService() *StockAlertService {
+	if stockAlertService == nil {
+		stockAlertService = NewStockAlertService()
+	}
+	return stockAlertService
+}
@@ -0,0 +1,718 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/config"
+	"github.com/Modeva-Ecommerce/modeva-cms-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrStockComboNotFound is returned when a stock change targets a combo the product doesn't have
+var ErrStockComboNotFound = errors.New("inventory combination not found")
+
+// ErrStockLocationNotFound is returned when a stock change targets a missing or inactive location
+var ErrStockLocationNotFound = errors.New("stock location not found or inactive")
+
+// ErrNoDefaultLocation is returned when stock is added without a location and no default is configured
+var ErrNoDefaultLocation = errors.New("no active default stock location configured")
+
+// errNotEnoughStock is used internally by splitDelta; callers get an InsufficientStockError
+var errNotEnoughStock = errors.New("not enough stock")
+
+// InsufficientStockError is returned when a change would take a combo below zero
+type InsufficientStockError struct {
+	ProductName string
+	VariantName string
+	Available   int
+	Requested   int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for %s (%s): %d available, %d requested",
+		e.ProductName, e.VariantName, e.Available, e.Requested)
+}
+
+// StockChange is a quantity change for one inventory combo.
+// The combo is matched by SKU first, then by variant name.
+type StockChange struct {
+	SKU         string
+	VariantName string
+	LocationID  *uuid.UUID // nil: added to the default location, removed by allocation priority
+	Delta       int
+}
+
+// StockMovementMeta describes why stock moved; it's copied onto every movement written
+type StockMovementMeta struct {
+	Reason        string
+	ReferenceType *string
+	ReferenceID   *string
+	Note          *string
+	AdminID       *uuid.UUID
+	AdminEmail    *string
+}
+
+// OrderStockLine is a single order line that moves stock
+type OrderStockLine struct {
+	ProductID  uuid.UUID
+	SKU        string
+	LocationID *uuid.UUID
+	Quantity   int
+}
+
+// locationQuantity is one location's quantity of a combo
+type locationQuantity struct {
+	LocationID uuid.UUID
+	SKU        string
+	Quantity   int
+}
+
+// locationDelta is the part of a combo's change that lands in one location
+type locationDelta struct {
+	LocationID uuid.UUID
+	Delta      int
+}
+
+// StockService keeps products.inventory, location_stock and the stock_movements ledger in sync
+type StockService struct{}
+
+// NewStockService creates a new stock service
+func NewStockService() *StockService {
+	return &StockService{}
+}
+
+// ApplyChanges locks the product row, applies the deltas to its locations and inventory
+// JSONB and appends one movement per location touched. Must be called inside a CMS transaction.
+func (s *StockService) ApplyChanges(
+	tx *gorm.DB,
+	productID uuid.UUID,
+	changes []StockChange,
+	meta StockMovementMeta,
+) ([]models.StockMovement, error) {
+	product, stock, err := s.lockProductStock(tx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	movements := make([]models.StockMovement, 0, len(changes))
+	for _, change := range changes {
+		idx := findCombo(product.Inventory, change.SKU, change.VariantName)
+		if idx < 0 || product.Inventory[idx].SKU == "" {
+			label := change.SKU
+			if label == "" {
+				label = change.VariantName
+			}
+			return nil, fmt.Errorf("%w: %s", ErrStockComboNotFound, label)
+		}
+		item := &product.Inventory[idx]
+		key := strings.ToLower(item.SKU)
+
+		parts, available, err := s.splitDelta(tx, stock[key], change.LocationID, change.Delta)
+		if errors.Is(err, errNotEnoughStock) {
+			return nil, &InsufficientStockError{
+				ProductName: product.Name,
+				VariantName: item.VariantName,
+				Available:   available,
+				Requested:   -change.Delta,
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, part := range parts {
+			locationAfter, err := s.applyLocationDelta(tx, productID, item.SKU, stock, part)
+			if err != nil {
+				return nil, err
+			}
+			item.Quantity += part.Delta
+			movements = append(movements, newMovement(productID, *item, part, item.Quantity, locationAfter, meta))
+		}
+	}
+
+	if len(movements) == 0 {
+		return movements, nil
+	}
+
+	if err := tx.Model(&models.Product{}).
+		Where("id = ?", productID).
+		Update("inventory", product.Inventory).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(&movements).Error; err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// Transfer moves stock of one combo between two locations. The product's total is unchanged.
+// Must be called inside a CMS transaction.
+func (s *StockService) Transfer(
+	tx *gorm.DB,
+	req models.StockTransferRequest,
+	meta StockMovementMeta,
+) ([]models.StockMovement, error) {
+	if req.FromLocationID == req.ToLocationID {
+		return nil, errors.New("source and destination locations must be different")
+	}
+
+	product, stock, err := s.lockProductStock(tx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := product.Inventory.IndexOfSKU(req.SKU)
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrStockComboNotFound, req.SKU)
+	}
+	item := product.Inventory[idx]
+	key := strings.ToLower(item.SKU)
+
+	if err := s.requireActiveLocation(tx, req.ToLocationID); err != nil {
+		return nil, err
+	}
+
+	out, available, err := s.splitDelta(tx, stock[key], &req.FromLocationID, -req.Quantity)
+	if errors.Is(err, errNotEnoughStock) {
+		return nil, &InsufficientStockError{
+			ProductName: product.Name,
+			VariantName: item.VariantName,
+			Available:   available,
+			Requested:   req.Quantity,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parts := append(out, locationDelta{LocationID: req.ToLocationID, Delta: req.Quantity})
+	movements := make([]models.StockMovement, 0, len(parts))
+	for _, part := range parts {
+		locationAfter, err := s.applyLocationDelta(tx, req.ProductID, item.SKU, stock, part)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, newMovement(req.ProductID, item, part, item.Quantity, locationAfter, meta))
+	}
+
+	if err := tx.Create(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// RecordInventoryDiff brings location_stock in line with an inventory JSONB that was written
+// directly (product create/update) and appends movements for every difference, so the ledger
+// still explains every change. Increases go to the default location, decreases follow the
+// allocation priority, and renamed SKUs keep their per-location stock.
+func (s *StockService) RecordInventoryDiff(
+	tx *gorm.DB,
+	productID uuid.UUID,
+	before, after models.InventoryList,
+	meta StockMovementMeta,
+) error {
+	stock, err := s.lockLocationStock(tx, productID)
+	if err != nil {
+		return err
+	}
+
+	movements := make([]models.StockMovement, 0)
+	matched := make(map[int]bool, len(before))
+
+	for _, item := range after {
+		if item.SKU == "" {
+			continue
+		}
+		key := strings.ToLower(item.SKU)
+
+		if idx := findCombo(before, item.SKU, item.VariantName); idx >= 0 {
+			matched[idx] = true
+
+			// SKU renamed: move the per-location rows along with it
+			if oldSKU := before[idx].SKU; oldSKU != "" && !strings.EqualFold(oldSKU, item.SKU) {
+				if err := tx.Exec(`
+					UPDATE location_stock SET sku = ?, updated_at = NOW()
+					WHERE product_id = ? AND LOWER(sku) = LOWER(?)
+				`, item.SKU, productID, oldSKU).Error; err != nil {
+					return err
+				}
+				oldKey := strings.ToLower(oldSKU)
+				for _, lq := range stock[oldKey] {
+					lq.SKU = item.SKU
+				}
+				stock[key] = stock[oldKey]
+				delete(stock, oldKey)
+			}
+		}
+
+		// Diff against what the locations actually hold, so drift is corrected too
+		onHand := 0
+		for _, lq := range stock[key] {
+			onHand += lq.Quantity
+		}
+		delta := item.Quantity - onHand
+		if delta == 0 {
+			continue
+		}
+
+		parts, _, err := s.splitDelta(tx, stock[key], nil, delta)
+		if err != nil {
+			return err
+		}
+		total := onHand
+		for _, part := range parts {
+			locationAfter, err := s.applyLocationDelta(tx, productID, item.SKU, stock, part)
+			if err != nil {
+				return err
+			}
+			total += part.Delta
+			movements = append(movements, newMovement(productID, item, part, total, locationAfter, meta))
+		}
+	}
+
+	// Combos that were removed take their remaining stock with them
+	for idx, item := range before {
+		if matched[idx] || item.SKU == "" {
+			continue
+		}
+		key := strings.ToLower(item.SKU)
+
+		total := 0
+		for _, lq := range stock[key] {
+			total += lq.Quantity
+		}
+		for _, lq := range stock[key] {
+			if lq.Quantity == 0 {
+				continue
+			}
+			total -= lq.Quantity
+			zero := 0
+			part := locationDelta{LocationID: lq.LocationID, Delta: -lq.Quantity}
+			movements = append(movements, newMovement(productID, item, part, total, &zero, meta))
+		}
+
+		if err := tx.Exec(`
+			DELETE FROM location_stock WHERE product_id = ? AND LOWER(sku) = ?
+		`, productID, key).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(movements) == 0 {
+		return nil
+	}
+	return tx.Create(&movements).Error
+}
+
+// DeductOrderStock takes the ordered quantities out of stock (reason "sale"), allocating
+// each line across locations by priority. All products are updated in one CMS transaction,
+// so either every line is deducted or none is.
+func (s *StockService) DeductOrderStock(ctx context.Context, orderID uuid.UUID, lines []OrderStockLine) error {
+	_, err := s.applyOrderStock(ctx, orderID, lines, -1, StockMovementMeta{Reason: models.StockReasonSale}, false)
+	return err
+}
+
+// DeductBackorderStock takes an order's backordered units out of stock as it ships (reason
+// "sale"). It's idempotent per order: once the units were taken, later calls are skipped.
+// Returns whether stock moved.
+func (s *StockService) DeductBackorderStock(ctx context.Context, orderID uuid.UUID, lines []OrderStockLine) (bool, error) {
+	note := backorderFulfilmentNote
+	return s.applyOrderStock(ctx, orderID, lines, -1, StockMovementMeta{Reason: models.StockReasonSale, Note: &note}, true)
+}
+
+// RestockCancelledOrder puts a cancelled order's items back into the locations they were
+// taken from (reason "cancellation"). It's idempotent, concurrent calls included: an order
+// that was already restocked (or released) is skipped. Returns whether stock moved.
+func (s *StockService) RestockCancelledOrder(
+	ctx context.Context,
+	orderID uuid.UUID,
+	adminID *uuid.UUID,
+	adminEmail *string,
+) (bool, error) {
+	return s.returnOrderStock(ctx, orderID, StockMovementMeta{
+		Reason:     models.StockReasonCancellation,
+		AdminID:    adminID,
+		AdminEmail: adminEmail,
+	})
+}
+
+// ReleaseOrderStock returns the stock taken for an order that was never placed, e.g. when
+// its checkout failed to commit after the stock was deducted (reason "cancellation", like
+// a restock, so the order's stock comes back once)
+func (s *StockService) ReleaseOrderStock(ctx context.Context, orderID uuid.UUID) error {
+	_, err := s.returnOrderStock(ctx, orderID, StockMovementMeta{Reason: models.StockReasonCancellation})
+	return err
+}
+
+// returnOrderStock reverses an order's sale movements; stock from since-deactivated
+// locations goes to the default. Returns whether stock moved.
+func (s *StockService) returnOrderStock(ctx context.Context, orderID uuid.UUID, meta StockMovementMeta) (bool, error) {
+	var lines []OrderStockLine
+	if err := config.CmsGorm.WithContext(ctx).
+		Raw(`
+			SELECT
+				sm.product_id,
+				sm.sku,
+				CASE WHEN l.is_active THEN sm.location_id END AS location_id,
+				-SUM(sm.delta) AS quantity
+			FROM stock_movements sm
+			LEFT JOIN stock_locations l ON l.id = sm.location_id
+			WHERE sm.reference_type = ? AND sm.reference_id = ? AND sm.reason = ?
+			  AND sm.sku IS NOT NULL
+			GROUP BY sm.product_id, sm.sku, 3
+			HAVING SUM(sm.delta) < 0
+		`, models.StockReferenceOrder, orderID.String(), models.StockReasonSale).
+		Scan(&lines).Error; err != nil {
+		return false, err
+	}
+	if len(lines) == 0 {
+		return false, nil
+	}
+
+	return s.applyOrderStock(ctx, orderID, lines, 1, meta, true)
+}
+
+// backorderFulfilmentNote tells the sale movements of an order's backordered units from
+// its checkout ones
+const backorderFulfilmentNote = "Backorder fulfilment"
+
+// errOrderStockMoved aborts an order stock move that already happened
+var errOrderStockMoved = errors.New("order stock already moved")
+
+// applyOrderStock moves stock for every order line in sign direction (-1 out, +1 in).
+// With once, the move is skipped when the order already has movements with its reason and
+// note; the check holds an advisory lock on them, so concurrent moves can't both pass it.
+// Returns whether stock moved.
+func (s *StockService) applyOrderStock(
+	ctx context.Context,
+	orderID uuid.UUID,
+	lines []OrderStockLine,
+	sign int,
+	meta StockMovementMeta,
+	once bool,
+) (bool, error) {
+	// Group lines per product; lock products in a stable order to avoid deadlocks
+	byProduct := make(map[uuid.UUID][]StockChange)
+	productIDs := make([]uuid.UUID, 0)
+	for _, line := range lines {
+		if line.SKU == "" || line.Quantity <= 0 {
+			continue
+		}
+		if _, exists := byProduct[line.ProductID]; !exists {
+			productIDs = append(productIDs, line.ProductID)
+		}
+		byProduct[line.ProductID] = append(byProduct[line.ProductID], StockChange{
+			SKU:        line.SKU,
+			LocationID: line.LocationID,
+			Delta:      sign * line.Quantity,
+		})
+	}
+	if len(productIDs) == 0 {
+		return false, nil
+	}
+	sort.Slice(productIDs, func(i, j int) bool {
+		return productIDs[i].String() < productIDs[j].String()
+	})
+
+	referenceType := models.StockReferenceOrder
+	referenceID := orderID.String()
+	meta.ReferenceType = &referenceType
+	meta.ReferenceID = &referenceID
+
+	err := config.CmsGorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if once {
+			note := ""
+			if meta.Note != nil {
+				note = *meta.Note
+			}
+			if err := tx.Exec(
+				"SELECT pg_advisory_xact_lock(hashtextextended(?, 0))",
+				"order-stock:"+referenceID+":"+meta.Reason+":"+note,
+			).Error; err != nil {
+				return err
+			}
+
+			var existing int64
+			if err := tx.Model(&models.StockMovement{}).
+				Where("reference_type = ? AND reference_id = ? AND reason = ? AND note IS NOT DISTINCT FROM ?",
+					referenceType, referenceID, meta.Reason, meta.Note).
+				Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing > 0 {
+				return errOrderStockMoved
+			}
+		}
+
+		for _, productID := range productIDs {
+			if _, err := s.ApplyChanges(tx, productID, byProduct[productID], meta); err != nil {
+				// Returned items for purged products or removed combos can't be restocked
+				if sign > 0 && (errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, ErrStockComboNotFound)) {
+					log.Printf("[stock] skipping restock for product %s on order %s: %v", productID, orderID, err)
+					continue
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errOrderStockMoved) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Checkouts and cancellations can move combos across their low-stock threshold
+	GetStockAlertService().CheckProductsAsync(productIDs...)
+	if sign > 0 {
+		GetBackInStockService().NotifyRestockedAsync(productIDs...)
+	}
+	return true, nil
+}
+
+// ════════════════════════════════════════════════════════════
+// Location Helpers
+// ════════════════════════════════════════════════════════════
+
+// lockProductStock locks the product row and its per-location stock. Trashed products are
+// included, so stock returned for them (e.g. from cancelled orders) isn't lost; only purged
+// products are not found.
+func (s *StockService) lockProductStock(tx *gorm.DB, productID uuid.UUID) (*models.Product, map[string][]*locationQuantity, error) {
+	var product models.Product
+	if err := tx.
+		Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, name, inventory").
+		First(&product, "id = ?", productID).Error; err != nil {
+		return nil, nil, err
+	}
+
+	stock, err := s.lockLocationStock(tx, productID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &product, stock, nil
+}
+
+// lockLocationStock locks a product's location_stock rows at active locations and returns
+// them keyed by lower-cased SKU, in allocation order (priority, then default location first)
+func (s *StockService) lockLocationStock(tx *gorm.DB, productID uuid.UUID) (map[string][]*locationQuantity, error) {
+	var rows []locationQuantity
+	if err := tx.Raw(`
+		SELECT ls.location_id, ls.sku, ls.quantity
+		FROM location_stock ls
+		JOIN stock_locations l ON l.id = ls.location_id
+		WHERE ls.product_id = ? AND l.is_active
+		ORDER BY l.priority ASC, l.is_default DESC, l.name ASC
+		FOR UPDATE OF ls
+	`, productID).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	stock := make(map[string][]*locationQuantity)
+	for i := range rows {
+		key := strings.ToLower(rows[i].SKU)
+		stock[key] = append(stock[key], &rows[i])
+	}
+	return stock, nil
+}
+
+// splitDelta works out which locations a combo's delta lands in.
+// On errNotEnoughStock the second return value is the quantity that was available.
+func (s *StockService) splitDelta(
+	tx *gorm.DB,
+	stock []*locationQuantity,
+	locationID *uuid.UUID,
+	delta int,
+) ([]locationDelta, int, error) {
+	// Explicit location
+	if locationID != nil {
+		current := -1
+		for _, lq := range stock {
+			if lq.LocationID == *locationID {
+				current = lq.Quantity
+				break
+			}
+		}
+		if current < 0 {
+			if err := s.requireActiveLocation(tx, *locationID); err != nil {
+				return nil, 0, err
+			}
+			current = 0
+		}
+		if current+delta < 0 {
+			return nil, current, errNotEnoughStock
+		}
+		return []locationDelta{{LocationID: *locationID, Delta: delta}}, current, nil
+	}
+
+	// Stock in: default location (locked so it isn't deactivated meanwhile)
+	if delta > 0 {
+		var defaultID uuid.UUID
+		if err := tx.Raw(`
+			SELECT id FROM stock_locations WHERE is_default AND is_active LIMIT 1 FOR SHARE
+		`).Scan(&defaultID).Error; err != nil {
+			return nil, 0, err
+		}
+		if defaultID == uuid.Nil {
+			return nil, 0, ErrNoDefaultLocation
+		}
+		return []locationDelta{{LocationID: defaultID, Delta: delta}}, 0, nil
+	}
+
+	return splitStockOut(stock, delta)
+}
+
+// splitStockOut takes a stock-out delta from the first location that can cover it alone,
+// otherwise from each in priority order. On errNotEnoughStock the second return value is
+// the quantity that was available.
+func splitStockOut(stock []*locationQuantity, delta int) ([]locationDelta, int, error) {
+	need := -delta
+	for _, lq := range stock {
+		if lq.Quantity >= need {
+			return []locationDelta{{LocationID: lq.LocationID, Delta: delta}}, lq.Quantity, nil
+		}
+	}
+
+	parts := make([]locationDelta, 0)
+	remaining := need
+	for _, lq := range stock {
+		if remaining == 0 {
+			break
+		}
+		take := lq.Quantity
+		if take > remaining {
+			take = remaining
+		}
+		if take > 0 {
+			parts = append(parts, locationDelta{LocationID: lq.LocationID, Delta: -take})
+			remaining -= take
+		}
+	}
+	if remaining > 0 {
+		return nil, need - remaining, errNotEnoughStock
+	}
+	return parts, need, nil
+}
+
+// applyLocationDelta writes one location's new quantity and returns it
+func (s *StockService) applyLocationDelta(
+	tx *gorm.DB,
+	productID uuid.UUID,
+	sku string,
+	stock map[string][]*locationQuantity,
+	part locationDelta,
+) (*int, error) {
+	key := strings.ToLower(sku)
+
+	var entry *locationQuantity
+	for _, lq := range stock[key] {
+		if lq.LocationID == part.LocationID {
+			entry = lq
+			break
+		}
+	}
+	if entry == nil {
+		entry = &locationQuantity{LocationID: part.LocationID, SKU: sku}
+		stock[key] = append(stock[key], entry)
+	}
+	entry.Quantity += part.Delta
+
+	if err := tx.Exec(`
+		INSERT INTO location_stock (location_id, product_id, sku, quantity, updated_at)
+		VALUES (?, ?, ?, ?, NOW())
+		ON CONFLICT (location_id, product_id, sku)
+		DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = NOW()
+	`, part.LocationID, productID, entry.SKU, entry.Quantity).Error; err != nil {
+		return nil, err
+	}
+
+	quantity := entry.Quantity
+	return &quantity, nil
+}
+
+// requireActiveLocation returns ErrStockLocationNotFound unless the location exists and is
+// active, and keeps it from being deactivated until the transaction ends
+func (s *StockService) requireActiveLocation(tx *gorm.DB, locationID uuid.UUID) error {
+	var found uuid.UUID
+	if err := tx.Raw(`
+		SELECT id FROM stock_locations WHERE id = ? AND is_active FOR SHARE
+	`, locationID).Scan(&found).Error; err != nil {
+		return err
+	}
+	if found == uuid.Nil {
+		return ErrStockLocationNotFound
+	}
+	return nil
+}
+
+// findCombo returns the index of the combo matching sku (preferred) or variant name, or -1
+func findCombo(inventory models.InventoryList, sku, variantName string) int {
+	if idx := inventory.IndexOfSKU(sku); idx >= 0 {
+		return idx
+	}
+	if variantName == "" {
+		return -1
+	}
+	for idx, item := range inventory {
+		if strings.EqualFold(item.VariantName, variantName) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// newMovement builds a ledger entry for the part of a change that landed in one location
+func newMovement(
+	productID uuid.UUID,
+	item models.InventoryField,
+	part locationDelta,
+	quantityAfter int,
+	locationQuantityAfter *int,
+	meta StockMovementMeta,
+) models.StockMovement {
+	var sku *string
+	if item.SKU != "" {
+		value := item.SKU
+		sku = &value
+	}
+	locationID := part.LocationID
+
+	return models.StockMovement{
+		ProductID:             productID,
+		SKU:                   sku,
+		VariantName:           item.VariantName,
+		Combo:                 models.TagsList(item.Combo),
+		Delta:                 part.Delta,
+		QuantityAfter:         quantityAfter,
+		Reason:                meta.Reason,
+		LocationID:            &locationID,
+		LocationQuantityAfter: locationQuantityAfter,
+		ReferenceType:         meta.ReferenceType,
+		ReferenceID:           meta.ReferenceID,
+		Note:                  meta.Note,
+		AdminID:               meta.AdminID,
+		AdminEmail:            meta.AdminEmail,
+	}
+}
+
+// Global instance
+var stockService *StockService
+
+// GetStockService returns the global stock service instance
+func GetStockService() *StockService {
+	if stockService == nil {
+		stockService = NewStockService()
+	}
+	return stockService
+}
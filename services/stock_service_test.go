@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSplitStockOut(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	stock := func(quantities ...int) []*locationQuantity {
+		ids := []uuid.UUID{a, b, c}
+		out := make([]*locationQuantity, 0, len(quantities))
+		for i, quantity := range quantities {
+			out = append(out, &locationQuantity{LocationID: ids[i], Quantity: quantity})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		stock     []*locationQuantity
+		delta     int
+		want      []locationDelta
+		available int
+		err       error
+	}{
+		{
+			name:      "first location covers it",
+			stock:     stock(5, 10),
+			delta:     -3,
+			want:      []locationDelta{{LocationID: a, Delta: -3}},
+			available: 5,
+		},
+		{
+			name:      "exactly empties a location",
+			stock:     stock(3, 10),
+			delta:     -3,
+			want:      []locationDelta{{LocationID: a, Delta: -3}},
+			available: 3,
+		},
+		{
+			name:      "later location covers it alone before splitting",
+			stock:     stock(2, 10),
+			delta:     -4,
+			want:      []locationDelta{{LocationID: b, Delta: -4}},
+			available: 10,
+		},
+		{
+			name:      "split in priority order",
+			stock:     stock(2, 3, 4),
+			delta:     -6,
+			want:      []locationDelta{{LocationID: a, Delta: -2}, {LocationID: b, Delta: -3}, {LocationID: c, Delta: -1}},
+			available: 6,
+		},
+		{
+			name:      "empty and negative locations are skipped",
+			stock:     stock(0, -2, 4),
+			delta:     -4,
+			want:      []locationDelta{{LocationID: c, Delta: -4}},
+			available: 4,
+		},
+		{
+			name:      "not enough stock",
+			stock:     stock(2, 3),
+			delta:     -6,
+			available: 5,
+			err:       errNotEnoughStock,
+		},
+		{
+			name:  "no locations",
+			delta: -1,
+			err:   errNotEnoughStock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, available, err := splitStockOut(tt.stock, tt.delta)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if available != tt.available {
+				t.Errorf("available = %d, want %d", available, tt.available)
+			}
+			if tt.err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parts = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
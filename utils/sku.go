@@ -0,0 +1,115 @@
+// ════════════════════════════════════════════════════════════
+// Path: utils/sku.go
+// SKU generation and barcode (GTIN/EAN) validation helpers
+// ════════════════════════════════════════════════════════════
+
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultSKUPattern is used for combos without a SKU when the product has no pattern of its own
+const DefaultSKUPattern = "{product-slug}-{combo}"
+
+// MaxSKULength matches the product_skus.sku column size, in characters
+const MaxSKULength = 100
+
+// Slugify lowercases a string and collapses every run of non-alphanumeric characters into "-"
+func Slugify(s string) string {
+	var b strings.Builder
+	lastDash := true // avoid a leading dash
+
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			lastDash = false
+			continue
+		}
+		if !lastDash {
+			b.WriteRune('-')
+			lastDash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// GenerateSKU expands a SKU pattern for a single inventory combo.
+//
+// Supported tokens:
+//   - {product-slug}  slug of the product name
+//   - {combo}         slug of every combo value joined by "-"
+//   - {<type>}        slug of the combo value for that variant type, e.g. {size} or {color}
+//
+// variantTypes must be in the same order as the values in combo.
+func GenerateSKU(pattern, productName string, variantTypes []string, combo []string) string {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = DefaultSKUPattern
+	}
+
+	comboSlugs := make([]string, 0, len(combo))
+	for _, value := range combo {
+		if slug := Slugify(value); slug != "" {
+			comboSlugs = append(comboSlugs, slug)
+		}
+	}
+
+	replacements := []string{
+		"{product-slug}", Slugify(productName),
+		"{combo}", strings.Join(comboSlugs, "-"),
+	}
+	for i, variantType := range variantTypes {
+		value := ""
+		if i < len(combo) {
+			value = Slugify(combo[i])
+		}
+		replacements = append(replacements, "{"+Slugify(variantType)+"}", value)
+	}
+
+	sku := strings.NewReplacer(replacements...).Replace(strings.ToLower(pattern))
+
+	// Tidy up separators left behind by empty tokens
+	for strings.Contains(sku, "--") {
+		sku = strings.ReplaceAll(sku, "--", "-")
+	}
+	sku = strings.Trim(sku, "-")
+
+	// The column counts characters; cut on them so a letter isn't split
+	if utf8.RuneCountInString(sku) > MaxSKULength {
+		sku = strings.TrimRight(string([]rune(sku)[:MaxSKULength]), "-")
+	}
+	return sku
+}
+
+// IsValidGTIN reports whether code is a well-formed GTIN-8, GTIN-12 (UPC-A),
+// GTIN-13 (EAN-13) or GTIN-14 with a correct check digit
+func IsValidGTIN(code string) bool {
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		ch := code[i]
+		if ch < '0' || ch > '9' {
+			return false
+		}
+		digit := int(ch - '0')
+		// Weights alternate 3,1,3,1... starting from the digit next to the check digit
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	check := code[len(code)-1]
+	if check < '0' || check > '9' {
+		return false
+	}
+	return (10-sum%10)%10 == int(check-'0')
+}
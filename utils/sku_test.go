@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Linen Shirt", "linen-shirt"},
+		{"  --Summer  Dress!! ", "summer-dress"},
+		{"Größe XL", "größe-xl"},
+		{"100% Cotton", "100-cotton"},
+		{"", ""},
+		{"!!!", ""},
+	}
+	for _, tt := range tests {
+		if got := Slugify(tt.in); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateSKU(t *testing.T) {
+	tests := []struct {
+		name         string
+		pattern      string
+		productName  string
+		variantTypes []string
+		combo        []string
+		want         string
+	}{
+		{
+			name:         "default pattern",
+			productName:  "Linen Shirt",
+			variantTypes: []string{"Size", "Color"},
+			combo:        []string{"Small", "Black"},
+			want:         "linen-shirt-small-black",
+		},
+		{
+			name:         "variant type tokens",
+			pattern:      "LS-{Color}-{size}",
+			productName:  "Linen Shirt",
+			variantTypes: []string{"Size", "Color"},
+			combo:        []string{"Small", "Navy Blue"},
+			want:         "ls-navy-blue-small",
+		},
+		{
+			name:         "empty tokens leave no stray dashes",
+			pattern:      "{product-slug}-{color}-{size}",
+			productName:  "Tote",
+			variantTypes: []string{"Size", "Color"},
+			combo:        []string{"One Size", "!!"},
+			want:         "tote-one-size",
+		},
+		{
+			name:        "no combo values",
+			productName: "Gift Card",
+			want:        "gift-card",
+		},
+		{
+			name:         "more types than values",
+			pattern:      "{product-slug}-{size}-{color}",
+			productName:  "Cap",
+			variantTypes: []string{"Size", "Color"},
+			combo:        []string{"M"},
+			want:         "cap-m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateSKU(tt.pattern, tt.productName, tt.variantTypes, tt.combo); got != tt.want {
+				t.Errorf("GenerateSKU() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSKUTruncation(t *testing.T) {
+	tests := []struct {
+		name        string
+		productName string
+		want        string
+	}{
+		{
+			name:        "ascii cut at the limit",
+			productName: strings.Repeat("a", MaxSKULength+20),
+			want:        strings.Repeat("a", MaxSKULength),
+		},
+		{
+			name:        "multi-byte letters cut on characters, not bytes",
+			productName: strings.Repeat("ö", MaxSKULength+1),
+			want:        strings.Repeat("ö", MaxSKULength),
+		},
+		{
+			name:        "a dash left at the cut is trimmed",
+			productName: strings.Repeat("é", MaxSKULength-1) + " x",
+			want:        strings.Repeat("é", MaxSKULength-1),
+		},
+		{
+			name:        "at the limit in characters but over it in bytes is kept whole",
+			productName: strings.Repeat("ß", MaxSKULength),
+			want:        strings.Repeat("ß", MaxSKULength),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateSKU("{product-slug}", tt.productName, nil, nil)
+			if !utf8.ValidString(got) {
+				t.Fatalf("GenerateSKU() returned invalid UTF-8 %q", got)
+			}
+			if n := utf8.RuneCountInString(got); n > MaxSKULength {
+				t.Errorf("GenerateSKU() is %d characters, over %d", n, MaxSKULength)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateSKU() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidGTIN(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"4006381333931", true},  // EAN-13
+		{"4006381333932", false}, // Wrong check digit
+		{"036000291452", true},   // UPC-A
+		{"96385074", true},       // GTIN-8
+		{"10012345678902", true}, // GTIN-14
+		{"40063813339", false},   // 11 digits
+		{"40063813339a1", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsValidGTIN(tt.code); got != tt.want {
+			t.Errorf("IsValidGTIN(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}